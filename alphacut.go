@@ -0,0 +1,50 @@
+package fuzzy
+
+import "math"
+
+// AlphaCut returns the contiguous intervals of the domain of the membership where its
+// degree is greater than or equal to alpha, sampled at steps+1 evenly spaced points.
+// The bounds of the intervals are the outermost samples above the threshold, so they
+// are accurate to a step. A convex membership yields at most one interval, multi-modal
+// ones (i.e. aggregated outputs) may yield several. Unbounded domains yield no interval.
+func AlphaCut(m Membership, alpha float64, steps int) [][2]float64 {
+	min, max := m.Domain()
+	if math.IsInf(min, 0) || math.IsInf(max, 0) || min > max {
+		return nil
+	}
+
+	if steps < 1 || min == max {
+		steps = 1
+	}
+
+	step := (max - min) / float64(steps)
+
+	var (
+		intervals [][2]float64
+		start     = math.NaN()
+		previous  float64
+	)
+
+	for i := 0; i <= steps; i++ {
+		x := sampleAt(min, max, step, i, steps)
+
+		if m.Value(x) >= alpha {
+			if math.IsNaN(start) {
+				start = x
+			}
+			previous = x
+			continue
+		}
+
+		if !math.IsNaN(start) {
+			intervals = append(intervals, [2]float64{start, previous})
+			start = math.NaN()
+		}
+	}
+
+	if !math.IsNaN(start) {
+		intervals = append(intervals, [2]float64{start, previous})
+	}
+
+	return intervals
+}
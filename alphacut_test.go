@@ -0,0 +1,28 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAlphaCut(t *testing.T) {
+	testCases := []struct {
+		membership Membership
+		alpha      float64
+		expected   [][2]float64
+	}{
+		{membership: Triangular(0, 50, 100), alpha: 0.5, expected: [][2]float64{{25, 75}}},
+		{membership: Triangular(0, 50, 100), alpha: 1, expected: [][2]float64{{50, 50}}},
+		{membership: Trapezoid(0, 20, 60, 100), alpha: 0.5, expected: [][2]float64{{10, 80}}},
+		{membership: Max(Triangular(0, 20, 40), Triangular(60, 80, 100)), alpha: 0.5, expected: [][2]float64{{10, 30}, {70, 90}}},
+		{membership: Linear(0, 100), alpha: 0.75, expected: [][2]float64{{75, 100}}},
+		{membership: Triangular(0, 50, 100), alpha: 1.5, expected: nil},
+		{membership: Constant(1), alpha: 0.5, expected: [][2]float64{{1, 1}}},
+	}
+
+	for i, tc := range testCases {
+		if g, e := AlphaCut(tc.membership, tc.alpha, 100), tc.expected; !reflect.DeepEqual(g, e) {
+			t.Errorf("test case #%d: AlphaCut(): got '%v', expected '%v'", i, g, e)
+		}
+	}
+}
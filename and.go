@@ -1,8 +1,6 @@
 package fuzzy
 
 import (
-	"math"
-
 	"github.com/pkg/errors"
 )
 
@@ -10,19 +8,25 @@ type AndExpr struct {
 	exprs []Expr
 }
 
+// Value combines the operands with the t-norm of the context, the minimum by default
 func (e *AndExpr) Value(ctx *Context) (float64, error) {
-	min := math.Inf(1) // Initialize to positive infinity
+	var result float64
 
-	for _, m := range e.exprs {
+	for i, m := range e.exprs {
 		v, err := m.Value(ctx)
 		if err != nil {
 			return 0, errors.WithStack(err)
 		}
 
-		min = math.Min(min, v)
+		if i == 0 {
+			result = v
+			continue
+		}
+
+		result = ctx.norms.TNorm(result, v)
 	}
 
-	return min, nil
+	return result, nil
 }
 
 func (e *AndExpr) Exprs() []Expr {
@@ -0,0 +1,122 @@
+package fuzzy
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// countingMembership counts the evaluations of the wrapped membership
+type countingMembership struct {
+	Membership
+	evaluations atomic.Int64
+}
+
+func (m *countingMembership) Value(x float64) float64 {
+	m.evaluations.Add(1)
+	return m.Membership.Value(x)
+}
+
+func TestMembershipCache(t *testing.T) {
+	hot := &countingMembership{Membership: Linear(20, 40)}
+
+	engine := NewEngine(nil).
+		Variables(
+			NewVariable("temperature", NewTerm("hot", hot)),
+			NewVariable(
+				"fan",
+				NewTerm("slow", Triangular(0, 25, 50)),
+				NewTerm("fast", Triangular(50, 75, 100)),
+			),
+		).
+		Rules(
+			If(Is("temperature", "hot")).Then("fan", "fast"),
+			If(Not(Is("temperature", "hot"))).Then("fan", "slow"),
+			If(Very(Is("temperature", "hot"))).Then("fan", "fast"),
+		)
+
+	if _, err := engine.Infer(Values{"temperature": 30}); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := hot.evaluations.Load(), int64(1); g != e {
+		t.Errorf("cached evaluations: got '%v', expected '%v'", g, e)
+	}
+
+	// The cache does not outlive the inference
+	if _, err := engine.Infer(Values{"temperature": 35}); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := hot.evaluations.Load(), int64(2); g != e {
+		t.Errorf("cached evaluations after a second inference: got '%v', expected '%v'", g, e)
+	}
+
+	hot.evaluations.Store(0)
+
+	if _, err := engine.WithMembershipCache(false).Infer(Values{"temperature": 30}); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := hot.evaluations.Load(), int64(3); g != e {
+		t.Errorf("uncached evaluations: got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestMembershipCacheResults(t *testing.T) {
+	uncached := newLargeEngine(500).WithMembershipCache(false)
+
+	engines := map[string]*Engine{
+		"cached":          newLargeEngine(500),
+		"cached parallel": newLargeEngine(500).WithParallelism(4),
+	}
+
+	for temperature := 0.0; temperature <= 40; temperature += 2.5 {
+		for _, humidity := range []float64{10, 45, 90} {
+			values := Values{"temperature": temperature, "humidity": humidity}
+
+			expected, err := uncached.Infer(values)
+			if err != nil {
+				t.Fatalf("%+v", errors.WithStack(err))
+			}
+
+			for name, engine := range engines {
+				results, err := engine.Infer(values)
+				if err != nil {
+					t.Fatalf("%+v", errors.WithStack(err))
+				}
+
+				if g, e := len(results["comfort"]), len(expected["comfort"]); g != e {
+					t.Errorf("%s %v: len(results): got '%v', expected '%v'", name, values, g, e)
+				}
+
+				for term, result := range expected["comfort"] {
+					if g, e := results["comfort"][term].TruthDegree(), result.TruthDegree(); g != e {
+						t.Errorf("%s %v: comfort IS %s: got '%v', expected '%v'", name, values, term, g, e)
+					}
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkInferMembershipCache(b *testing.B) {
+	values := Values{"temperature": 22, "humidity": 45}
+
+	for _, bc := range []struct {
+		name   string
+		engine *Engine
+	}{
+		{name: "cached", engine: newLargeEngine(5000)},
+		{name: "uncached", engine: newLargeEngine(5000).WithMembershipCache(false)},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				if _, err := bc.engine.Infer(values); err != nil {
+					b.Fatalf("%+v", errors.WithStack(err))
+				}
+			}
+		})
+	}
+}
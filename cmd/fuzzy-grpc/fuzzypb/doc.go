@@ -0,0 +1,5 @@
+// Package fuzzypb holds the protocol buffers messages and the gRPC service of the
+// fuzzy-grpc server, generated from fuzzy.proto.
+package fuzzypb
+
+//go:generate buf generate
@@ -0,0 +1,883 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: fuzzy.proto
+
+package fuzzypb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListEnginesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEnginesRequest) Reset() {
+	*x = ListEnginesRequest{}
+	mi := &file_fuzzy_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEnginesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEnginesRequest) ProtoMessage() {}
+
+func (x *ListEnginesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fuzzy_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEnginesRequest.ProtoReflect.Descriptor instead.
+func (*ListEnginesRequest) Descriptor() ([]byte, []int) {
+	return file_fuzzy_proto_rawDescGZIP(), []int{0}
+}
+
+type ListEnginesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Engines       []string               `protobuf:"bytes,1,rep,name=engines,proto3" json:"engines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEnginesResponse) Reset() {
+	*x = ListEnginesResponse{}
+	mi := &file_fuzzy_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEnginesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEnginesResponse) ProtoMessage() {}
+
+func (x *ListEnginesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fuzzy_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEnginesResponse.ProtoReflect.Descriptor instead.
+func (*ListEnginesResponse) Descriptor() ([]byte, []int) {
+	return file_fuzzy_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListEnginesResponse) GetEngines() []string {
+	if x != nil {
+		return x.Engines
+	}
+	return nil
+}
+
+type GetEngineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEngineRequest) Reset() {
+	*x = GetEngineRequest{}
+	mi := &file_fuzzy_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEngineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEngineRequest) ProtoMessage() {}
+
+func (x *GetEngineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fuzzy_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEngineRequest.ProtoReflect.Descriptor instead.
+func (*GetEngineRequest) Descriptor() ([]byte, []int) {
+	return file_fuzzy_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetEngineRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetEngineResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Variables []*Variable            `protobuf:"bytes,2,rep,name=variables,proto3" json:"variables,omitempty"`
+	// Potential issues reported by fuzzy.Lint(), such as contradictory rules
+	Warnings      []string `protobuf:"bytes,3,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	Rules         []*Rule  `protobuf:"bytes,4,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEngineResponse) Reset() {
+	*x = GetEngineResponse{}
+	mi := &file_fuzzy_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEngineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEngineResponse) ProtoMessage() {}
+
+func (x *GetEngineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fuzzy_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEngineResponse.ProtoReflect.Descriptor instead.
+func (*GetEngineResponse) Descriptor() ([]byte, []int) {
+	return file_fuzzy_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetEngineResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetEngineResponse) GetVariables() []*Variable {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *GetEngineResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *GetEngineResponse) GetRules() []*Rule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type Variable struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	UniverseMin   float64                `protobuf:"fixed64,2,opt,name=universe_min,json=universeMin,proto3" json:"universe_min,omitempty"`
+	UniverseMax   float64                `protobuf:"fixed64,3,opt,name=universe_max,json=universeMax,proto3" json:"universe_max,omitempty"`
+	Terms         []*Term                `protobuf:"bytes,4,rep,name=terms,proto3" json:"terms,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Variable) Reset() {
+	*x = Variable{}
+	mi := &file_fuzzy_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Variable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Variable) ProtoMessage() {}
+
+func (x *Variable) ProtoReflect() protoreflect.Message {
+	mi := &file_fuzzy_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Variable.ProtoReflect.Descriptor instead.
+func (*Variable) Descriptor() ([]byte, []int) {
+	return file_fuzzy_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Variable) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Variable) GetUniverseMin() float64 {
+	if x != nil {
+		return x.UniverseMin
+	}
+	return 0
+}
+
+func (x *Variable) GetUniverseMax() float64 {
+	if x != nil {
+		return x.UniverseMax
+	}
+	return 0
+}
+
+func (x *Variable) GetTerms() []*Term {
+	if x != nil {
+		return x.Terms
+	}
+	return nil
+}
+
+func (x *Variable) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type Term struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DomainMin     float64                `protobuf:"fixed64,2,opt,name=domain_min,json=domainMin,proto3" json:"domain_min,omitempty"`
+	DomainMax     float64                `protobuf:"fixed64,3,opt,name=domain_max,json=domainMax,proto3" json:"domain_max,omitempty"`
+	Weight        float64                `protobuf:"fixed64,4,opt,name=weight,proto3" json:"weight,omitempty"`
+	Membership    *Membership            `protobuf:"bytes,5,opt,name=membership,proto3" json:"membership,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Term) Reset() {
+	*x = Term{}
+	mi := &file_fuzzy_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Term) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Term) ProtoMessage() {}
+
+func (x *Term) ProtoReflect() protoreflect.Message {
+	mi := &file_fuzzy_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Term.ProtoReflect.Descriptor instead.
+func (*Term) Descriptor() ([]byte, []int) {
+	return file_fuzzy_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Term) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Term) GetDomainMin() float64 {
+	if x != nil {
+		return x.DomainMin
+	}
+	return 0
+}
+
+func (x *Term) GetDomainMax() float64 {
+	if x != nil {
+		return x.DomainMax
+	}
+	return 0
+}
+
+func (x *Term) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Term) GetMembership() *Membership {
+	if x != nil {
+		return x.Membership
+	}
+	return nil
+}
+
+// Membership mirrors fuzzy.MembershipDefinition, i.e. {type: "TRIANGULAR", params: [0, 5, 10]}
+type Membership struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Params        []float64              `protobuf:"fixed64,2,rep,packed,name=params,proto3" json:"params,omitempty"`
+	Children      []*Membership          `protobuf:"bytes,3,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Membership) Reset() {
+	*x = Membership{}
+	mi := &file_fuzzy_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Membership) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Membership) ProtoMessage() {}
+
+func (x *Membership) ProtoReflect() protoreflect.Message {
+	mi := &file_fuzzy_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Membership.ProtoReflect.Descriptor instead.
+func (*Membership) Descriptor() ([]byte, []int) {
+	return file_fuzzy_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Membership) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Membership) GetParams() []float64 {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *Membership) GetChildren() []*Membership {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type Rule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The rule formatted with fuzzy.FormatRule()
+	Rule          string            `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	Metadata      map[string]string `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rule) Reset() {
+	*x = Rule{}
+	mi := &file_fuzzy_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rule) ProtoMessage() {}
+
+func (x *Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_fuzzy_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rule.ProtoReflect.Descriptor instead.
+func (*Rule) Descriptor() ([]byte, []int) {
+	return file_fuzzy_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Rule) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *Rule) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type InferRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Engine string                 `protobuf:"bytes,1,opt,name=engine,proto3" json:"engine,omitempty"`
+	Inputs map[string]float64     `protobuf:"bytes,2,rep,name=inputs,proto3" json:"inputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	// Defuzzification function, "centroid" (default) or "mean-max"
+	Defuzz string `protobuf:"bytes,3,opt,name=defuzz,proto3" json:"defuzz,omitempty"`
+	// Number of sampling steps of the defuzzification function, 100 if zero
+	Steps int32 `protobuf:"varint,4,opt,name=steps,proto3" json:"steps,omitempty"`
+	// Defuzzification functions overriding defuzz for the given output variables
+	VariableDefuzz map[string]string `protobuf:"bytes,5,rep,name=variable_defuzz,json=variableDefuzz,proto3" json:"variable_defuzz,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *InferRequest) Reset() {
+	*x = InferRequest{}
+	mi := &file_fuzzy_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InferRequest) ProtoMessage() {}
+
+func (x *InferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fuzzy_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InferRequest.ProtoReflect.Descriptor instead.
+func (*InferRequest) Descriptor() ([]byte, []int) {
+	return file_fuzzy_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *InferRequest) GetEngine() string {
+	if x != nil {
+		return x.Engine
+	}
+	return ""
+}
+
+func (x *InferRequest) GetInputs() map[string]float64 {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *InferRequest) GetDefuzz() string {
+	if x != nil {
+		return x.Defuzz
+	}
+	return ""
+}
+
+func (x *InferRequest) GetSteps() int32 {
+	if x != nil {
+		return x.Steps
+	}
+	return 0
+}
+
+func (x *InferRequest) GetVariableDefuzz() map[string]string {
+	if x != nil {
+		return x.VariableDefuzz
+	}
+	return nil
+}
+
+type InferResponse struct {
+	state   protoimpl.MessageState     `protogen:"open.v1"`
+	Results map[string]*VariableResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Name of the defuzzification function in use
+	Defuzzifier   string `protobuf:"bytes,2,opt,name=defuzzifier,proto3" json:"defuzzifier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InferResponse) Reset() {
+	*x = InferResponse{}
+	mi := &file_fuzzy_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InferResponse) ProtoMessage() {}
+
+func (x *InferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fuzzy_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InferResponse.ProtoReflect.Descriptor instead.
+func (*InferResponse) Descriptor() ([]byte, []int) {
+	return file_fuzzy_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *InferResponse) GetResults() map[string]*VariableResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *InferResponse) GetDefuzzifier() string {
+	if x != nil {
+		return x.Defuzzifier
+	}
+	return ""
+}
+
+type VariableResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         float64                `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	Best          string                 `protobuf:"bytes,2,opt,name=best,proto3" json:"best,omitempty"`
+	Terms         map[string]*TermResult `protobuf:"bytes,3,rep,name=terms,proto3" json:"terms,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VariableResult) Reset() {
+	*x = VariableResult{}
+	mi := &file_fuzzy_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VariableResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VariableResult) ProtoMessage() {}
+
+func (x *VariableResult) ProtoReflect() protoreflect.Message {
+	mi := &file_fuzzy_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VariableResult.ProtoReflect.Descriptor instead.
+func (*VariableResult) Descriptor() ([]byte, []int) {
+	return file_fuzzy_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *VariableResult) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *VariableResult) GetBest() string {
+	if x != nil {
+		return x.Best
+	}
+	return ""
+}
+
+func (x *VariableResult) GetTerms() map[string]*TermResult {
+	if x != nil {
+		return x.Terms
+	}
+	return nil
+}
+
+type TermResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TruthDegree   float64                `protobuf:"fixed64,1,opt,name=truth_degree,json=truthDegree,proto3" json:"truth_degree,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TermResult) Reset() {
+	*x = TermResult{}
+	mi := &file_fuzzy_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TermResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TermResult) ProtoMessage() {}
+
+func (x *TermResult) ProtoReflect() protoreflect.Message {
+	mi := &file_fuzzy_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TermResult.ProtoReflect.Descriptor instead.
+func (*TermResult) Descriptor() ([]byte, []int) {
+	return file_fuzzy_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TermResult) GetTruthDegree() float64 {
+	if x != nil {
+		return x.TruthDegree
+	}
+	return 0
+}
+
+var File_fuzzy_proto protoreflect.FileDescriptor
+
+const file_fuzzy_proto_rawDesc = "" +
+	"\n" +
+	"\vfuzzy.proto\x12\bfuzzy.v1\"\x14\n" +
+	"\x12ListEnginesRequest\"/\n" +
+	"\x13ListEnginesResponse\x12\x18\n" +
+	"\aengines\x18\x01 \x03(\tR\aengines\"&\n" +
+	"\x10GetEngineRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x9b\x01\n" +
+	"\x11GetEngineResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x120\n" +
+	"\tvariables\x18\x02 \x03(\v2\x12.fuzzy.v1.VariableR\tvariables\x12\x1a\n" +
+	"\bwarnings\x18\x03 \x03(\tR\bwarnings\x12$\n" +
+	"\x05rules\x18\x04 \x03(\v2\x0e.fuzzy.v1.RuleR\x05rules\"\x85\x02\n" +
+	"\bVariable\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\funiverse_min\x18\x02 \x01(\x01R\vuniverseMin\x12!\n" +
+	"\funiverse_max\x18\x03 \x01(\x01R\vuniverseMax\x12$\n" +
+	"\x05terms\x18\x04 \x03(\v2\x0e.fuzzy.v1.TermR\x05terms\x12<\n" +
+	"\bmetadata\x18\x05 \x03(\v2 .fuzzy.v1.Variable.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa6\x01\n" +
+	"\x04Term\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"domain_min\x18\x02 \x01(\x01R\tdomainMin\x12\x1d\n" +
+	"\n" +
+	"domain_max\x18\x03 \x01(\x01R\tdomainMax\x12\x16\n" +
+	"\x06weight\x18\x04 \x01(\x01R\x06weight\x124\n" +
+	"\n" +
+	"membership\x18\x05 \x01(\v2\x14.fuzzy.v1.MembershipR\n" +
+	"membership\"j\n" +
+	"\n" +
+	"Membership\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
+	"\x06params\x18\x02 \x03(\x01R\x06params\x120\n" +
+	"\bchildren\x18\x03 \x03(\v2\x14.fuzzy.v1.MembershipR\bchildren\"\x91\x01\n" +
+	"\x04Rule\x12\x12\n" +
+	"\x04rule\x18\x01 \x01(\tR\x04rule\x128\n" +
+	"\bmetadata\x18\x02 \x03(\v2\x1c.fuzzy.v1.Rule.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe3\x02\n" +
+	"\fInferRequest\x12\x16\n" +
+	"\x06engine\x18\x01 \x01(\tR\x06engine\x12:\n" +
+	"\x06inputs\x18\x02 \x03(\v2\".fuzzy.v1.InferRequest.InputsEntryR\x06inputs\x12\x16\n" +
+	"\x06defuzz\x18\x03 \x01(\tR\x06defuzz\x12\x14\n" +
+	"\x05steps\x18\x04 \x01(\x05R\x05steps\x12S\n" +
+	"\x0fvariable_defuzz\x18\x05 \x03(\v2*.fuzzy.v1.InferRequest.VariableDefuzzEntryR\x0evariableDefuzz\x1a9\n" +
+	"\vInputsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1aA\n" +
+	"\x13VariableDefuzzEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc7\x01\n" +
+	"\rInferResponse\x12>\n" +
+	"\aresults\x18\x01 \x03(\v2$.fuzzy.v1.InferResponse.ResultsEntryR\aresults\x12 \n" +
+	"\vdefuzzifier\x18\x02 \x01(\tR\vdefuzzifier\x1aT\n" +
+	"\fResultsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12.\n" +
+	"\x05value\x18\x02 \x01(\v2\x18.fuzzy.v1.VariableResultR\x05value:\x028\x01\"\xc5\x01\n" +
+	"\x0eVariableResult\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x01R\x05value\x12\x12\n" +
+	"\x04best\x18\x02 \x01(\tR\x04best\x129\n" +
+	"\x05terms\x18\x03 \x03(\v2#.fuzzy.v1.VariableResult.TermsEntryR\x05terms\x1aN\n" +
+	"\n" +
+	"TermsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
+	"\x05value\x18\x02 \x01(\v2\x14.fuzzy.v1.TermResultR\x05value:\x028\x01\"/\n" +
+	"\n" +
+	"TermResult\x12!\n" +
+	"\ftruth_degree\x18\x01 \x01(\x01R\vtruthDegree2\x99\x02\n" +
+	"\aEngines\x12J\n" +
+	"\vListEngines\x12\x1c.fuzzy.v1.ListEnginesRequest\x1a\x1d.fuzzy.v1.ListEnginesResponse\x12D\n" +
+	"\tGetEngine\x12\x1a.fuzzy.v1.GetEngineRequest\x1a\x1b.fuzzy.v1.GetEngineResponse\x128\n" +
+	"\x05Infer\x12\x16.fuzzy.v1.InferRequest\x1a\x17.fuzzy.v1.InferResponse\x12B\n" +
+	"\vInferStream\x12\x16.fuzzy.v1.InferRequest\x1a\x17.fuzzy.v1.InferResponse(\x010\x01B5Z3github.com/bornholm/go-fuzzy/cmd/fuzzy-grpc/fuzzypbb\x06proto3"
+
+var (
+	file_fuzzy_proto_rawDescOnce sync.Once
+	file_fuzzy_proto_rawDescData []byte
+)
+
+func file_fuzzy_proto_rawDescGZIP() []byte {
+	file_fuzzy_proto_rawDescOnce.Do(func() {
+		file_fuzzy_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_fuzzy_proto_rawDesc), len(file_fuzzy_proto_rawDesc)))
+	})
+	return file_fuzzy_proto_rawDescData
+}
+
+var file_fuzzy_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_fuzzy_proto_goTypes = []any{
+	(*ListEnginesRequest)(nil),  // 0: fuzzy.v1.ListEnginesRequest
+	(*ListEnginesResponse)(nil), // 1: fuzzy.v1.ListEnginesResponse
+	(*GetEngineRequest)(nil),    // 2: fuzzy.v1.GetEngineRequest
+	(*GetEngineResponse)(nil),   // 3: fuzzy.v1.GetEngineResponse
+	(*Variable)(nil),            // 4: fuzzy.v1.Variable
+	(*Term)(nil),                // 5: fuzzy.v1.Term
+	(*Membership)(nil),          // 6: fuzzy.v1.Membership
+	(*Rule)(nil),                // 7: fuzzy.v1.Rule
+	(*InferRequest)(nil),        // 8: fuzzy.v1.InferRequest
+	(*InferResponse)(nil),       // 9: fuzzy.v1.InferResponse
+	(*VariableResult)(nil),      // 10: fuzzy.v1.VariableResult
+	(*TermResult)(nil),          // 11: fuzzy.v1.TermResult
+	nil,                         // 12: fuzzy.v1.Variable.MetadataEntry
+	nil,                         // 13: fuzzy.v1.Rule.MetadataEntry
+	nil,                         // 14: fuzzy.v1.InferRequest.InputsEntry
+	nil,                         // 15: fuzzy.v1.InferRequest.VariableDefuzzEntry
+	nil,                         // 16: fuzzy.v1.InferResponse.ResultsEntry
+	nil,                         // 17: fuzzy.v1.VariableResult.TermsEntry
+}
+var file_fuzzy_proto_depIdxs = []int32{
+	4,  // 0: fuzzy.v1.GetEngineResponse.variables:type_name -> fuzzy.v1.Variable
+	7,  // 1: fuzzy.v1.GetEngineResponse.rules:type_name -> fuzzy.v1.Rule
+	5,  // 2: fuzzy.v1.Variable.terms:type_name -> fuzzy.v1.Term
+	12, // 3: fuzzy.v1.Variable.metadata:type_name -> fuzzy.v1.Variable.MetadataEntry
+	6,  // 4: fuzzy.v1.Term.membership:type_name -> fuzzy.v1.Membership
+	6,  // 5: fuzzy.v1.Membership.children:type_name -> fuzzy.v1.Membership
+	13, // 6: fuzzy.v1.Rule.metadata:type_name -> fuzzy.v1.Rule.MetadataEntry
+	14, // 7: fuzzy.v1.InferRequest.inputs:type_name -> fuzzy.v1.InferRequest.InputsEntry
+	15, // 8: fuzzy.v1.InferRequest.variable_defuzz:type_name -> fuzzy.v1.InferRequest.VariableDefuzzEntry
+	16, // 9: fuzzy.v1.InferResponse.results:type_name -> fuzzy.v1.InferResponse.ResultsEntry
+	17, // 10: fuzzy.v1.VariableResult.terms:type_name -> fuzzy.v1.VariableResult.TermsEntry
+	10, // 11: fuzzy.v1.InferResponse.ResultsEntry.value:type_name -> fuzzy.v1.VariableResult
+	11, // 12: fuzzy.v1.VariableResult.TermsEntry.value:type_name -> fuzzy.v1.TermResult
+	0,  // 13: fuzzy.v1.Engines.ListEngines:input_type -> fuzzy.v1.ListEnginesRequest
+	2,  // 14: fuzzy.v1.Engines.GetEngine:input_type -> fuzzy.v1.GetEngineRequest
+	8,  // 15: fuzzy.v1.Engines.Infer:input_type -> fuzzy.v1.InferRequest
+	8,  // 16: fuzzy.v1.Engines.InferStream:input_type -> fuzzy.v1.InferRequest
+	1,  // 17: fuzzy.v1.Engines.ListEngines:output_type -> fuzzy.v1.ListEnginesResponse
+	3,  // 18: fuzzy.v1.Engines.GetEngine:output_type -> fuzzy.v1.GetEngineResponse
+	9,  // 19: fuzzy.v1.Engines.Infer:output_type -> fuzzy.v1.InferResponse
+	9,  // 20: fuzzy.v1.Engines.InferStream:output_type -> fuzzy.v1.InferResponse
+	17, // [17:21] is the sub-list for method output_type
+	13, // [13:17] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_fuzzy_proto_init() }
+func file_fuzzy_proto_init() {
+	if File_fuzzy_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_fuzzy_proto_rawDesc), len(file_fuzzy_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_fuzzy_proto_goTypes,
+		DependencyIndexes: file_fuzzy_proto_depIdxs,
+		MessageInfos:      file_fuzzy_proto_msgTypes,
+	}.Build()
+	File_fuzzy_proto = out.File
+	file_fuzzy_proto_goTypes = nil
+	file_fuzzy_proto_depIdxs = nil
+}
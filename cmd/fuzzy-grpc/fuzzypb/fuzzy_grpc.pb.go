@@ -0,0 +1,244 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: fuzzy.proto
+
+package fuzzypb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Engines_ListEngines_FullMethodName = "/fuzzy.v1.Engines/ListEngines"
+	Engines_GetEngine_FullMethodName   = "/fuzzy.v1.Engines/GetEngine"
+	Engines_Infer_FullMethodName       = "/fuzzy.v1.Engines/Infer"
+	Engines_InferStream_FullMethodName = "/fuzzy.v1.Engines/InferStream"
+)
+
+// EnginesClient is the client API for Engines service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Engines exposes the fuzzy engines loaded by the server
+type EnginesClient interface {
+	// ListEngines returns the names of the loaded engines
+	ListEngines(ctx context.Context, in *ListEnginesRequest, opts ...grpc.CallOption) (*ListEnginesResponse, error)
+	// GetEngine returns the definition of the given engine
+	GetEngine(ctx context.Context, in *GetEngineRequest, opts ...grpc.CallOption) (*GetEngineResponse, error)
+	// Infer runs the inference of the given engine with the given inputs
+	Infer(ctx context.Context, in *InferRequest, opts ...grpc.CallOption) (*InferResponse, error)
+	// InferStream runs an inference for each request received on the stream,
+	// sending the responses in the same order
+	InferStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[InferRequest, InferResponse], error)
+}
+
+type enginesClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEnginesClient(cc grpc.ClientConnInterface) EnginesClient {
+	return &enginesClient{cc}
+}
+
+func (c *enginesClient) ListEngines(ctx context.Context, in *ListEnginesRequest, opts ...grpc.CallOption) (*ListEnginesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEnginesResponse)
+	err := c.cc.Invoke(ctx, Engines_ListEngines_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *enginesClient) GetEngine(ctx context.Context, in *GetEngineRequest, opts ...grpc.CallOption) (*GetEngineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEngineResponse)
+	err := c.cc.Invoke(ctx, Engines_GetEngine_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *enginesClient) Infer(ctx context.Context, in *InferRequest, opts ...grpc.CallOption) (*InferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InferResponse)
+	err := c.cc.Invoke(ctx, Engines_Infer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *enginesClient) InferStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[InferRequest, InferResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Engines_ServiceDesc.Streams[0], Engines_InferStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[InferRequest, InferResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Engines_InferStreamClient = grpc.BidiStreamingClient[InferRequest, InferResponse]
+
+// EnginesServer is the server API for Engines service.
+// All implementations must embed UnimplementedEnginesServer
+// for forward compatibility.
+//
+// Engines exposes the fuzzy engines loaded by the server
+type EnginesServer interface {
+	// ListEngines returns the names of the loaded engines
+	ListEngines(context.Context, *ListEnginesRequest) (*ListEnginesResponse, error)
+	// GetEngine returns the definition of the given engine
+	GetEngine(context.Context, *GetEngineRequest) (*GetEngineResponse, error)
+	// Infer runs the inference of the given engine with the given inputs
+	Infer(context.Context, *InferRequest) (*InferResponse, error)
+	// InferStream runs an inference for each request received on the stream,
+	// sending the responses in the same order
+	InferStream(grpc.BidiStreamingServer[InferRequest, InferResponse]) error
+	mustEmbedUnimplementedEnginesServer()
+}
+
+// UnimplementedEnginesServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEnginesServer struct{}
+
+func (UnimplementedEnginesServer) ListEngines(context.Context, *ListEnginesRequest) (*ListEnginesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListEngines not implemented")
+}
+func (UnimplementedEnginesServer) GetEngine(context.Context, *GetEngineRequest) (*GetEngineResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetEngine not implemented")
+}
+func (UnimplementedEnginesServer) Infer(context.Context, *InferRequest) (*InferResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Infer not implemented")
+}
+func (UnimplementedEnginesServer) InferStream(grpc.BidiStreamingServer[InferRequest, InferResponse]) error {
+	return status.Error(codes.Unimplemented, "method InferStream not implemented")
+}
+func (UnimplementedEnginesServer) mustEmbedUnimplementedEnginesServer() {}
+func (UnimplementedEnginesServer) testEmbeddedByValue()                 {}
+
+// UnsafeEnginesServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EnginesServer will
+// result in compilation errors.
+type UnsafeEnginesServer interface {
+	mustEmbedUnimplementedEnginesServer()
+}
+
+func RegisterEnginesServer(s grpc.ServiceRegistrar, srv EnginesServer) {
+	// If the following call panics, it indicates UnimplementedEnginesServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Engines_ServiceDesc, srv)
+}
+
+func _Engines_ListEngines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEnginesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnginesServer).ListEngines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Engines_ListEngines_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnginesServer).ListEngines(ctx, req.(*ListEnginesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Engines_GetEngine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEngineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnginesServer).GetEngine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Engines_GetEngine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnginesServer).GetEngine(ctx, req.(*GetEngineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Engines_Infer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnginesServer).Infer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Engines_Infer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnginesServer).Infer(ctx, req.(*InferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Engines_InferStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EnginesServer).InferStream(&grpc.GenericServerStream[InferRequest, InferResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Engines_InferStreamServer = grpc.BidiStreamingServer[InferRequest, InferResponse]
+
+// Engines_ServiceDesc is the grpc.ServiceDesc for Engines service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Engines_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "fuzzy.v1.Engines",
+	HandlerType: (*EnginesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListEngines",
+			Handler:    _Engines_ListEngines_Handler,
+		},
+		{
+			MethodName: "GetEngine",
+			Handler:    _Engines_GetEngine_Handler,
+		},
+		{
+			MethodName: "Infer",
+			Handler:    _Engines_Infer_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "InferStream",
+			Handler:       _Engines_InferStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "fuzzy.proto",
+}
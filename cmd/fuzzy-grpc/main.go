@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/bornholm/go-fuzzy/cmd/fuzzy-grpc/fuzzypb"
+	"github.com/bornholm/go-fuzzy/cmd/internal/definitions"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// Configuration for the server
+type Config struct {
+	Address     string
+	Definitions string
+	// Watch enables the reloading of the definitions when their files change,
+	// checked every WatchInterval
+	Watch         bool
+	WatchInterval time.Duration
+}
+
+func parseConfig() *Config {
+	config := &Config{}
+
+	flag.StringVar(&config.Address, "port", ":3004", "address to listen on")
+	flag.StringVar(&config.Definitions, "definitions", "*.fuzzy", "dsl file pattern to load")
+	flag.BoolVar(&config.Watch, "watch", false, "reload the definitions when their files change")
+	flag.DurationVar(&config.WatchInterval, "watch-interval", 2*time.Second, "interval between checks of the definition files with -watch")
+
+	flag.Parse()
+
+	return config
+}
+
+func main() {
+	config := parseConfig()
+
+	log.Printf("Loading fuzzy engine definition files from pattern '%s'", config.Definitions)
+
+	dslFiles, err := definitions.LoadFiles(config.Definitions)
+	if err != nil {
+		log.Fatalf("Failed to load dsl files: %v", err)
+	}
+
+	if len(dslFiles) == 0 {
+		log.Printf("No files found with pattern '%s'", config.Definitions)
+	} else {
+		engineNames := make([]string, 0, len(dslFiles))
+		for name := range dslFiles {
+			engineNames = append(engineNames, name)
+		}
+		log.Printf("Loaded %d definition files: %v", len(dslFiles), strings.Join(engineNames, ", "))
+	}
+
+	registry, err := definitions.NewRegistryFromDSL(dslFiles)
+	if err != nil {
+		log.Fatalf("Failed to create engines: %v", err)
+	}
+
+	if config.Watch {
+		if err := definitions.Watch(context.Background(), registry, config.Definitions, config.WatchInterval); err != nil {
+			log.Fatalf("Failed to watch definition files: %v", err)
+		}
+
+		log.Printf("Watching definition files every %s", config.WatchInterval)
+	}
+
+	listener, err := net.Listen("tcp", config.Address)
+	if err != nil {
+		log.Fatalf("Failed to listen on %s: %v", config.Address, err)
+	}
+
+	server := grpc.NewServer()
+	fuzzypb.RegisterEnginesServer(server, NewServer(registry))
+
+	// Allows clients like grpcurl to discover the service
+	reflection.Register(server)
+
+	log.Printf("Starting gRPC server on %s", config.Address)
+	log.Fatal(server.Serve(listener))
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/bornholm/go-fuzzy"
+	"github.com/bornholm/go-fuzzy/cmd/fuzzy-grpc/fuzzypb"
+	"github.com/bornholm/go-fuzzy/cmd/internal/definitions"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements the Engines gRPC service over the engines of the registry
+type Server struct {
+	fuzzypb.UnimplementedEnginesServer
+
+	registry *definitions.Registry
+}
+
+// NewServer creates a gRPC service serving the engines of the given registry
+func NewServer(registry *definitions.Registry) *Server {
+	return &Server{
+		registry: registry,
+	}
+}
+
+// ListEngines implements fuzzypb.EnginesServer
+func (s *Server) ListEngines(ctx context.Context, req *fuzzypb.ListEnginesRequest) (*fuzzypb.ListEnginesResponse, error) {
+	return &fuzzypb.ListEnginesResponse{
+		Engines: s.registry.Names(),
+	}, nil
+}
+
+// GetEngine implements fuzzypb.EnginesServer
+func (s *Server) GetEngine(ctx context.Context, req *fuzzypb.GetEngineRequest) (*fuzzypb.GetEngineResponse, error) {
+	variables, rules, exists := s.registry.Get(req.GetName())
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "engine '%s' not found", req.GetName())
+	}
+
+	response := &fuzzypb.GetEngineResponse{
+		Name:      req.GetName(),
+		Variables: make([]*fuzzypb.Variable, 0, len(variables)),
+		Rules:     make([]*fuzzypb.Rule, 0, len(rules)),
+		Warnings:  make([]string, 0),
+	}
+
+	for _, v := range variables {
+		variable, err := newVariable(v)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "could not describe variable '%s': %v", v.Name(), err)
+		}
+
+		response.Variables = append(response.Variables, variable)
+	}
+
+	for _, r := range rules {
+		response.Rules = append(response.Rules, &fuzzypb.Rule{
+			Rule:     fuzzy.FormatRule(r),
+			Metadata: r.Metadata(),
+		})
+	}
+
+	for _, w := range fuzzy.Lint(variables, rules) {
+		response.Warnings = append(response.Warnings, w.String())
+	}
+
+	return response, nil
+}
+
+// Infer implements fuzzypb.EnginesServer
+func (s *Server) Infer(ctx context.Context, req *fuzzypb.InferRequest) (*fuzzypb.InferResponse, error) {
+	return s.infer(ctx, req)
+}
+
+// InferStream implements fuzzypb.EnginesServer. The stream is closed with the
+// error of the first failing inference.
+func (s *Server) InferStream(stream fuzzypb.Engines_InferStreamServer) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		response, err := s.infer(stream.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(response); err != nil {
+			return err
+		}
+	}
+}
+
+// infer runs the inference of the requested engine, the returned errors being gRPC statuses
+func (s *Server) infer(ctx context.Context, req *fuzzypb.InferRequest) (*fuzzypb.InferResponse, error) {
+	variables, rules, exists := s.registry.Get(req.GetEngine())
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "engine '%s' not found", req.GetEngine())
+	}
+
+	engine, err := engineFromRequest(req, variables, rules)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	results, err := engine.InferContext(ctx, req.GetInputs())
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, status.FromContextError(ctxErr).Err()
+		}
+
+		return nil, status.Errorf(codes.Internal, "inference error: %v", err)
+	}
+
+	response, err := newInferResponse(engine, results)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not defuzzify value: %v", err)
+	}
+
+	return response, nil
+}
+
+// engineFromRequest creates an engine for the given definition, using the
+// defuzzification functions selected by the request
+func engineFromRequest(req *fuzzypb.InferRequest, variables []*fuzzy.Variable, rules []*fuzzy.Rule) (*fuzzy.Engine, error) {
+	engine, err := definitions.NewInferenceEngine(variables, rules, definitions.Defuzzification{
+		Name:      req.GetDefuzz(),
+		Steps:     int(req.GetSteps()),
+		Variables: req.GetVariableDefuzz(),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return engine, nil
+}
+
+// newInferResponse converts the inference results to their protocol buffers representation
+func newInferResponse(engine *fuzzy.Engine, results fuzzy.Results) (*fuzzypb.InferResponse, error) {
+	inference, err := definitions.NewInferenceResult(engine, results)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response := &fuzzypb.InferResponse{
+		Defuzzifier: inference.Defuzzifier,
+		Results:     make(map[string]*fuzzypb.VariableResult, len(inference.Variables)),
+	}
+
+	for name, r := range inference.Variables {
+		result := &fuzzypb.VariableResult{
+			Value: r.Value,
+			Best:  r.Best,
+			Terms: make(map[string]*fuzzypb.TermResult, len(r.Terms)),
+		}
+
+		for term, truthDegree := range r.Terms {
+			result.Terms[term] = &fuzzypb.TermResult{TruthDegree: truthDegree}
+		}
+
+		response.Results[name] = result
+	}
+
+	return response, nil
+}
+
+// newVariable describes the variable and the memberships of its terms, sorted by name
+func newVariable(v *fuzzy.Variable) (*fuzzypb.Variable, error) {
+	terms := v.Terms()
+	slices.SortFunc(terms, func(a, b *fuzzy.Term) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	variable := &fuzzypb.Variable{
+		Name:        v.Name(),
+		UniverseMin: v.UniverseMin(),
+		UniverseMax: v.UniverseMax(),
+		Terms:       make([]*fuzzypb.Term, 0, len(terms)),
+		Metadata:    v.Metadata(),
+	}
+
+	for _, t := range terms {
+		membership, err := fuzzy.DescribeMembership(t.Membership())
+		if err != nil {
+			return nil, errors.Wrapf(err, "term '%s'", t.Name())
+		}
+
+		min, max := t.Domain()
+
+		variable.Terms = append(variable.Terms, &fuzzypb.Term{
+			Name:       t.Name(),
+			DomainMin:  min,
+			DomainMax:  max,
+			Weight:     t.Weight(),
+			Membership: newMembership(membership),
+		})
+	}
+
+	return variable, nil
+}
+
+func newMembership(def fuzzy.MembershipDefinition) *fuzzypb.Membership {
+	membership := &fuzzypb.Membership{
+		Type:   def.Type,
+		Params: def.Params,
+	}
+
+	for _, c := range def.Children {
+		membership.Children = append(membership.Children, newMembership(c))
+	}
+
+	return membership
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/bornholm/go-fuzzy/cmd/fuzzy-grpc/fuzzypb"
+	"github.com/bornholm/go-fuzzy/cmd/internal/definitions"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const thermostat = `
+DEFINE temperature ( TERM cold LINEAR (20, 0), TERM hot LINEAR (20, 30) );
+DEFINE heating ( TERM low TRIANGULAR (0, 25, 50), TERM high TRIANGULAR (50, 75, 100) );
+
+IF temperature IS cold THEN heating IS high;
+IF temperature IS hot THEN heating IS low;
+`
+
+func newTestClient(t *testing.T) fuzzypb.EnginesClient {
+	registry, err := definitions.NewRegistryFromDSL(map[string]string{"thermostat": thermostat})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	listener := bufconn.Listen(1 << 20)
+
+	server := grpc.NewServer()
+	fuzzypb.RegisterEnginesServer(server, NewServer(registry))
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	t.Cleanup(func() { conn.Close() })
+
+	return fuzzypb.NewEnginesClient(conn)
+}
+
+func TestListEngines(t *testing.T) {
+	client := newTestClient(t)
+
+	res, err := client.ListEngines(context.Background(), &fuzzypb.ListEnginesRequest{})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := len(res.GetEngines()), 1; g != e {
+		t.Fatalf("len(res.Engines): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := res.GetEngines()[0], "thermostat"; g != e {
+		t.Errorf("res.Engines[0]: got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestGetEngine(t *testing.T) {
+	client := newTestClient(t)
+
+	res, err := client.GetEngine(context.Background(), &fuzzypb.GetEngineRequest{Name: "thermostat"})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := len(res.GetVariables()), 2; g != e {
+		t.Fatalf("len(res.Variables): got '%v', expected '%v'", g, e)
+	}
+
+	temperature := res.GetVariables()[0]
+
+	if g, e := temperature.GetName(), "temperature"; g != e {
+		t.Errorf("variable name: got '%v', expected '%v'", g, e)
+	}
+
+	// Terms are sorted by name
+	if g, e := temperature.GetTerms()[1].GetName(), "hot"; g != e {
+		t.Errorf("term name: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := temperature.GetTerms()[1].GetDomainMax(), 30.0; g != e {
+		t.Errorf("term domain max: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := temperature.GetTerms()[1].GetMembership().GetType(), "LINEAR"; g != e {
+		t.Errorf("membership type: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := res.GetRules()[0].GetRule(), "IF temperature IS cold THEN heating IS high"; g != e {
+		t.Errorf("res.Rules[0]: got '%v', expected '%v'", g, e)
+	}
+
+	_, err = client.GetEngine(context.Background(), &fuzzypb.GetEngineRequest{Name: "unknown"})
+	if g, e := status.Code(err), codes.NotFound; g != e {
+		t.Errorf("GetEngine(unknown): got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestInfer(t *testing.T) {
+	client := newTestClient(t)
+
+	res, err := client.Infer(context.Background(), &fuzzypb.InferRequest{
+		Engine: "thermostat",
+		Inputs: map[string]float64{"temperature": 30},
+		Defuzz: "mean-max",
+	})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := res.GetDefuzzifier(), "mean-max"; g != e {
+		t.Errorf("res.Defuzzifier: got '%v', expected '%v'", g, e)
+	}
+
+	heating := res.GetResults()["heating"]
+
+	if g, e := heating.GetBest(), "low"; g != e {
+		t.Errorf("heating best: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := heating.GetValue(), 25.0; g != e {
+		t.Errorf("heating value: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := heating.GetTerms()["low"].GetTruthDegree(), 1.0; g != e {
+		t.Errorf("heating IS low: got '%v', expected '%v'", g, e)
+	}
+
+	testCases := []struct {
+		req      *fuzzypb.InferRequest
+		expected codes.Code
+	}{
+		{req: &fuzzypb.InferRequest{Engine: "unknown"}, expected: codes.NotFound},
+		{req: &fuzzypb.InferRequest{Engine: "thermostat", Defuzz: "unknown"}, expected: codes.InvalidArgument},
+		{req: &fuzzypb.InferRequest{Engine: "thermostat", VariableDefuzz: map[string]string{"heating": "unknown"}}, expected: codes.InvalidArgument},
+		{req: &fuzzypb.InferRequest{Engine: "thermostat", Steps: -1}, expected: codes.InvalidArgument},
+	}
+
+	for i, tc := range testCases {
+		_, err := client.Infer(context.Background(), tc.req)
+		if g, e := status.Code(err), tc.expected; g != e {
+			t.Errorf("test case #%d: got '%v', expected '%v'", i, g, e)
+		}
+	}
+}
+
+func TestInferStream(t *testing.T) {
+	client := newTestClient(t)
+
+	stream, err := client.InferStream(context.Background())
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	temperatures := []float64{0, 30}
+	expected := []string{"high", "low"}
+
+	for _, temperature := range temperatures {
+		req := &fuzzypb.InferRequest{
+			Engine: "thermostat",
+			Inputs: map[string]float64{"temperature": temperature},
+		}
+
+		if err := stream.Send(req); err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	for i, e := range expected {
+		res, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if g := res.GetResults()["heating"].GetBest(); g != e {
+			t.Errorf("response #%d best heating: got '%v', expected '%v'", i, g, e)
+		}
+	}
+
+	if _, err := stream.Recv(); !errors.Is(err, io.EOF) {
+		t.Errorf("stream.Recv(): got '%v', expected '%v'", err, io.EOF)
+	}
+}
@@ -1,19 +1,59 @@
 package main
 
-import "flag"
+import (
+	"flag"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
 
 // Configuration for the server
 type Config struct {
-	Address     string
-	Definitions string
+	Address         string
+	Definitions     string
+	SnapshotOnError bool
+	// CORSOrigins lists the origins allowed to issue cross-origin requests, "*" allowing any
+	// origin. CORS handling is disabled when empty.
+	CORSOrigins []string
+	// Watch enables the reloading of the definitions when their files change,
+	// checked every WatchInterval
+	Watch         bool
+	WatchInterval time.Duration
+	// LogFormat is the format of the request logs, either "text" or "json"
+	LogFormat string
 }
 
 func parseConfig() *Config {
-	config := &Config{}
+	config := &Config{
+		LogFormat: logFormatText,
+	}
 
 	// Parse command line flags
 	flag.StringVar(&config.Address, "port", ":3003", "address to listen on")
 	flag.StringVar(&config.Definitions, "definitions", "*.fuzzy", "dsl file pattern to load")
+	flag.BoolVar(&config.SnapshotOnError, "snapshot-on-error", false, "log the engine snapshot and inputs when an inference fails")
+	flag.BoolVar(&config.Watch, "watch", false, "reload the definitions when their files change")
+	flag.DurationVar(&config.WatchInterval, "watch-interval", 2*time.Second, "interval between checks of the definition files with -watch")
+	flag.Func("cors-origins", "comma-separated list of the origins allowed to issue cross-origin requests, '*' for any origin", func(value string) error {
+		config.CORSOrigins = nil
+		for _, origin := range strings.Split(value, ",") {
+			if origin = strings.TrimSpace(origin); origin != "" {
+				config.CORSOrigins = append(config.CORSOrigins, origin)
+			}
+		}
+
+		return nil
+	})
+	flag.Func("log-format", "format of the request logs, 'text' or 'json' (default 'text')", func(value string) error {
+		if value != logFormatText && value != logFormatJSON {
+			return errors.Errorf("unknown log format '%s', expected '%s' or '%s'", value, logFormatText, logFormatJSON)
+		}
+
+		config.LogFormat = value
+
+		return nil
+	})
 	flag.Parse()
 
 	return config
@@ -1,33 +1,38 @@
 package main
 
 import (
+	"compress/gzip"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"slices"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/bornholm/go-fuzzy"
+	"github.com/bornholm/go-fuzzy/cmd/internal/definitions"
+	"github.com/bornholm/go-fuzzy/dsl"
 	"github.com/pkg/errors"
 )
 
-type jsonVariable struct {
-	Name  string     `json:"name"`
-	Terms []jsonTerm `json:"terms"`
-}
-
-type jsonTerm struct {
-	Name       string    `json:"name"`
-	Domain     []float64 `json:"domain"`
-	Membership string    `json:"membership"`
+type jsonRule struct {
+	Rule     string            `json:"rule"`
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 // createHandler creates an HTTP handler for a specific fuzzy engine
-func createHandler(registry *Registry) http.Handler {
+func createHandler(registry *definitions.Registry, config *Config) http.Handler {
 	mux := http.NewServeMux()
 
+	metrics := NewMetrics()
+
+	mux.Handle("GET /metrics", metrics)
+
 	// Root endpoint - list available engines
 	mux.HandleFunc("GET /api/v1/engines", func(w http.ResponseWriter, r *http.Request) {
 		response := struct {
@@ -42,87 +47,121 @@ func createHandler(registry *Registry) http.Handler {
 	mux.HandleFunc("GET /api/v1/engines/{name}", func(w http.ResponseWriter, r *http.Request) {
 		name := r.PathValue("name")
 		// Check if engine exists
-		variables, _, exists := registry.Get(name)
+		variables, rules, exists := registry.Get(name)
 		if !exists {
 			http.Error(w, fmt.Sprintf("Engine '%s' not found", name), http.StatusNotFound)
 			return
 		}
 
 		response := struct {
-			Variables []jsonVariable `json:"variables"`
-			Rules     []string       `json:"rules"`
+			Variables []*fuzzy.Variable `json:"variables"`
+			Rules     []jsonRule        `json:"rules"`
+			Warnings  []string          `json:"warnings"`
 		}{
-			Variables: make([]jsonVariable, 0),
-			Rules:     make([]string, 0),
-		}
-
-		for _, v := range variables {
-			terms := slices.Collect[jsonTerm](func(yield func(jsonTerm) bool) {
-				for _, t := range v.Terms() {
-					min, max := t.Domain()
-					term := jsonTerm{
-						Name:       t.Name(),
-						Domain:     []float64{min, max},
-						Membership: fmt.Sprintf("%T", t.Membership()),
-					}
-					if !yield(term) {
-						return
-					}
-				}
-			})
-			slices.SortFunc(terms, func(a, b jsonTerm) int {
-				return strings.Compare(a.Name, b.Name)
-			})
-			response.Variables = append(response.Variables, jsonVariable{
-				Name:  v.Name(),
-				Terms: terms,
+			Variables: append([]*fuzzy.Variable{}, variables...),
+			Rules:     make([]jsonRule, 0),
+			Warnings:  make([]string, 0),
+		}
+
+		for _, w := range fuzzy.Lint(variables, rules) {
+			response.Warnings = append(response.Warnings, w.String())
+		}
+
+		for _, r := range rules {
+			response.Rules = append(response.Rules, jsonRule{
+				Rule:     fuzzy.FormatRule(r),
+				Metadata: r.Metadata(),
 			})
 		}
 
 		jsonResponse(w, response)
 	})
 
-	mux.HandleFunc("POST /api/v1/engines/{name}", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc("GET /api/v1/engines/{name}/variables/{variable}/curves", func(w http.ResponseWriter, r *http.Request) {
 		name := r.PathValue("name")
-
 		// Check if engine exists
-		variables, rules, exists := registry.Get(name)
+		variables, _, exists := registry.Get(name)
 		if !exists {
 			http.Error(w, fmt.Sprintf("Engine '%s' not found", name), http.StatusNotFound)
 			return
 		}
 
-		defuzz := r.URL.Query().Get("defuzz")
-		if defuzz == "" {
-			defuzz = "centroid"
+		variableName := r.PathValue("variable")
+		index := slices.IndexFunc(variables, func(v *fuzzy.Variable) bool { return v.Name() == variableName })
+		if index == -1 {
+			http.Error(w, fmt.Sprintf("Variable '%s' not found in engine '%s'", variableName, name), http.StatusNotFound)
+			return
 		}
 
-		rawSteps := r.URL.Query().Get("steps")
-		if rawSteps == "" {
-			rawSteps = "100"
+		steps, err := curveSteps(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
 
-		steps, err := strconv.ParseInt(rawSteps, 10, 32)
+		jsonResponse(w, newCurvesResponse(variables[index], steps))
+	})
+
+	mux.HandleFunc("PUT /api/v1/engines/{name}", func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("name")
+
+		defer r.Body.Close()
+
+		content, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxDefinitionSize))
 		if err != nil {
-			http.Error(w, fmt.Sprintf("Invalid step value '%v', expected integer", steps), http.StatusBadRequest)
+			http.Error(w, fmt.Sprintf("Could not read definition: %v", err), http.StatusBadRequest)
 			return
 		}
 
-		var defuzzify fuzzy.DefuzzifyFunc
+		result, err := dsl.ParseRulesAndVariables(string(content))
+		if err != nil {
+			jsonStatusResponse(w, http.StatusBadRequest, newDefinitionErrorResponse("Invalid DSL", err))
+			return
+		}
 
-		switch defuzz {
-		case "centroid":
-			defuzzify = fuzzy.Centroid(int(steps))
-		case "mean-max":
-			defuzzify = fuzzy.MeanOfMaximum(int(steps))
-		default:
-			http.Error(w, fmt.Sprintf("Invalid defuzzification function '%s'", name), http.StatusBadRequest)
+		if _, err := definitions.NewEngine(result.Variables, result.Rules, nil); err != nil {
+			jsonStatusResponse(w, http.StatusBadRequest, newDefinitionErrorResponse("Invalid engine definition", err))
 			return
 		}
 
-		engine := fuzzy.NewEngine(defuzzify)
-		engine.Variables(variables...)
-		engine.Rules(rules...)
+		status := http.StatusOK
+		if created := registry.Put(name, result.Variables, result.Rules); created {
+			status = http.StatusCreated
+		}
+
+		jsonStatusResponse(w, status, struct {
+			Name string `json:"name"`
+		}{
+			Name: name,
+		})
+	})
+
+	mux.HandleFunc("DELETE /api/v1/engines/{name}", func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("name")
+
+		if deleted := registry.Delete(name); !deleted {
+			http.Error(w, fmt.Sprintf("Engine '%s' not found", name), http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("POST /api/v1/engines/{name}", func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("name")
+
+		// Check if engine exists
+		variables, rules, exists := registry.Get(name)
+		if !exists {
+			http.Error(w, fmt.Sprintf("Engine '%s' not found", name), http.StatusNotFound)
+			return
+		}
+
+		engine, err := engineFromRequest(r, variables, rules)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 
 		// Parse JSON input
 		var inputValues fuzzy.Values
@@ -134,72 +173,268 @@ func createHandler(registry *Registry) http.Handler {
 		defer r.Body.Close()
 
 		// Run inference
+		start := time.Now()
 		results, err := engine.Infer(inputValues)
+		metrics.ObserveInference(name, time.Since(start), err)
 		if err != nil {
+			if config.SnapshotOnError {
+				logSnapshot(name, engine, inputValues)
+			}
+
 			http.Error(w, fmt.Sprintf("Inference error: %v", err), http.StatusInternalServerError)
 			return
 		}
 
-		type jsonTermResult struct {
-			TruthDegree float64 `json:"truthDegree"`
+		response, err := newInferenceResponse(engine, results)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not defuzzify value: %+v", errors.WithStack(err)), http.StatusInternalServerError)
+			return
 		}
 
-		type jsonVariableResult struct {
-			Value float64                   `json:"value"`
-			Best  string                    `json:"best,omitempty"`
-			Terms map[string]jsonTermResult `json:"terms,omitempty"`
+		jsonResponse(w, response)
+	})
+
+	mux.HandleFunc("POST /api/v1/infer", func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+
+		// Parse the JSON engine definition and inputs
+		var request jsonInferenceRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid JSON: %v", err), http.StatusBadRequest)
+			return
 		}
 
-		// Prepare response
-		response := struct {
-			Results map[string]jsonVariableResult `json:"results"`
-		}{
-			Results: make(map[string]jsonVariableResult),
+		engine, err := engineFromRequest(r, request.Engine.Variables, request.Engine.Rules)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
 
-		// Process results for each variable
-		for varName, varResults := range results {
-			jsonVar := jsonVariableResult{
-				Terms: make(map[string]jsonTermResult),
-			}
+		results, err := engine.Infer(request.Inputs)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Inference error: %v", err), http.StatusBadRequest)
+			return
+		}
 
-			// Find the best term
-			bestTerm, ok := results.Best(varName)
-			if ok {
-				jsonVar.Best = bestTerm.Term()
-			}
+		response, err := newInferenceResponse(engine, results)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not defuzzify value: %+v", errors.WithStack(err)), http.StatusInternalServerError)
+			return
+		}
+
+		jsonResponse(w, response)
+	})
 
-			// Get defuzzified value if possible
-			if len(varResults) > 0 {
-				defuzz, err := engine.Defuzzify(varName, results)
-				if err != nil {
-					http.Error(w, fmt.Sprintf("Could not defuzzify value: %+v", errors.WithStack(err)), http.StatusInternalServerError)
-					return
-				}
+	subscriptions := NewSubscriptions()
 
-				jsonVar.Value = defuzz
-			}
+	mux.HandleFunc("GET /api/v1/engines/{name}/sse", createSubscribeHandler(registry, subscriptions))
+	mux.HandleFunc("POST /api/v1/engines/{name}/sse/{id}", createPublishHandler(subscriptions))
 
-			// Add results for each term
-			for termName, result := range varResults {
-				termResult := jsonTermResult{
-					TruthDegree: result.TruthDegree(),
-				}
+	return mux
+}
 
-				jsonVar.Terms[termName] = termResult
-			}
+type jsonTermResult struct {
+	TruthDegree float64 `json:"truthDegree"`
+}
+
+type jsonVariableResult struct {
+	Value float64                   `json:"value"`
+	Best  string                    `json:"best,omitempty"`
+	Terms map[string]jsonTermResult `json:"terms,omitempty"`
+}
 
-			response.Results[varName] = jsonVar
+// jsonInferenceRequest holds an engine definition, in the format of fuzzy.EngineFromJSON(),
+// and the inputs to infer with it
+type jsonInferenceRequest struct {
+	Engine struct {
+		Variables []*fuzzy.Variable `json:"variables"`
+		Rules     []*fuzzy.Rule     `json:"rules"`
+	} `json:"engine"`
+	Inputs fuzzy.Values `json:"inputs"`
+}
+
+// maxDefinitionSize bounds the size of the DSL definitions sent to the server
+const maxDefinitionSize = 1 << 20
+
+type jsonDefinitionError struct {
+	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+}
+
+type jsonDefinitionErrorResponse struct {
+	Error  string                `json:"error"`
+	Errors []jsonDefinitionError `json:"errors"`
+}
+
+// maxCurveSteps bounds the number of samples of a membership curve
+const maxCurveSteps = 10000
+
+type jsonPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+type jsonCurvesResponse struct {
+	Variable string                 `json:"variable"`
+	Universe [2]float64             `json:"universe"`
+	Terms    map[string][]jsonPoint `json:"terms"`
+}
+
+type jsonInferenceResponse struct {
+	Defuzzifier string                        `json:"defuzzifier"`
+	Results     map[string]jsonVariableResult `json:"results"`
+}
+
+// defuzzVariablePrefix prefixes the query parameters selecting the defuzzification
+// function of a specific output variable
+const defuzzVariablePrefix = "defuzz."
+
+// engineFromRequest creates an engine for the given definition, using the
+// defuzzification function selected by the "defuzz" and "steps" query parameters,
+// overridden for the variables given by the "defuzz.<variable>" ones
+func engineFromRequest(r *http.Request, variables []*fuzzy.Variable, rules []*fuzzy.Rule) (*fuzzy.Engine, error) {
+	defuzzification := definitions.Defuzzification{
+		Name:      r.URL.Query().Get("defuzz"),
+		Variables: make(map[string]string),
+	}
+
+	if rawSteps := r.URL.Query().Get("steps"); rawSteps != "" {
+		steps, err := strconv.ParseInt(rawSteps, 10, 32)
+		if err != nil {
+			return nil, errors.Errorf("Invalid step value '%v', expected integer", rawSteps)
 		}
 
-		jsonResponse(w, response)
-	})
+		defuzzification.Steps = int(steps)
+	}
 
-	return mux
+	// Per-variable overrides, i.e. "defuzz.fan=mean-max"
+	for key, values := range r.URL.Query() {
+		variable, found := strings.CutPrefix(key, defuzzVariablePrefix)
+		if !found || len(values) == 0 {
+			continue
+		}
+
+		defuzzification.Variables[variable] = values[0]
+	}
+
+	engine, err := definitions.NewInferenceEngine(variables, rules, defuzzification)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return engine, nil
+}
+
+// newDefinitionErrorResponse describes the errors of an invalid definition, with their
+// position for parsing errors
+func newDefinitionErrorResponse(message string, err error) *jsonDefinitionErrorResponse {
+	response := &jsonDefinitionErrorResponse{
+		Error:  fmt.Sprintf("%s: %v", message, err),
+		Errors: make([]jsonDefinitionError, 0),
+	}
+
+	var parseErrs dsl.ParseErrors
+	var parseErr *dsl.ParseError
+	var validationErrs fuzzy.ValidationErrors
+
+	switch {
+	case errors.As(err, &parseErrs):
+		for _, e := range parseErrs {
+			response.Errors = append(response.Errors, jsonDefinitionError{Message: e.Error(), Line: e.Line(), Column: e.Column()})
+		}
+	case errors.As(err, &parseErr):
+		response.Errors = append(response.Errors, jsonDefinitionError{Message: parseErr.Error(), Line: parseErr.Line(), Column: parseErr.Column()})
+	case errors.As(err, &validationErrs):
+		for _, e := range validationErrs {
+			response.Errors = append(response.Errors, jsonDefinitionError{Message: e.Error()})
+		}
+	default:
+		response.Errors = append(response.Errors, jsonDefinitionError{Message: err.Error()})
+	}
+
+	return response
+}
+
+// curveSteps returns the number of samples selected by the "steps" query parameter,
+// 100 by default, clamped to maxCurveSteps
+func curveSteps(r *http.Request) (int, error) {
+	rawSteps := r.URL.Query().Get("steps")
+	if rawSteps == "" {
+		return 100, nil
+	}
+
+	steps, err := strconv.ParseInt(rawSteps, 10, 32)
+	if err != nil || steps < 1 {
+		return 0, errors.Errorf("Invalid step value '%v', expected positive integer", rawSteps)
+	}
+
+	return int(min(steps, maxCurveSteps)), nil
+}
+
+// newCurvesResponse samples the membership of each term of the variable across its universe,
+// the curves having steps+1 points, both universe bounds included
+func newCurvesResponse(variable *fuzzy.Variable, steps int) *jsonCurvesResponse {
+	universeMin, universeMax := variable.UniverseMin(), variable.UniverseMax()
+
+	response := &jsonCurvesResponse{
+		Variable: variable.Name(),
+		Universe: [2]float64{universeMin, universeMax},
+		Terms:    make(map[string][]jsonPoint),
+	}
+
+	step := (universeMax - universeMin) / float64(steps)
+
+	for _, term := range variable.Terms() {
+		points := make([]jsonPoint, 0, steps+1)
+		for i := 0; i <= steps; i++ {
+			x := universeMin + float64(i)*step
+			points = append(points, jsonPoint{X: x, Y: term.Membership().Value(x)})
+		}
+
+		response.Terms[term.Name()] = points
+	}
+
+	return response
+}
+
+// newInferenceResponse converts the inference results to their JSON representation
+func newInferenceResponse(engine *fuzzy.Engine, results fuzzy.Results) (*jsonInferenceResponse, error) {
+	inference, err := definitions.NewInferenceResult(engine, results)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response := &jsonInferenceResponse{
+		Defuzzifier: inference.Defuzzifier,
+		Results:     make(map[string]jsonVariableResult, len(inference.Variables)),
+	}
+
+	for name, result := range inference.Variables {
+		jsonVar := jsonVariableResult{
+			Value: result.Value,
+			Best:  result.Best,
+			Terms: make(map[string]jsonTermResult, len(result.Terms)),
+		}
+
+		for term, truthDegree := range result.Terms {
+			jsonVar.Terms[term] = jsonTermResult{TruthDegree: truthDegree}
+		}
+
+		response.Results[name] = jsonVar
+	}
+
+	return response, nil
 }
 
 func jsonResponse(w http.ResponseWriter, response any) {
+	jsonStatusResponse(w, http.StatusOK, response)
+}
+
+// jsonStatusResponse writes the JSON response with the given status code
+func jsonStatusResponse(w http.ResponseWriter, status int, response any) {
 	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
 	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", " ")
 	if err := encoder.Encode(response); err != nil {
@@ -207,10 +442,323 @@ func jsonResponse(w http.ResponseWriter, response any) {
 	}
 }
 
-// LoggingMiddleware logs incoming requests
-func loggingMiddleware(next http.Handler) http.Handler {
+// logSnapshot logs the engine snapshot and the inputs so that the inference can be replayed with fuzzy.Replay()
+func logSnapshot(name string, engine *fuzzy.Engine, inputs fuzzy.Values) {
+	snapshot, err := engine.Snapshot()
+	if err != nil {
+		log.Printf("[ERROR] could not snapshot engine '%s': %+v", name, errors.WithStack(err))
+		return
+	}
+
+	rawInputs, err := json.Marshal(inputs)
+	if err != nil {
+		log.Printf("[ERROR] could not encode inputs for engine '%s': %+v", name, errors.WithStack(err))
+		return
+	}
+
+	log.Printf("[SNAPSHOT] engine '%s': snapshot=%s inputs=%s", name, base64.StdEncoding.EncodeToString(snapshot), rawInputs)
+}
+
+// gzipMinSize is the minimal size of the response bodies compressed by gzipMiddleware
+const gzipMinSize = 1024
+
+// gzipMiddleware compresses the responses with gzip for the clients accepting it.
+// Bodies smaller than gzipMinSize, responses without body and event streams are sent as is.
+func gzipMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		log.Printf("%s %s", r.Method, r.URL.Path)
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		if !acceptsGzip(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		gw := &gzipResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		defer gw.close()
+
+		next.ServeHTTP(gw, r)
+	})
+}
+
+// acceptsGzip returns true if the Accept-Encoding header of the request allows gzip
+func acceptsGzip(r *http.Request) bool {
+	for _, encoding := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(encoding), ";")
+		if strings.TrimSpace(name) != "gzip" {
+			continue
+		}
+
+		// gzip;q=0 explicitly refuses the encoding
+		q, err := strconv.ParseFloat(strings.TrimPrefix(strings.TrimSpace(params), "q="), 64)
+		return params == "" || err != nil || q > 0
+	}
+
+	return false
+}
+
+// gzipResponseWriter buffers the beginning of the body to decide whether to compress it:
+// the response is compressed once the body reaches gzipMinSize, sent as is otherwise
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	buffer      []byte
+	gzip        *gzip.Writer
+	passthrough bool
+}
+
+func (w *gzipResponseWriter) WriteHeader(status int) {
+	if w.gzip != nil || w.passthrough {
+		return
+	}
+
+	w.status = status
+
+	// Responses without body and streams are not compressed
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified ||
+		strings.HasPrefix(w.Header().Get("Content-Type"), "text/event-stream") {
+		w.startPassthrough()
+	}
+}
+
+func (w *gzipResponseWriter) Write(data []byte) (int, error) {
+	if w.passthrough {
+		return w.ResponseWriter.Write(data)
+	}
+
+	if w.gzip != nil {
+		return w.gzip.Write(data)
+	}
+
+	if strings.HasPrefix(w.Header().Get("Content-Type"), "text/event-stream") {
+		w.startPassthrough()
+		return w.ResponseWriter.Write(data)
+	}
+
+	w.buffer = append(w.buffer, data...)
+
+	if len(w.buffer) >= gzipMinSize {
+		if err := w.startGzip(); err != nil {
+			return 0, errors.WithStack(err)
+		}
+	}
+
+	return len(data), nil
+}
+
+// Flush sends the buffered data, allowing streaming through the writer
+func (w *gzipResponseWriter) Flush() {
+	switch {
+	case w.gzip != nil:
+		if err := w.gzip.Flush(); err != nil {
+			return
+		}
+	case !w.passthrough:
+		w.startPassthrough()
+	}
+
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap allows http.ResponseController to reach the underlying writer
+func (w *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *gzipResponseWriter) startGzip() error {
+	w.Header().Del("Content-Length")
+	w.Header().Set("Content-Encoding", "gzip")
+	w.ResponseWriter.WriteHeader(w.status)
+
+	w.gzip = gzip.NewWriter(w.ResponseWriter)
+
+	_, err := w.gzip.Write(w.buffer)
+	w.buffer = nil
+
+	return errors.WithStack(err)
+}
+
+func (w *gzipResponseWriter) startPassthrough() {
+	w.passthrough = true
+	w.ResponseWriter.WriteHeader(w.status)
+
+	if len(w.buffer) > 0 {
+		if _, err := w.ResponseWriter.Write(w.buffer); err != nil {
+			log.Printf("[ERROR] could not write response: %+v", errors.WithStack(err))
+		}
+		w.buffer = nil
+	}
+}
+
+// close terminates the response, compressed or not
+func (w *gzipResponseWriter) close() {
+	switch {
+	case w.gzip != nil:
+		if err := w.gzip.Close(); err != nil {
+			log.Printf("[ERROR] could not compress response: %+v", errors.WithStack(err))
+		}
+	case !w.passthrough:
+		w.startPassthrough()
+	}
+}
+
+// healthMiddleware serves the liveness (/healthz) and readiness (/readyz) probes, the
+// latter failing with 503 until ready is set, i.e. once the definitions are loaded.
+// Probes are answered before reaching the next handler, hence are not logged.
+func healthMiddleware(ready *atomic.Bool, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/healthz":
+			fmt.Fprintln(w, "ok")
+		case "/readyz":
+			if !ready.Load() {
+				http.Error(w, "not ready", http.StatusServiceUnavailable)
+				return
+			}
+
+			fmt.Fprintln(w, "ok")
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// Log formats of loggingMiddleware
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
+type jsonRequestLog struct {
+	Time       time.Time `json:"time"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	Status     int       `json:"status"`
+	Bytes      int64     `json:"bytes"`
+	DurationMs float64   `json:"durationMs"`
+	Engine     string    `json:"engine,omitempty"`
+}
+
+// LoggingMiddleware logs the completed requests, with their status, body size and duration,
+// either as text with the standard logger or as one JSON object per line written to out
+func loggingMiddleware(format string, out io.Writer, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		lw := &loggingResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(lw, r)
+
+		duration := time.Since(start)
+
+		if format != logFormatJSON {
+			log.Printf("%s %s %d %dB %s", r.Method, r.URL.Path, lw.status, lw.bytes, duration)
+			return
+		}
+
+		entry := jsonRequestLog{
+			Time:       start,
+			Method:     r.Method,
+			Path:       r.URL.Path,
+			Status:     lw.status,
+			Bytes:      lw.bytes,
+			DurationMs: float64(duration.Microseconds()) / 1000,
+			// Set by the router, for the routes of a named engine
+			Engine: r.PathValue("name"),
+		}
+
+		data, err := json.Marshal(entry)
+		if err != nil {
+			log.Printf("[ERROR] could not encode request log: %+v", errors.WithStack(err))
+			return
+		}
+
+		if _, err := out.Write(append(data, '\n')); err != nil {
+			log.Printf("[ERROR] could not write request log: %+v", errors.WithStack(err))
+		}
+	})
+}
+
+// loggingResponseWriter captures the status and the size of the response body
+type loggingResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+func (w *loggingResponseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *loggingResponseWriter) Write(data []byte) (int, error) {
+	w.wroteHeader = true
+
+	n, err := w.ResponseWriter.Write(data)
+	w.bytes += int64(n)
+
+	return n, err
+}
+
+// Flush allows streaming through the writer
+func (w *loggingResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap allows http.ResponseController to reach the underlying writer
+func (w *loggingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// corsAllowedMethods are the methods allowed in cross-origin requests
+var corsAllowedMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions}
+
+// corsMiddleware allows cross-origin requests from the given origins, "*" allowing any origin,
+// and answers the preflight requests. It is a no-op without origins.
+func corsMiddleware(origins []string, next http.Handler) http.Handler {
+	if len(origins) == 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+
+		w.Header().Add("Vary", "Origin")
+
+		if origin == "" || (!slices.Contains(origins, "*") && !slices.Contains(origins, origin)) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+
+		// Preflight request
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Set("Access-Control-Allow-Methods", strings.Join(corsAllowedMethods, ", "))
+
+			headers := r.Header.Get("Access-Control-Request-Headers")
+			if headers == "" {
+				headers = "Content-Type"
+			}
+			w.Header().Set("Access-Control-Allow-Headers", headers)
+			w.Header().Set("Access-Control-Max-Age", "600")
+
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
 		next.ServeHTTP(w, r)
 	})
 }
@@ -0,0 +1,689 @@
+package main
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/bornholm/go-fuzzy"
+	"github.com/bornholm/go-fuzzy/cmd/internal/definitions"
+	"github.com/pkg/errors"
+)
+
+func TestInferDuplicateVariables(t *testing.T) {
+	registry := definitions.NewRegistry()
+	registry.Register(
+		"duplicate",
+		[]*fuzzy.Variable{
+			fuzzy.NewVariable("temperature", fuzzy.NewTerm("hot", fuzzy.Linear(20, 30))),
+			fuzzy.NewVariable("temperature", fuzzy.NewTerm("cold", fuzzy.Inverted(fuzzy.Linear(0, 10)))),
+		},
+		nil,
+	)
+
+	handler := createHandler(registry, &Config{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/engines/duplicate", strings.NewReader(`{"temperature": 25}`))
+	res := httptest.NewRecorder()
+
+	handler.ServeHTTP(res, req)
+
+	if g, e := res.Code, http.StatusBadRequest; g != e {
+		t.Errorf("res.Code: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := res.Body.String(), fuzzy.ErrVariableAlreadyExists.Error(); !strings.Contains(g, e) {
+		t.Errorf("res.Body: got '%v', expected to contain '%v'", g, e)
+	}
+}
+
+func TestGetEngineMemberships(t *testing.T) {
+	registry := definitions.NewRegistry()
+	registry.Register(
+		"temperature",
+		[]*fuzzy.Variable{
+			fuzzy.NewVariable("temperature", fuzzy.NewTerm("cold", fuzzy.Inverted(fuzzy.Linear(0, 10)))),
+		},
+		nil,
+	)
+
+	handler := createHandler(registry, &Config{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/engines/temperature", nil)
+	res := httptest.NewRecorder()
+
+	handler.ServeHTTP(res, req)
+
+	if g, e := res.Code, http.StatusOK; g != e {
+		t.Fatalf("res.Code: got '%v', expected '%v'", g, e)
+	}
+
+	var response struct {
+		Variables []struct {
+			Terms []struct {
+				Membership fuzzy.MembershipDefinition `json:"membership"`
+			} `json:"terms"`
+		} `json:"variables"`
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	membership := response.Variables[0].Terms[0].Membership
+
+	if g, e := membership.Type, fuzzy.KindInverted; g != e {
+		t.Errorf("membership.Type: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := membership.Children[0].Params, []float64{0, 10}; !slices.Equal(g, e) {
+		t.Errorf("membership.Children[0].Params: got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestInferFromJSONDefinition(t *testing.T) {
+	handler := createHandler(definitions.NewRegistry(), &Config{})
+
+	body := `{
+		"engine": {
+			"variables": [
+				{"name": "temperature", "terms": [{"name": "hot", "membership": {"type": "LINEAR", "params": [20, 30]}}]},
+				{"name": "ac_mode", "terms": [{"name": "cooling", "membership": {"type": "TRIANGULAR", "params": [0, 50, 100]}}]}
+			],
+			"rules": [
+				{
+					"premise": {"type": "IS", "variable": "temperature", "term": "hot"},
+					"conclusions": [{"variable": "ac_mode", "term": "cooling"}]
+				}
+			]
+		},
+		"inputs": {"temperature": 25}
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/infer", strings.NewReader(body))
+	res := httptest.NewRecorder()
+
+	handler.ServeHTTP(res, req)
+
+	if g, e := res.Code, http.StatusOK; g != e {
+		t.Fatalf("res.Code: got '%v', expected '%v' (%s)", g, e, res.Body.String())
+	}
+
+	var response jsonInferenceResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := response.Results["ac_mode"].Terms["cooling"].TruthDegree, 0.5; g != e {
+		t.Errorf("ac_mode IS cooling: got '%v', expected '%v'", g, e)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/infer", strings.NewReader(`{"engine": {"variables": [{"name": "x", "terms": [{"name": "a", "membership": {"type": "GAUSSIAN"}}]}]}}`))
+	res = httptest.NewRecorder()
+
+	handler.ServeHTTP(res, req)
+
+	if g, e := res.Code, http.StatusBadRequest; g != e {
+		t.Errorf("res.Code: got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestGetVariableCurves(t *testing.T) {
+	registry := definitions.NewRegistry()
+	registry.Register(
+		"temperature",
+		[]*fuzzy.Variable{
+			fuzzy.NewVariable("temperature",
+				fuzzy.NewTerm("cold", fuzzy.Inverted(fuzzy.Linear(0, 10))),
+				fuzzy.NewTerm("hot", fuzzy.Linear(10, 20)),
+			),
+		},
+		nil,
+	)
+
+	handler := createHandler(registry, &Config{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/engines/temperature/variables/temperature/curves?steps=4", nil)
+	res := httptest.NewRecorder()
+
+	handler.ServeHTTP(res, req)
+
+	if g, e := res.Code, http.StatusOK; g != e {
+		t.Fatalf("res.Code: got '%v', expected '%v'", g, e)
+	}
+
+	var response jsonCurvesResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := response.Universe, [2]float64{0, 20}; g != e {
+		t.Errorf("response.Universe: got '%v', expected '%v'", g, e)
+	}
+
+	expected := map[string][]jsonPoint{
+		"cold": {{X: 0, Y: 1}, {X: 5, Y: 0.5}, {X: 10, Y: 0}, {X: 15, Y: 0}, {X: 20, Y: 0}},
+		"hot":  {{X: 0, Y: 0}, {X: 5, Y: 0}, {X: 10, Y: 0}, {X: 15, Y: 0.5}, {X: 20, Y: 1}},
+	}
+
+	for term, e := range expected {
+		if g := response.Terms[term]; !slices.Equal(g, e) {
+			t.Errorf("term '%s': got '%v', expected '%v'", term, g, e)
+		}
+	}
+
+	// Steps are clamped
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/engines/temperature/variables/temperature/curves?steps=1000000", nil)
+	res = httptest.NewRecorder()
+
+	handler.ServeHTTP(res, req)
+
+	response = jsonCurvesResponse{}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := len(response.Terms["hot"]), maxCurveSteps+1; g != e {
+		t.Errorf("len(response.Terms[\"hot\"]): got '%v', expected '%v'", g, e)
+	}
+
+	testCases := []struct {
+		url  string
+		code int
+	}{
+		{url: "/api/v1/engines/temperature/variables/temperature/curves", code: http.StatusOK},
+		{url: "/api/v1/engines/temperature/variables/temperature/curves?steps=0", code: http.StatusBadRequest},
+		{url: "/api/v1/engines/temperature/variables/temperature/curves?steps=abc", code: http.StatusBadRequest},
+		{url: "/api/v1/engines/temperature/variables/humidity/curves", code: http.StatusNotFound},
+		{url: "/api/v1/engines/unknown/variables/temperature/curves", code: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+		res := httptest.NewRecorder()
+
+		handler.ServeHTTP(res, req)
+
+		if g, e := res.Code, tc.code; g != e {
+			t.Errorf("%s: res.Code: got '%v', expected '%v'", tc.url, g, e)
+		}
+	}
+}
+
+func TestCORSPreflight(t *testing.T) {
+	registry := definitions.NewRegistry()
+	registry.Register(
+		"temperature",
+		[]*fuzzy.Variable{
+			fuzzy.NewVariable("temperature", fuzzy.NewTerm("hot", fuzzy.Linear(20, 30))),
+		},
+		nil,
+	)
+
+	handler := corsMiddleware([]string{"https://playground.example"}, createHandler(registry, &Config{}))
+
+	req := httptest.NewRequest(http.MethodOptions, "/api/v1/engines/temperature", nil)
+	req.Header.Set("Origin", "https://playground.example")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	req.Header.Set("Access-Control-Request-Headers", "Content-Type, X-Requested-With")
+	res := httptest.NewRecorder()
+
+	handler.ServeHTTP(res, req)
+
+	if g, e := res.Code, http.StatusNoContent; g != e {
+		t.Errorf("res.Code: got '%v', expected '%v'", g, e)
+	}
+
+	expectedHeaders := map[string]string{
+		"Access-Control-Allow-Origin":  "https://playground.example",
+		"Access-Control-Allow-Methods": "GET, POST, PUT, DELETE, OPTIONS",
+		"Access-Control-Allow-Headers": "Content-Type, X-Requested-With",
+		"Vary":                         "Origin",
+	}
+
+	for header, e := range expectedHeaders {
+		if g := res.Header().Get(header); g != e {
+			t.Errorf("%s: got '%v', expected '%v'", header, g, e)
+		}
+	}
+
+	// Actual request from an allowed origin
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/engines/temperature", strings.NewReader(`{"temperature": 25}`))
+	req.Header.Set("Origin", "https://playground.example")
+	res = httptest.NewRecorder()
+
+	handler.ServeHTTP(res, req)
+
+	if g, e := res.Code, http.StatusOK; g != e {
+		t.Errorf("res.Code: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := res.Header().Get("Access-Control-Allow-Origin"), "https://playground.example"; g != e {
+		t.Errorf("Access-Control-Allow-Origin: got '%v', expected '%v'", g, e)
+	}
+
+	// Request from another origin
+	req = httptest.NewRequest(http.MethodOptions, "/api/v1/engines/temperature", nil)
+	req.Header.Set("Origin", "https://evil.example")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	res = httptest.NewRecorder()
+
+	handler.ServeHTTP(res, req)
+
+	if g := res.Header().Get("Access-Control-Allow-Origin"); g != "" {
+		t.Errorf("Access-Control-Allow-Origin: got '%v', expected no header", g)
+	}
+}
+
+func TestHealthProbes(t *testing.T) {
+	var ready atomic.Bool
+
+	handler := healthMiddleware(&ready, createHandler(definitions.NewRegistry(), &Config{}))
+
+	probe := func(path string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		res := httptest.NewRecorder()
+
+		handler.ServeHTTP(res, req)
+
+		return res.Code
+	}
+
+	// Not ready
+	if g, e := probe("/healthz"), http.StatusOK; g != e {
+		t.Errorf("/healthz: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := probe("/readyz"), http.StatusServiceUnavailable; g != e {
+		t.Errorf("/readyz: got '%v', expected '%v'", g, e)
+	}
+
+	// Ready
+	ready.Store(true)
+
+	if g, e := probe("/healthz"), http.StatusOK; g != e {
+		t.Errorf("/healthz: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := probe("/readyz"), http.StatusOK; g != e {
+		t.Errorf("/readyz: got '%v', expected '%v'", g, e)
+	}
+
+	// Other routes are still served
+	if g, e := probe("/api/v1/engines"), http.StatusOK; g != e {
+		t.Errorf("/api/v1/engines: got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestPutEngine(t *testing.T) {
+	registry := definitions.NewRegistry()
+	handler := createHandler(registry, &Config{})
+
+	put := func(definition string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/engines/thermostat", strings.NewReader(definition))
+		res := httptest.NewRecorder()
+
+		handler.ServeHTTP(res, req)
+
+		return res
+	}
+
+	infer := func() jsonInferenceResponse {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/engines/thermostat", strings.NewReader(`{"temperature": 30}`))
+		res := httptest.NewRecorder()
+
+		handler.ServeHTTP(res, req)
+
+		if g, e := res.Code, http.StatusOK; g != e {
+			t.Fatalf("res.Code: got '%v', expected '%v': %s", g, e, res.Body.String())
+		}
+
+		var response jsonInferenceResponse
+		if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		return response
+	}
+
+	// Creation
+	res := put(`
+DEFINE temperature ( TERM hot LINEAR (20, 30) );
+DEFINE heating ( TERM low INVERTED (LINEAR (0, 100)), TERM high LINEAR (0, 100) );
+IF temperature IS hot THEN heating IS low;
+`)
+
+	if g, e := res.Code, http.StatusCreated; g != e {
+		t.Fatalf("res.Code: got '%v', expected '%v': %s", g, e, res.Body.String())
+	}
+
+	if g, e := infer().Results["heating"].Best, "low"; g != e {
+		t.Errorf("best heating term: got '%v', expected '%v'", g, e)
+	}
+
+	// Replacement
+	res = put(`
+DEFINE temperature ( TERM hot LINEAR (20, 30) );
+DEFINE heating ( TERM low INVERTED (LINEAR (0, 100)), TERM high LINEAR (0, 100) );
+IF temperature IS hot THEN heating IS high;
+`)
+
+	if g, e := res.Code, http.StatusOK; g != e {
+		t.Fatalf("res.Code: got '%v', expected '%v': %s", g, e, res.Body.String())
+	}
+
+	if g, e := infer().Results["heating"].Best, "high"; g != e {
+		t.Errorf("best heating term: got '%v', expected '%v'", g, e)
+	}
+
+	// Invalid definitions leave the engine untouched
+	testCases := []struct {
+		definition string
+		expected   jsonDefinitionError
+	}{
+		{
+			definition: "DEFINE temperature ( TERM hot LINEAR (20, 30) );\nIF temperature IS THEN heating IS high;",
+			expected:   jsonDefinitionError{Line: 2, Column: 19},
+		},
+		{
+			definition: "DEFINE temperature ( TERM hot LINEAR (20, 30) );\nIF temperature IS hot THEN fan IS fast;",
+			expected:   jsonDefinitionError{Message: "rule #0: variable 'fan': undefined variable"},
+		},
+	}
+
+	for i, tc := range testCases {
+		res := put(tc.definition)
+
+		if g, e := res.Code, http.StatusBadRequest; g != e {
+			t.Errorf("test case #%d: res.Code: got '%v', expected '%v'", i, g, e)
+			continue
+		}
+
+		if g, e := res.Header().Get("Content-Type"), "application/json"; g != e {
+			t.Errorf("test case #%d: Content-Type: got '%v', expected '%v'", i, g, e)
+		}
+
+		var response jsonDefinitionErrorResponse
+		if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if len(response.Errors) == 0 {
+			t.Errorf("test case #%d: expected errors, got none", i)
+			continue
+		}
+
+		g := response.Errors[0]
+
+		if tc.expected.Line != 0 && (g.Line != tc.expected.Line || g.Column != tc.expected.Column) {
+			t.Errorf("test case #%d: error position: got '%d:%d', expected '%d:%d'", i, g.Line, g.Column, tc.expected.Line, tc.expected.Column)
+		}
+
+		if tc.expected.Message != "" && !strings.Contains(g.Message, tc.expected.Message) {
+			t.Errorf("test case #%d: error message: got '%v', expected to contain '%v'", i, g.Message, tc.expected.Message)
+		}
+	}
+
+	if g, e := infer().Results["heating"].Best, "high"; g != e {
+		t.Errorf("best heating term after invalid definitions: got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestDeleteEngine(t *testing.T) {
+	registry := definitions.NewRegistry()
+	registry.Register(
+		"temperature",
+		[]*fuzzy.Variable{
+			fuzzy.NewVariable("temperature", fuzzy.NewTerm("hot", fuzzy.Linear(20, 30))),
+		},
+		nil,
+	)
+
+	handler := createHandler(registry, &Config{})
+
+	request := func(method string) int {
+		req := httptest.NewRequest(method, "/api/v1/engines/temperature", nil)
+		res := httptest.NewRecorder()
+
+		handler.ServeHTTP(res, req)
+
+		return res.Code
+	}
+
+	if g, e := request(http.MethodDelete), http.StatusNoContent; g != e {
+		t.Errorf("first DELETE: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := request(http.MethodGet), http.StatusNotFound; g != e {
+		t.Errorf("GET after DELETE: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := request(http.MethodDelete), http.StatusNotFound; g != e {
+		t.Errorf("second DELETE: got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestGzipResponses(t *testing.T) {
+	registry := definitions.NewRegistry()
+	registry.Register(
+		"temperature",
+		[]*fuzzy.Variable{
+			fuzzy.NewVariable("temperature",
+				fuzzy.NewTerm("cold", fuzzy.Inverted(fuzzy.Linear(0, 10))),
+				fuzzy.NewTerm("hot", fuzzy.Linear(10, 20)),
+			),
+		},
+		nil,
+	)
+
+	handler := gzipMiddleware(createHandler(registry, &Config{}))
+
+	curvesURL := "/api/v1/engines/temperature/variables/temperature/curves?steps=1000"
+
+	// Large response
+	req := httptest.NewRequest(http.MethodGet, curvesURL, nil)
+	req.Header.Set("Accept-Encoding", "deflate, gzip;q=0.8")
+	res := httptest.NewRecorder()
+
+	handler.ServeHTTP(res, req)
+
+	if g, e := res.Code, http.StatusOK; g != e {
+		t.Fatalf("res.Code: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := res.Header().Get("Content-Encoding"), "gzip"; g != e {
+		t.Fatalf("Content-Encoding: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := res.Header().Get("Content-Type"), "application/json"; g != e {
+		t.Errorf("Content-Type: got '%v', expected '%v'", g, e)
+	}
+
+	reader, err := gzip.NewReader(res.Body)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	var response jsonCurvesResponse
+	if err := json.NewDecoder(reader).Decode(&response); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := len(response.Terms["hot"]), 1001; g != e {
+		t.Errorf("len(response.Terms[\"hot\"]): got '%v', expected '%v'", g, e)
+	}
+
+	testCases := []struct {
+		url            string
+		acceptEncoding string
+	}{
+		// Tiny body
+		{url: "/api/v1/engines", acceptEncoding: "gzip"},
+		// No gzip support
+		{url: curvesURL, acceptEncoding: ""},
+		{url: curvesURL, acceptEncoding: "gzip;q=0"},
+		// Error
+		{url: "/api/v1/engines/unknown", acceptEncoding: "gzip"},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+		req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+		res := httptest.NewRecorder()
+
+		handler.ServeHTTP(res, req)
+
+		if g := res.Header().Get("Content-Encoding"); g != "" {
+			t.Errorf("%s (%s): Content-Encoding: got '%v', expected none", tc.url, tc.acceptEncoding, g)
+		}
+
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if len(body) == 0 {
+			t.Errorf("%s (%s): expected a plain body", tc.url, tc.acceptEncoding)
+		}
+	}
+}
+
+func TestJSONRequestLogging(t *testing.T) {
+	registry := definitions.NewRegistry()
+	registry.Register(
+		"thermostat",
+		[]*fuzzy.Variable{
+			fuzzy.NewVariable("temperature", fuzzy.NewTerm("hot", fuzzy.Linear(20, 30))),
+		},
+		nil,
+	)
+
+	var out strings.Builder
+
+	handler := loggingMiddleware(logFormatJSON, &out, createHandler(registry, &Config{}))
+
+	for _, url := range []string{"/api/v1/engines/thermostat", "/api/v1/engines/unknown"} {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		res := httptest.NewRecorder()
+
+		handler.ServeHTTP(res, req)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if g, e := len(lines), 2; g != e {
+		t.Fatalf("len(lines): got '%v', expected '%v': %s", g, e, out.String())
+	}
+
+	var entries []jsonRequestLog
+	for _, line := range lines {
+		var entry jsonRequestLog
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if g, e := entries[0].Method, http.MethodGet; g != e {
+		t.Errorf("entries[0].Method: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := entries[0].Path, "/api/v1/engines/thermostat"; g != e {
+		t.Errorf("entries[0].Path: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := entries[0].Status, http.StatusOK; g != e {
+		t.Errorf("entries[0].Status: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := entries[0].Engine, "thermostat"; g != e {
+		t.Errorf("entries[0].Engine: got '%v', expected '%v'", g, e)
+	}
+
+	if entries[0].Bytes == 0 {
+		t.Errorf("entries[0].Bytes: got '%v', expected a non-zero size", entries[0].Bytes)
+	}
+
+	if entries[0].Time.IsZero() || entries[0].DurationMs < 0 {
+		t.Errorf("entries[0]: invalid time '%v' or duration '%v'", entries[0].Time, entries[0].DurationMs)
+	}
+
+	if g, e := entries[1].Status, http.StatusNotFound; g != e {
+		t.Errorf("entries[1].Status: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := entries[1].Engine, "unknown"; g != e {
+		t.Errorf("entries[1].Engine: got '%v', expected '%v'", g, e)
+	}
+
+	// The raw JSON object holds all the fields
+	var raw map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &raw); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	for _, field := range []string{"time", "method", "path", "status", "bytes", "durationMs", "engine"} {
+		if _, exists := raw[field]; !exists {
+			t.Errorf("field '%s' missing from '%s'", field, lines[0])
+		}
+	}
+}
+
+func TestPerVariableDefuzzify(t *testing.T) {
+	handler := createHandler(definitions.NewRegistry(), &Config{})
+
+	body := `{
+		"engine": {
+			"variables": [
+				{"name": "temperature", "terms": [{"name": "hot", "membership": {"type": "LINEAR", "params": [0, 100]}}]},
+				{"name": "heating", "terms": [{"name": "low", "membership": {"type": "TRAPEZOID", "params": [0, 10, 20, 100]}}]},
+				{"name": "fan", "terms": [{"name": "slow", "membership": {"type": "TRAPEZOID", "params": [0, 10, 20, 100]}}]}
+			],
+			"rules": [
+				{
+					"premise": {"type": "IS", "variable": "temperature", "term": "hot"},
+					"conclusions": [{"variable": "heating", "term": "low"}, {"variable": "fan", "term": "slow"}]
+				}
+			]
+		},
+		"inputs": {"temperature": 100}
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/infer?defuzz.fan=mean-max", strings.NewReader(body))
+	res := httptest.NewRecorder()
+
+	handler.ServeHTTP(res, req)
+
+	if g, e := res.Code, http.StatusOK; g != e {
+		t.Fatalf("res.Code: got '%v', expected '%v' (%s)", g, e, res.Body.String())
+	}
+
+	var response jsonInferenceResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := response.Results["fan"].Value, 15.0; g != e {
+		t.Errorf("fan: got '%v', expected '%v'", g, e)
+	}
+
+	// The heating is still defuzzified with the centroid
+	if g, e := response.Results["heating"].Value, 15.0; g == e {
+		t.Errorf("heating: got '%v', expected a centroid apart from '%v'", g, e)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/infer?defuzz.fan=unknown", strings.NewReader(body))
+	res = httptest.NewRecorder()
+
+	handler.ServeHTTP(res, req)
+
+	if g, e := res.Code, http.StatusBadRequest; g != e {
+		t.Errorf("res.Code: got '%v', expected '%v'", g, e)
+	}
+}
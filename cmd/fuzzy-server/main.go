@@ -1,89 +1,67 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
-	"path/filepath"
 	"strings"
+	"sync/atomic"
 
-	"github.com/bornholm/go-fuzzy/dsl"
-	"github.com/pkg/errors"
+	"github.com/bornholm/go-fuzzy/cmd/internal/definitions"
 )
 
-// LoadDSLFiles loads all .dsl files from the specified directory
-func loadFiles(pattern string) (map[string]string, error) {
-	files, err := filepath.Glob(pattern)
-	if err != nil {
-		return nil, errors.Errorf("failed to find files with pattern '%s': %+v", pattern, err)
-	}
+func main() {
+	config := parseConfig()
 
-	dslFiles := make(map[string]string)
-	for _, f := range files {
-		// Extract name without extension (my-engine.dsl -> my-engine)
-		name := strings.TrimSuffix(filepath.Base(f), filepath.Ext(f))
+	registry := definitions.NewRegistry()
 
-		content, err := os.ReadFile(f)
-		if err != nil {
-			return nil, errors.Errorf("failed to read file %s: %+v", f, err)
-		}
+	// Set once the definitions are loaded, see /readyz
+	var ready atomic.Bool
 
-		dslFiles[name] = string(content)
-	}
+	// Create HTTP handler
+	handler := createHandler(registry, config)
 
-	return dslFiles, nil
-}
+	handler = gzipMiddleware(handler)
+	handler = corsMiddleware(config.CORSOrigins, handler)
+	handler = loggingMiddleware(config.LogFormat, os.Stderr, handler)
+	handler = healthMiddleware(&ready, handler)
 
-// createRegistryFromDSL parses DSL content and creates associated registry
-func createRegistryFromDSL(dslFiles map[string]string) (*Registry, error) {
-	registry := NewRegistry()
+	go func() {
+		// Load DSL files
+		log.Printf("Loading fuzzy engine definition files from pattern '%s'", config.Definitions)
 
-	for name, content := range dslFiles {
-		// Parse rules and variables
-		result, err := dsl.ParseRulesAndVariables(content)
+		dslFiles, err := definitions.LoadFiles(config.Definitions)
 		if err != nil {
-			return nil, errors.Errorf("failed to parse DSL for engine %s: %+v", name, err)
+			log.Fatalf("Failed to load dsl files: %v", err)
 		}
 
-		// Register the engine
-		registry.Register(name, result.Variables, result.Rules)
-	}
-
-	return registry, nil
-}
-
-func main() {
-	config := parseConfig()
-
-	// Load DSL files
-	log.Printf("Loading fuzzy engine definition files from pattern '%s'", config.Definitions)
-
-	dslFiles, err := loadFiles(config.Definitions)
-	if err != nil {
-		log.Fatalf("Failed to load dsl files: %v", err)
-	}
+		if len(dslFiles) == 0 {
+			log.Printf("No files found with pattern '%s'", config.Definitions)
+		} else {
+			// Get engine names and join them for logging
+			engineNames := make([]string, 0, len(dslFiles))
+			for name := range dslFiles {
+				engineNames = append(engineNames, name)
+			}
+			log.Printf("Loaded %d definition files: %v", len(dslFiles), strings.Join(engineNames, ", "))
+		}
 
-	if len(dslFiles) == 0 {
-		log.Printf("No files found with pattern '%s'", config.Definitions)
-	} else {
-		// Get engine names and join them for logging
-		engineNames := make([]string, 0, len(dslFiles))
-		for name := range dslFiles {
-			engineNames = append(engineNames, name)
+		// Create engines from DSL files
+		if err := definitions.RegisterFromDSL(registry, dslFiles); err != nil {
+			log.Fatalf("Failed to create engines: %v", err)
 		}
-		log.Printf("Loaded %d definition files: %v", len(dslFiles), strings.Join(engineNames, ", "))
-	}
 
-	// Create engines from DSL files
-	registry, err := createRegistryFromDSL(dslFiles)
-	if err != nil {
-		log.Fatalf("Failed to create engines: %v", err)
-	}
+		ready.Store(true)
 
-	// Create HTTP handler
-	handler := createHandler(registry)
+		if config.Watch {
+			if err := definitions.Watch(context.Background(), registry, config.Definitions, config.WatchInterval); err != nil {
+				log.Fatalf("Failed to watch definition files: %v", err)
+			}
 
-	handler = loggingMiddleware(handler)
+			log.Printf("Watching definition files every %s", config.WatchInterval)
+		}
+	}()
 
 	// Start HTTP server
 	log.Printf("Starting server on %s", config.Address)
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// inferDurationBuckets are the upper bounds, in seconds, of the inference latency histogram
+var inferDurationBuckets = []float64{0.0001, 0.00025, 0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1}
+
+// engineMetrics holds the inference metrics of an engine
+type engineMetrics struct {
+	total    uint64
+	errors   uint64
+	buckets  []uint64 // Non-cumulative counts, the last one for +Inf
+	sum      float64
+	observed uint64
+}
+
+// Metrics collects the inference counts and latencies per engine and exposes them
+// in the Prometheus text format, without depending on the Prometheus client library.
+// It is safe for concurrent use.
+type Metrics struct {
+	mutex   sync.Mutex
+	engines map[string]*engineMetrics
+}
+
+// NewMetrics creates empty metrics
+func NewMetrics() *Metrics {
+	return &Metrics{
+		engines: make(map[string]*engineMetrics),
+	}
+}
+
+// ObserveInference records an inference of the given engine, failed if err is not nil
+func (m *Metrics) ObserveInference(engine string, duration time.Duration, err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	metrics, exists := m.engines[engine]
+	if !exists {
+		metrics = &engineMetrics{buckets: make([]uint64, len(inferDurationBuckets)+1)}
+		m.engines[engine] = metrics
+	}
+
+	metrics.total++
+	if err != nil {
+		metrics.errors++
+	}
+
+	seconds := duration.Seconds()
+
+	bucket, _ := slices.BinarySearch(inferDurationBuckets, seconds)
+	metrics.buckets[bucket]++
+	metrics.sum += seconds
+	metrics.observed++
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var sb strings.Builder
+
+	engines := slices.Sorted(maps.Keys(m.engines))
+
+	sb.WriteString("# HELP fuzzy_infer_total Number of inferences per engine.\n")
+	sb.WriteString("# TYPE fuzzy_infer_total counter\n")
+	for _, name := range engines {
+		fmt.Fprintf(&sb, "fuzzy_infer_total{engine=\"%s\"} %d\n", escapeLabelValue(name), m.engines[name].total)
+	}
+
+	sb.WriteString("# HELP fuzzy_infer_errors_total Number of failed inferences per engine.\n")
+	sb.WriteString("# TYPE fuzzy_infer_errors_total counter\n")
+	for _, name := range engines {
+		fmt.Fprintf(&sb, "fuzzy_infer_errors_total{engine=\"%s\"} %d\n", escapeLabelValue(name), m.engines[name].errors)
+	}
+
+	sb.WriteString("# HELP fuzzy_infer_duration_seconds Inference latency per engine.\n")
+	sb.WriteString("# TYPE fuzzy_infer_duration_seconds histogram\n")
+	for _, name := range engines {
+		metrics := m.engines[name]
+		label := escapeLabelValue(name)
+
+		var cumulative uint64
+		for i, count := range metrics.buckets {
+			cumulative += count
+
+			le := "+Inf"
+			if i < len(inferDurationBuckets) {
+				le = strconv.FormatFloat(inferDurationBuckets[i], 'g', -1, 64)
+			}
+
+			fmt.Fprintf(&sb, "fuzzy_infer_duration_seconds_bucket{engine=\"%s\",le=\"%s\"} %d\n", label, le, cumulative)
+		}
+
+		fmt.Fprintf(&sb, "fuzzy_infer_duration_seconds_sum{engine=\"%s\"} %s\n", label, strconv.FormatFloat(metrics.sum, 'g', -1, 64))
+		fmt.Fprintf(&sb, "fuzzy_infer_duration_seconds_count{engine=\"%s\"} %d\n", label, metrics.observed)
+	}
+
+	n, err := io.WriteString(w, sb.String())
+
+	return int64(n), err
+}
+
+// ServeHTTP exposes the metrics, i.e. to be scraped by Prometheus
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	if _, err := m.WriteTo(w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// escapeLabelValue escapes the backslashes, double quotes and line feeds of a label value
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
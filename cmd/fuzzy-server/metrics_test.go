@@ -0,0 +1,104 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bornholm/go-fuzzy"
+	"github.com/bornholm/go-fuzzy/cmd/internal/definitions"
+	"github.com/pkg/errors"
+)
+
+func TestMetrics(t *testing.T) {
+	registry := definitions.NewRegistry()
+	registry.Register(
+		"thermostat",
+		[]*fuzzy.Variable{
+			fuzzy.NewVariable("temperature", fuzzy.NewTerm("hot", fuzzy.Linear(20, 30))),
+			fuzzy.NewVariable("heating", fuzzy.NewTerm("low", fuzzy.Inverted(fuzzy.Linear(0, 100)))),
+		},
+		[]*fuzzy.Rule{
+			fuzzy.If(fuzzy.Is("temperature", "hot")).Then("heating", "low"),
+		},
+	)
+
+	handler := createHandler(registry, &Config{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/engines/thermostat", strings.NewReader(`{"temperature": 25}`))
+	res := httptest.NewRecorder()
+
+	handler.ServeHTTP(res, req)
+
+	if g, e := res.Code, http.StatusOK; g != e {
+		t.Fatalf("res.Code: got '%v', expected '%v'", g, e)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	res = httptest.NewRecorder()
+
+	handler.ServeHTTP(res, req)
+
+	if g, e := res.Code, http.StatusOK; g != e {
+		t.Fatalf("res.Code: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := res.Header().Get("Content-Type"), "text/plain; version=0.0.4; charset=utf-8"; g != e {
+		t.Errorf("Content-Type: got '%v', expected '%v'", g, e)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	expectedLines := []string{
+		"# TYPE fuzzy_infer_total counter",
+		`fuzzy_infer_total{engine="thermostat"} 1`,
+		`fuzzy_infer_errors_total{engine="thermostat"} 0`,
+		"# TYPE fuzzy_infer_duration_seconds histogram",
+		`fuzzy_infer_duration_seconds_bucket{engine="thermostat",le="+Inf"} 1`,
+		`fuzzy_infer_duration_seconds_count{engine="thermostat"} 1`,
+	}
+
+	for _, line := range expectedLines {
+		if !strings.Contains(string(body), line+"\n") {
+			t.Errorf("metrics: expected line '%s' in:\n%s", line, body)
+		}
+	}
+}
+
+func TestMetricsHistogram(t *testing.T) {
+	metrics := NewMetrics()
+
+	metrics.ObserveInference(`my "engine"`, 200*time.Microsecond, nil)
+	metrics.ObserveInference(`my "engine"`, 3*time.Millisecond, errors.New("failure"))
+	metrics.ObserveInference(`my "engine"`, 2*time.Second, nil)
+
+	var sb strings.Builder
+	if _, err := metrics.WriteTo(&sb); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	expectedLines := []string{
+		`fuzzy_infer_total{engine="my \"engine\""} 3`,
+		`fuzzy_infer_errors_total{engine="my \"engine\""} 1`,
+		`fuzzy_infer_duration_seconds_bucket{engine="my \"engine\"",le="0.0001"} 0`,
+		`fuzzy_infer_duration_seconds_bucket{engine="my \"engine\"",le="0.00025"} 1`,
+		`fuzzy_infer_duration_seconds_bucket{engine="my \"engine\"",le="0.0025"} 1`,
+		`fuzzy_infer_duration_seconds_bucket{engine="my \"engine\"",le="0.005"} 2`,
+		`fuzzy_infer_duration_seconds_bucket{engine="my \"engine\"",le="1"} 2`,
+		`fuzzy_infer_duration_seconds_bucket{engine="my \"engine\"",le="+Inf"} 3`,
+		`fuzzy_infer_duration_seconds_sum{engine="my \"engine\""} 2.0032`,
+		`fuzzy_infer_duration_seconds_count{engine="my \"engine\""} 3`,
+	}
+
+	for _, line := range expectedLines {
+		if !strings.Contains(sb.String(), line+"\n") {
+			t.Errorf("metrics: expected line '%s' in:\n%s", line, sb.String())
+		}
+	}
+}
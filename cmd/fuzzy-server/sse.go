@@ -0,0 +1,212 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/bornholm/go-fuzzy"
+	"github.com/bornholm/go-fuzzy/cmd/internal/definitions"
+	"github.com/pkg/errors"
+)
+
+// subscription is a Server-Sent Events stream of inference results,
+// fed by the inputs posted to its paired endpoint
+type subscription struct {
+	name   string
+	inputs chan fuzzy.Values
+	done   chan struct{}
+}
+
+// Subscriptions holds the opened Server-Sent Events streams
+type Subscriptions struct {
+	subscriptions map[string]*subscription
+	mutex         sync.RWMutex
+}
+
+func (s *Subscriptions) Get(id string) (*subscription, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	sub, exists := s.subscriptions[id]
+	return sub, exists
+}
+
+func (s *Subscriptions) add(name string) (string, *subscription, error) {
+	id, err := newSubscriptionID()
+	if err != nil {
+		return "", nil, errors.WithStack(err)
+	}
+
+	sub := &subscription{
+		name:   name,
+		inputs: make(chan fuzzy.Values),
+		done:   make(chan struct{}),
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.subscriptions[id] = sub
+
+	return id, sub, nil
+}
+
+func (s *Subscriptions) remove(id string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if sub, exists := s.subscriptions[id]; exists {
+		close(sub.done)
+		delete(s.subscriptions, id)
+	}
+}
+
+func NewSubscriptions() *Subscriptions {
+	return &Subscriptions{
+		subscriptions: make(map[string]*subscription),
+	}
+}
+
+func newSubscriptionID() (string, error) {
+	data := make([]byte, 16)
+	if _, err := rand.Read(data); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return hex.EncodeToString(data), nil
+}
+
+// createSubscribeHandler opens a Server-Sent Events stream on the named engine.
+// The first "subscribed" event gives the subscription identifier, then each inputs
+// posted to the paired endpoint produces a "results" (or "error") event.
+func createSubscribeHandler(registry *definitions.Registry, subscriptions *Subscriptions) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("name")
+
+		variables, rules, exists := registry.Get(name)
+		if !exists {
+			http.Error(w, fmt.Sprintf("Engine '%s' not found", name), http.StatusNotFound)
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		// The engine is reused for the whole subscription
+		engine, err := engineFromRequest(r, variables, rules)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		id, sub, err := subscriptions.add(name)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not subscribe: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		defer subscriptions.remove(id)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+
+		if err := sendEvent(w, flusher, "subscribed", struct {
+			ID string `json:"id"`
+		}{id}); err != nil {
+			log.Printf("[ERROR] could not send event: %+v", errors.WithStack(err))
+			return
+		}
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+
+			case inputs := <-sub.inputs:
+				var event string
+				var data any
+
+				response, err := inferSubscription(engine, inputs)
+				if err != nil {
+					event, data = "error", struct {
+						Message string `json:"message"`
+					}{err.Error()}
+				} else {
+					event, data = "results", response
+				}
+
+				if err := sendEvent(w, flusher, event, data); err != nil {
+					log.Printf("[ERROR] could not send event: %+v", errors.WithStack(err))
+					return
+				}
+			}
+		}
+	}
+}
+
+// createPublishHandler sends the posted inputs to the given subscription
+func createPublishHandler(subscriptions *Subscriptions) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name, id := r.PathValue("name"), r.PathValue("id")
+
+		sub, exists := subscriptions.Get(id)
+		if !exists || sub.name != name {
+			http.Error(w, fmt.Sprintf("Subscription '%s' not found", id), http.StatusNotFound)
+			return
+		}
+
+		var inputValues fuzzy.Values
+		if err := json.NewDecoder(r.Body).Decode(&inputValues); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid JSON: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		defer r.Body.Close()
+
+		select {
+		case sub.inputs <- inputValues:
+			w.WriteHeader(http.StatusAccepted)
+		case <-sub.done:
+			http.Error(w, fmt.Sprintf("Subscription '%s' closed", id), http.StatusGone)
+		case <-r.Context().Done():
+		}
+	}
+}
+
+func inferSubscription(engine *fuzzy.Engine, inputs fuzzy.Values) (*jsonInferenceResponse, error) {
+	results, err := engine.Infer(inputs)
+	if err != nil {
+		return nil, errors.Wrap(err, "inference error")
+	}
+
+	response, err := newInferenceResponse(engine, results)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not defuzzify value")
+	}
+
+	return response, nil
+}
+
+func sendEvent(w http.ResponseWriter, flusher http.Flusher, event string, data any) error {
+	rawData, err := json.Marshal(data)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, rawData); err != nil {
+		return errors.WithStack(err)
+	}
+
+	flusher.Flush()
+
+	return nil
+}
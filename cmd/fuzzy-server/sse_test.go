@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bornholm/go-fuzzy/cmd/internal/definitions"
+	"github.com/bornholm/go-fuzzy/dsl"
+	"github.com/pkg/errors"
+)
+
+func TestSubscription(t *testing.T) {
+	result, err := dsl.ParseRulesAndVariables(`
+DEFINE temperature ( TERM hot LINEAR (20, 30) );
+DEFINE fan_speed ( TERM high LINEAR (0, 100) );
+IF temperature IS hot THEN fan_speed IS high;
+`)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	registry := definitions.NewRegistry()
+	registry.Register("fan", result.Variables, result.Rules)
+
+	server := httptest.NewServer(createHandler(registry, &Config{}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/v1/engines/fan/sse", nil)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	defer res.Body.Close()
+
+	if g, e := res.Header.Get("Content-Type"), "text/event-stream"; g != e {
+		t.Errorf("Content-Type: got '%v', expected '%v'", g, e)
+	}
+
+	reader := bufio.NewReader(res.Body)
+
+	readEvent := func() (string, string) {
+		var event, data string
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				t.Fatalf("%+v", errors.WithStack(err))
+			}
+
+			line = strings.TrimSuffix(line, "\n")
+			switch {
+			case line == "":
+				return event, data
+			case strings.HasPrefix(line, "event: "):
+				event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}
+
+	event, data := readEvent()
+	if g, e := event, "subscribed"; g != e {
+		t.Fatalf("event: got '%v', expected '%v'", g, e)
+	}
+
+	var subscribed struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(data), &subscribed); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	publish := func(body string) int {
+		res, err := http.Post(server.URL+"/api/v1/engines/fan/sse/"+subscribed.ID, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+		defer res.Body.Close()
+
+		return res.StatusCode
+	}
+
+	if g, e := publish(`{"temperature": 25}`), http.StatusAccepted; g != e {
+		t.Errorf("publish status: got '%v', expected '%v'", g, e)
+	}
+
+	event, data = readEvent()
+	if g, e := event, "results"; g != e {
+		t.Fatalf("event: got '%v', expected '%v'", g, e)
+	}
+
+	var response jsonInferenceResponse
+	if err := json.Unmarshal([]byte(data), &response); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := response.Results["fan_speed"].Terms["high"].TruthDegree, 0.5; g != e {
+		t.Errorf("fan_speed IS high: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := publish(`{}`), http.StatusAccepted; g != e {
+		t.Errorf("publish status: got '%v', expected '%v'", g, e)
+	}
+
+	if event, _ := readEvent(); event != "error" {
+		t.Errorf("event: got '%v', expected '%v'", event, "error")
+	}
+
+	res, err = http.Post(server.URL+"/api/v1/engines/fan/sse/unknown", "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+	res.Body.Close()
+
+	if g, e := res.StatusCode, http.StatusNotFound; g != e {
+		t.Errorf("unknown subscription status: got '%v', expected '%v'", g, e)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bornholm/go-fuzzy/cmd/internal/definitions"
+	"github.com/pkg/errors"
+)
+
+func TestWatchDefinitions(t *testing.T) {
+	dir := t.TempDir()
+	pattern := filepath.Join(dir, "*.fuzzy")
+	path := filepath.Join(dir, "thermostat.fuzzy")
+
+	writeDefinition := func(content string, modTime time.Time) {
+		// Write to a file not matching the pattern and rename it, so that the watcher
+		// never reads a truncated definition
+		tmp := path + ".tmp"
+
+		if err := os.WriteFile(tmp, []byte(content), 0o644); err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		// Ensure the change is detected even with a coarse file system timestamp resolution
+		if err := os.Chtimes(tmp, modTime, modTime); err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if err := os.Rename(tmp, path); err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+	}
+
+	now := time.Now()
+
+	writeDefinition(`
+DEFINE temperature ( TERM hot LINEAR (20, 30) );
+DEFINE heating ( TERM low LINEAR (0, 100) );
+IF temperature IS hot THEN heating IS low;
+`, now.Add(-time.Hour))
+
+	dslFiles, err := definitions.LoadFiles(pattern)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	registry, err := definitions.NewRegistryFromDSL(dslFiles)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := definitions.Watch(ctx, registry, pattern, 10*time.Millisecond); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	handler := createHandler(registry, &Config{})
+
+	getEngine := func() string {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/engines/thermostat", nil)
+		res := httptest.NewRecorder()
+
+		handler.ServeHTTP(res, req)
+
+		return res.Body.String()
+	}
+
+	// Engine registered at runtime
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/engines/fan", strings.NewReader(`
+DEFINE temperature ( TERM hot LINEAR (20, 30) );
+DEFINE fan ( TERM fast LINEAR (0, 100) );
+IF temperature IS hot THEN fan IS fast;
+`))
+	res := httptest.NewRecorder()
+
+	handler.ServeHTTP(res, req)
+
+	if g, e := res.Code, http.StatusCreated; g != e {
+		t.Fatalf("PUT /api/v1/engines/fan: got status '%v', expected '%v'", g, e)
+	}
+
+	waitFor := func(expected string) bool {
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			if strings.Contains(getEngine(), expected) {
+				return true
+			}
+
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		return false
+	}
+
+	if !strings.Contains(getEngine(), "heating IS low") {
+		t.Fatalf("initial engine: got '%s', expected rule 'heating IS low'", getEngine())
+	}
+
+	// Modified definition
+	writeDefinition(`
+DEFINE temperature ( TERM hot LINEAR (20, 30) );
+DEFINE heating ( TERM high LINEAR (0, 100) );
+IF temperature IS hot THEN heating IS high;
+`, now.Add(-30*time.Minute))
+
+	if !waitFor("heating IS high") {
+		t.Fatalf("reloaded engine: got '%s', expected rule 'heating IS high'", getEngine())
+	}
+
+	// The engine registered at runtime survives the reload
+	if _, _, exists := registry.Get("fan"); !exists {
+		t.Errorf("registry.Get(\"fan\"): engine registered at runtime should survive the reload")
+	}
+
+	// Invalid definition, the previous one is retained
+	writeDefinition(`IF temperature IS THEN;`, now)
+
+	// Wait for a few polls
+	time.Sleep(100 * time.Millisecond)
+
+	if body := getEngine(); !strings.Contains(body, "heating IS high") {
+		t.Errorf("engine after invalid change: got '%s', expected rule 'heating IS high'", body)
+	}
+}
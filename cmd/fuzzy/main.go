@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bornholm/go-fuzzy"
+	"github.com/bornholm/go-fuzzy/dsl"
+	"github.com/pkg/errors"
+)
+
+// inputFlags collects repeated -in name=value flags
+type inputFlags fuzzy.Values
+
+// String implements flag.Value
+func (f inputFlags) String() string {
+	pairs := make([]string, 0, len(f))
+	for name, value := range f {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", name, value))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// Set implements flag.Value
+func (f inputFlags) Set(raw string) error {
+	name, rawValue, found := strings.Cut(raw, "=")
+	if !found || name == "" {
+		return errors.Errorf("invalid input '%s', expected name=value", raw)
+	}
+
+	value, err := strconv.ParseFloat(rawValue, 64)
+	if err != nil {
+		return errors.Errorf("invalid value '%s' for input '%s', expected a number", rawValue, name)
+	}
+
+	f[name] = value
+
+	return nil
+}
+
+func main() {
+	var (
+		definition string
+		stdin      bool
+	)
+
+	inputs := inputFlags{}
+
+	flag.StringVar(&definition, "definition", "", "dsl definition file to load")
+	flag.BoolVar(&stdin, "stdin", false, "read json inputs from standard input")
+	flag.Var(inputs, "in", "input value as name=value, can be repeated and overrides json inputs")
+	flag.Parse()
+
+	if definition == "" {
+		log.Fatal("missing -definition flag")
+	}
+
+	file, err := os.Open(definition)
+	if err != nil {
+		log.Fatalf("could not read definition file: %+v", errors.WithStack(err))
+	}
+
+	// Included files are resolved relatively to the definition file
+	resolveInclude := func(path string) (string, error) {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(definition), path)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+
+		return string(content), nil
+	}
+
+	result, err := dsl.ParseReader(file, dsl.WithIncludeResolver(resolveInclude))
+	file.Close()
+	if err != nil {
+		log.Fatalf("could not parse definition: %v", err)
+	}
+
+	values := fuzzy.Values{}
+
+	if stdin {
+		if err := decodeValues(os.Stdin, values); err != nil {
+			log.Fatalf("could not decode json inputs: %v", err)
+		}
+	}
+
+	// Flags override json inputs
+	for name, value := range inputs {
+		values[name] = value
+	}
+
+	engine := fuzzy.NewEngineWith(fuzzy.NamedCentroid(100))
+	engine.Variables(result.Variables...)
+	engine.Rules(result.Rules...)
+	result.ApplyDefuzzifiers(engine)
+
+	for _, w := range engine.Lint() {
+		log.Printf("[WARN] %s", w)
+	}
+
+	results, err := engine.Infer(values)
+	if err != nil {
+		log.Fatalf("could not infer results: %v", err)
+	}
+
+	type jsonVariableResult struct {
+		Value float64            `json:"value"`
+		Best  string             `json:"best,omitempty"`
+		Terms map[string]float64 `json:"terms,omitempty"`
+	}
+
+	output := make(map[string]jsonVariableResult, len(results))
+
+	for _, name := range results.Variables() {
+		value, err := engine.Defuzzify(name, results)
+		if err != nil {
+			log.Fatalf("could not defuzzify variable '%s': %v", name, err)
+		}
+
+		variableResult := jsonVariableResult{
+			Value: value,
+			Terms: make(map[string]float64),
+		}
+
+		if best, ok := results.Best(name); ok {
+			variableResult.Best = best.Term()
+		}
+
+		for term, res := range results[name] {
+			variableResult.Terms[term] = res.TruthDegree()
+		}
+
+		output[name] = variableResult
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", " ")
+	if err := encoder.Encode(output); err != nil {
+		log.Fatalf("could not encode results: %+v", errors.WithStack(err))
+	}
+}
+
+func decodeValues(r io.Reader, values fuzzy.Values) error {
+	if err := json.NewDecoder(r).Decode(&values); err != nil && !errors.Is(err, io.EOF) {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bornholm/go-fuzzy"
+)
+
+func TestInputFlags(t *testing.T) {
+	inputs := inputFlags{}
+
+	for _, raw := range []string{"temperature=30", "humidity=80.5", "temperature=-2"} {
+		if err := inputs.Set(raw); err != nil {
+			t.Fatalf("inputs.Set(%q): %v", raw, err)
+		}
+	}
+
+	if g, e := inputs["temperature"], -2.0; g != e {
+		t.Errorf("inputs[\"temperature\"]: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := inputs["humidity"], 80.5; g != e {
+		t.Errorf("inputs[\"humidity\"]: got '%v', expected '%v'", g, e)
+	}
+
+	for _, raw := range []string{"temperature", "=30", "temperature=hot"} {
+		if err := inputs.Set(raw); err == nil {
+			t.Errorf("inputs.Set(%q): expected an error", raw)
+		}
+	}
+}
+
+func TestDecodeValues(t *testing.T) {
+	values := fuzzy.Values{}
+
+	if err := decodeValues(strings.NewReader(`{"temperature": 30}`), values); err != nil {
+		t.Fatalf("decodeValues(): %v", err)
+	}
+
+	if g, e := values["temperature"], 30.0; g != e {
+		t.Errorf("values[\"temperature\"]: got '%v', expected '%v'", g, e)
+	}
+
+	if err := decodeValues(strings.NewReader(""), values); err != nil {
+		t.Errorf("decodeValues(\"\"): %v", err)
+	}
+}
@@ -0,0 +1,5 @@
+// Package definitions holds the fuzzy engine definitions shared by the fuzzy-server
+// and fuzzy-grpc commands: the registry of the engines loaded from DSL files, their
+// reloading, the construction of engines with the selected defuzzification functions
+// and the defuzzification of their inference results.
+package definitions
@@ -0,0 +1,39 @@
+package definitions
+
+import (
+	"github.com/bornholm/go-fuzzy"
+	"github.com/pkg/errors"
+)
+
+// ErrUnknownDefuzzifier is returned by NewDefuzzifier() for unsupported names
+var ErrUnknownDefuzzifier = errors.New("unknown defuzzification function")
+
+// NewDefuzzifier returns the defuzzification function associated with the given name
+func NewDefuzzifier(name string, steps int) (fuzzy.Defuzzifier, error) {
+	switch name {
+	case "", fuzzy.DefuzzifierCentroid:
+		return fuzzy.NamedCentroid(steps), nil
+	case fuzzy.DefuzzifierMeanOfMaximum:
+		return fuzzy.NamedMeanOfMaximum(steps), nil
+	default:
+		return nil, errors.Wrapf(ErrUnknownDefuzzifier, "'%s'", name)
+	}
+}
+
+// NewEngine creates a fuzzy engine from the given definition, checking that its
+// rules only reference defined variables and terms
+func NewEngine(variables []*fuzzy.Variable, rules []*fuzzy.Rule, defuzzify fuzzy.Defuzzifier) (*fuzzy.Engine, error) {
+	engine := fuzzy.NewEngineWith(defuzzify)
+
+	if err := engine.AddVariables(variables...); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	engine.AddRules(rules...)
+
+	if err := engine.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return engine, nil
+}
@@ -0,0 +1,107 @@
+package definitions
+
+import (
+	"github.com/bornholm/go-fuzzy"
+	"github.com/pkg/errors"
+)
+
+// DefaultSteps is the number of sampling steps of the defuzzification functions
+// when not given
+const DefaultSteps = 100
+
+// Defuzzification selects the defuzzification functions of an inference
+type Defuzzification struct {
+	// Name of the engine defuzzification function, see NewDefuzzifier()
+	Name string
+	// Steps is the number of sampling steps of the functions, DefaultSteps if zero
+	Steps int
+	// Variables overrides the engine defuzzification function for the given
+	// output variables, indexed by variable name
+	Variables map[string]string
+}
+
+// NewInferenceEngine creates an engine for the given definition, using the selected
+// defuzzification functions
+func NewInferenceEngine(variables []*fuzzy.Variable, rules []*fuzzy.Rule, defuzzification Defuzzification) (*fuzzy.Engine, error) {
+	steps := defuzzification.Steps
+	if steps == 0 {
+		steps = DefaultSteps
+	}
+
+	if steps < 0 {
+		return nil, errors.Errorf("invalid step value '%d', expected positive integer", steps)
+	}
+
+	defuzzify, err := NewDefuzzifier(defuzzification.Name, steps)
+	if err != nil {
+		return nil, errors.Errorf("invalid defuzzification function '%s'", defuzzification.Name)
+	}
+
+	engine, err := NewEngine(variables, rules, defuzzify)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid engine definition")
+	}
+
+	for variable, name := range defuzzification.Variables {
+		defuzzify, err := NewDefuzzifier(name, steps)
+		if err != nil {
+			return nil, errors.Errorf("invalid defuzzification function '%s' for variable '%s'", name, variable)
+		}
+
+		engine.SetDefuzzify(variable, defuzzify)
+	}
+
+	return engine, nil
+}
+
+// InferenceResult holds the defuzzified results of an inference
+type InferenceResult struct {
+	// Defuzzifier is the name of the engine defuzzification function
+	Defuzzifier string
+	// Variables holds the result of each output variable, indexed by name
+	Variables map[string]VariableResult
+}
+
+// VariableResult holds the defuzzified value of an output variable, its best term
+// and the truth degree of each of its terms
+type VariableResult struct {
+	Value float64
+	Best  string
+	Terms map[string]float64
+}
+
+// NewInferenceResult defuzzifies the results of an inference of the given engine
+func NewInferenceResult(engine *fuzzy.Engine, results fuzzy.Results) (*InferenceResult, error) {
+	inference := &InferenceResult{
+		Defuzzifier: engine.DefuzzifierName(),
+		Variables:   make(map[string]VariableResult, len(results)),
+	}
+
+	for name, variableResults := range results {
+		result := VariableResult{
+			Terms: make(map[string]float64, len(variableResults)),
+		}
+
+		if best, ok := results.Best(name); ok {
+			result.Best = best.Term()
+		}
+
+		// Get defuzzified value if possible
+		if len(variableResults) > 0 {
+			value, err := engine.Defuzzify(name, results)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+
+			result.Value = value
+		}
+
+		for term, r := range variableResults {
+			result.Terms[term] = r.TruthDegree()
+		}
+
+		inference.Variables[name] = result
+	}
+
+	return inference, nil
+}
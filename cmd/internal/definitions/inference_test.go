@@ -0,0 +1,70 @@
+package definitions
+
+import (
+	"testing"
+
+	"github.com/bornholm/go-fuzzy"
+	"github.com/pkg/errors"
+)
+
+func TestNewInferenceEngine(t *testing.T) {
+	registry, err := NewRegistryFromDSL(map[string]string{
+		"thermostat": `
+DEFINE temperature ( TERM cold LINEAR (20, 0), TERM hot LINEAR (20, 30) );
+DEFINE heating ( TERM low TRIANGULAR (0, 25, 50), TERM high TRIANGULAR (50, 75, 100) );
+
+IF temperature IS cold THEN heating IS high;
+IF temperature IS hot THEN heating IS low;
+`,
+	})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	variables, rules, _ := registry.Get("thermostat")
+
+	invalid := []Defuzzification{
+		{Name: "unknown"},
+		{Steps: -1},
+		{Variables: map[string]string{"heating": "unknown"}},
+	}
+
+	for i, d := range invalid {
+		if _, err := NewInferenceEngine(variables, rules, d); err == nil {
+			t.Errorf("test case #%d: expected an error", i)
+		}
+	}
+
+	engine, err := NewInferenceEngine(variables, rules, Defuzzification{Name: "mean-max"})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	results, err := engine.Infer(fuzzy.Values{"temperature": 30})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	inference, err := NewInferenceResult(engine, results)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := inference.Defuzzifier, "mean-max"; g != e {
+		t.Errorf("inference.Defuzzifier: got '%v', expected '%v'", g, e)
+	}
+
+	heating := inference.Variables["heating"]
+
+	if g, e := heating.Best, "low"; g != e {
+		t.Errorf("heating best: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := heating.Value, 25.0; g != e {
+		t.Errorf("heating value: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := heating.Terms["low"], 1.0; g != e {
+		t.Errorf("heating IS low: got '%v', expected '%v'", g, e)
+	}
+}
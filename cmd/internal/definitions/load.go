@@ -0,0 +1,64 @@
+package definitions
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bornholm/go-fuzzy/dsl"
+	"github.com/pkg/errors"
+)
+
+// LoadFiles reads the DSL files matching the pattern, indexed by their name without extension
+func LoadFiles(pattern string) (map[string]string, error) {
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, errors.Errorf("failed to find files with pattern '%s': %+v", pattern, err)
+	}
+
+	dslFiles := make(map[string]string)
+	for _, f := range files {
+		// Extract name without extension (my-engine.dsl -> my-engine)
+		name := strings.TrimSuffix(filepath.Base(f), filepath.Ext(f))
+
+		content, err := os.ReadFile(f)
+		if err != nil {
+			return nil, errors.Errorf("failed to read file %s: %+v", f, err)
+		}
+
+		dslFiles[name] = string(content)
+	}
+
+	return dslFiles, nil
+}
+
+// NewRegistryFromDSL parses DSL content and creates associated registry
+func NewRegistryFromDSL(dslFiles map[string]string) (*Registry, error) {
+	registry := NewRegistry()
+
+	if err := RegisterFromDSL(registry, dslFiles); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return registry, nil
+}
+
+// RegisterFromDSL parses DSL content and registers the resulting engines in the registry
+func RegisterFromDSL(registry *Registry, dslFiles map[string]string) error {
+	for name, content := range dslFiles {
+		// Parse rules and variables
+		result, err := dsl.ParseRulesAndVariables(content)
+		if err != nil {
+			return errors.Errorf("failed to parse DSL for engine %s: %+v", name, err)
+		}
+
+		if _, err := NewEngine(result.Variables, result.Rules, nil); err != nil {
+			return errors.Errorf("invalid definition for engine %s: %v", name, err)
+		}
+
+		// Register the engine
+		registry.Register(name, result.Variables, result.Rules)
+	}
+
+	return nil
+}
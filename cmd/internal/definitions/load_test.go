@@ -0,0 +1,45 @@
+package definitions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestNewRegistryFromDSLDuplicateDefinitions(t *testing.T) {
+	_, err := NewRegistryFromDSL(map[string]string{
+		"duplicate": `
+DEFINE temperature ( TERM hot LINEAR (20, 30), TERM hot LINEAR (25, 35) );
+`,
+	})
+	if err == nil {
+		t.Errorf("NewRegistryFromDSL(): expected an error on duplicate terms")
+	}
+}
+
+func TestNewRegistryFromDSLInvalidDefinitions(t *testing.T) {
+	_, err := NewRegistryFromDSL(map[string]string{
+		"undefined": `
+DEFINE temperature ( TERM hot LINEAR (20, 30) );
+IF temperature IS hot THEN fan IS fast;
+`,
+	})
+	if err == nil {
+		t.Fatalf("NewRegistryFromDSL(): expected an error on undefined variables")
+	}
+
+	if g, e := err.Error(), "rule #0: variable 'fan': undefined variable"; !strings.Contains(g, e) {
+		t.Errorf("NewRegistryFromDSL(): got '%v', expected to contain '%v'", g, e)
+	}
+
+	// The bundled examples are valid
+	files, err := LoadFiles("../../fuzzy-server/examples/*.fuzzy")
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if _, err := NewRegistryFromDSL(files); err != nil {
+		t.Errorf("NewRegistryFromDSL(examples): %+v", errors.WithStack(err))
+	}
+}
@@ -0,0 +1,154 @@
+package definitions
+
+import (
+	"maps"
+	"sync"
+
+	"github.com/bornholm/go-fuzzy"
+)
+
+type registryEntry struct {
+	Rules     []*fuzzy.Rule
+	Variables []*fuzzy.Variable
+	// FromFile is true for the definitions loaded from the definition files,
+	// false for the ones registered at runtime with Put() or Replace()
+	FromFile bool
+}
+
+// Registry holds all the loaded fuzzy engine definitions.
+// It is safe for concurrent use.
+type Registry struct {
+	mutex   sync.RWMutex
+	entries map[string]registryEntry
+	// deleted holds the names of the definitions removed at runtime,
+	// which are not restored by Reload()
+	deleted map[string]struct{}
+}
+
+// NewRegistry creates a new registry
+func NewRegistry() *Registry {
+	return &Registry{
+		entries: make(map[string]registryEntry),
+		deleted: make(map[string]struct{}),
+	}
+}
+
+// Get returns a fuzzy engine definition by name
+func (r *Registry) Get(name string) ([]*fuzzy.Variable, []*fuzzy.Rule, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	entry, exists := r.entries[name]
+	if !exists {
+		return nil, nil, false
+	}
+
+	return entry.Variables, entry.Rules, exists
+}
+
+// Register adds a fuzzy engine definition loaded from the definition files to the registry
+func (r *Registry) Register(name string, variables []*fuzzy.Variable, rules []*fuzzy.Rule) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.deleted, name)
+
+	r.entries[name] = registryEntry{
+		Rules:     rules,
+		Variables: variables,
+		FromFile:  true,
+	}
+}
+
+// Put registers or replaces a fuzzy engine definition and returns true if it was not registered yet
+func (r *Registry) Put(name string, variables []*fuzzy.Variable, rules []*fuzzy.Rule) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	_, exists := r.entries[name]
+
+	delete(r.deleted, name)
+
+	r.entries[name] = registryEntry{
+		Rules:     rules,
+		Variables: variables,
+	}
+
+	return !exists
+}
+
+// Replace atomically swaps the definition of an already registered fuzzy engine.
+// It returns false, leaving the registry untouched, if no engine is registered under this name.
+func (r *Registry) Replace(name string, variables []*fuzzy.Variable, rules []*fuzzy.Rule) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.entries[name]; !exists {
+		return false
+	}
+
+	r.entries[name] = registryEntry{
+		Rules:     rules,
+		Variables: variables,
+	}
+
+	return true
+}
+
+// Delete removes a fuzzy engine definition and returns true if it was registered.
+// A deleted definition is not restored by Reload().
+func (r *Registry) Delete(name string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	_, exists := r.entries[name]
+	if exists {
+		delete(r.entries, name)
+		r.deleted[name] = struct{}{}
+	}
+
+	return exists
+}
+
+// Reload atomically replaces the definitions loaded from the definition files by the ones
+// of the other registry, i.e. after reloading the files. The definitions registered or deleted
+// at runtime are left as is. Definitions retrieved before the reload are unaffected.
+func (r *Registry) Reload(other *Registry) {
+	other.mutex.RLock()
+	reloaded := maps.Clone(other.entries)
+	other.mutex.RUnlock()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for name, entry := range r.entries {
+		if _, exists := reloaded[name]; entry.FromFile && !exists {
+			delete(r.entries, name)
+		}
+	}
+
+	for name, entry := range reloaded {
+		if _, deleted := r.deleted[name]; deleted {
+			continue
+		}
+
+		if current, exists := r.entries[name]; exists && !current.FromFile {
+			continue
+		}
+
+		entry.FromFile = true
+		r.entries[name] = entry
+	}
+}
+
+// Names returns all registered fuzzy engine definition names
+func (r *Registry) Names() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	names := make([]string, 0, len(r.entries))
+	for name := range r.entries {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,170 @@
+package definitions
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/bornholm/go-fuzzy"
+)
+
+func TestRegistryReplace(t *testing.T) {
+	registry := NewRegistry()
+
+	if replaced := registry.Replace("unknown", nil, nil); replaced {
+		t.Errorf("registry.Replace(\"unknown\"): got '%v', expected '%v'", replaced, false)
+	}
+
+	if _, _, exists := registry.Get("unknown"); exists {
+		t.Errorf("registry.Get(\"unknown\"): engine should not have been registered by Replace")
+	}
+
+	registry.Register("engine", []*fuzzy.Variable{fuzzy.NewVariable("a")}, nil)
+
+	variables := []*fuzzy.Variable{fuzzy.NewVariable("b")}
+	if replaced := registry.Replace("engine", variables, nil); !replaced {
+		t.Errorf("registry.Replace(\"engine\"): got '%v', expected '%v'", replaced, true)
+	}
+
+	got, _, exists := registry.Get("engine")
+	if !exists {
+		t.Fatalf("registry.Get(\"engine\"): engine should exist")
+	}
+
+	if g, e := got[0].Name(), "b"; g != e {
+		t.Errorf("registry.Get(\"engine\"): got variable '%v', expected '%v'", g, e)
+	}
+}
+
+func TestRegistryConcurrentGetAndReplace(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("engine", []*fuzzy.Variable{fuzzy.NewVariable("a")}, nil)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				variables, _, exists := registry.Get("engine")
+				if !exists || len(variables) != 1 {
+					t.Errorf("registry.Get(\"engine\"): unexpected definition %v", variables)
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for j := 0; j < 1000; j++ {
+			registry.Replace("engine", []*fuzzy.Variable{fuzzy.NewVariable("b")}, nil)
+		}
+	}()
+
+	wg.Wait()
+}
+
+func TestRegistryConcurrentGetAndRegister(t *testing.T) {
+	registry := NewRegistry()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				registry.Get("engine")
+				registry.Names()
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for j := 0; j < 1000; j++ {
+			registry.Register("engine", []*fuzzy.Variable{fuzzy.NewVariable("a")}, nil)
+			registry.Register(fmt.Sprintf("engine-%d", j), nil, nil)
+		}
+	}()
+
+	wg.Wait()
+
+	if _, _, exists := registry.Get("engine"); !exists {
+		t.Errorf("registry.Get(\"engine\"): engine should exist")
+	}
+}
+
+func TestRegistryDelete(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("engine", []*fuzzy.Variable{fuzzy.NewVariable("a")}, nil)
+
+	if deleted := registry.Delete("unknown"); deleted {
+		t.Errorf("registry.Delete(\"unknown\"): got '%v', expected '%v'", deleted, false)
+	}
+
+	if deleted := registry.Delete("engine"); !deleted {
+		t.Errorf("registry.Delete(\"engine\"): got '%v', expected '%v'", deleted, true)
+	}
+
+	if _, _, exists := registry.Get("engine"); exists {
+		t.Errorf("registry.Get(\"engine\"): engine should have been deleted")
+	}
+
+	if deleted := registry.Delete("engine"); deleted {
+		t.Errorf("registry.Delete(\"engine\") twice: got '%v', expected '%v'", deleted, false)
+	}
+}
+
+func TestRegistryReload(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("kept", []*fuzzy.Variable{fuzzy.NewVariable("a")}, nil)
+	registry.Register("removed", nil, nil)
+	registry.Register("deleted", nil, nil)
+	registry.Register("overridden", []*fuzzy.Variable{fuzzy.NewVariable("a")}, nil)
+
+	registry.Put("runtime", nil, nil)
+	registry.Put("overridden", []*fuzzy.Variable{fuzzy.NewVariable("runtime")}, nil)
+	registry.Delete("deleted")
+
+	reloaded := NewRegistry()
+	reloaded.Register("kept", []*fuzzy.Variable{fuzzy.NewVariable("b")}, nil)
+	reloaded.Register("added", nil, nil)
+	reloaded.Register("deleted", nil, nil)
+	reloaded.Register("overridden", []*fuzzy.Variable{fuzzy.NewVariable("b")}, nil)
+
+	registry.Reload(reloaded)
+
+	testCases := []struct {
+		name     string
+		exists   bool
+		variable string
+	}{
+		{name: "kept", exists: true, variable: "b"},
+		{name: "added", exists: true},
+		{name: "removed", exists: false},
+		{name: "deleted", exists: false},
+		{name: "runtime", exists: true},
+		{name: "overridden", exists: true, variable: "runtime"},
+	}
+
+	for _, tc := range testCases {
+		variables, _, exists := registry.Get(tc.name)
+		if g, e := exists, tc.exists; g != e {
+			t.Errorf("registry.Get(\"%s\"): got '%v', expected '%v'", tc.name, g, e)
+			continue
+		}
+
+		if tc.variable == "" {
+			continue
+		}
+
+		if g, e := variables[0].Name(), tc.variable; g != e {
+			t.Errorf("registry.Get(\"%s\"): got variable '%v', expected '%v'", tc.name, g, e)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package definitions
+
+import (
+	"context"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// fileState identifies a version of a definition file
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// Watch polls the files matching the pattern at the given interval and, when
+// one of them is added, removed or modified, rebuilds the definitions and reloads them in the
+// registry, see Registry.Reload(). On error, the previous definitions are retained.
+//
+// The current state of the files is captured before returning, the polling running in
+// the background until ctx is done.
+func Watch(ctx context.Context, registry *Registry, pattern string, interval time.Duration) error {
+	previous, err := definitionStates(pattern)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			current, err := definitionStates(pattern)
+			if err != nil {
+				log.Printf("[ERROR] could not watch definition files: %+v", errors.WithStack(err))
+				continue
+			}
+
+			if maps.Equal(previous, current) {
+				continue
+			}
+
+			previous = current
+
+			if err := reloadDefinitions(registry, pattern); err != nil {
+				log.Printf("[ERROR] could not reload definitions, keeping the previous ones: %v", err)
+				continue
+			}
+
+			log.Printf("Reloaded definitions from pattern '%s'", pattern)
+		}
+	}()
+
+	return nil
+}
+
+// reloadDefinitions rebuilds the definitions from the files matching the pattern and
+// reloads them in the registry, leaving it untouched on error
+func reloadDefinitions(registry *Registry, pattern string) error {
+	dslFiles, err := LoadFiles(pattern)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	reloaded, err := NewRegistryFromDSL(dslFiles)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	registry.Reload(reloaded)
+
+	return nil
+}
+
+// definitionStates returns the state of each file matching the pattern
+func definitionStates(pattern string) (map[string]fileState, error) {
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	states := make(map[string]fileState, len(files))
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		states[f] = fileState{modTime: info.ModTime(), size: info.Size()}
+	}
+
+	return states, nil
+}
@@ -0,0 +1,88 @@
+package fuzzy
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Comparison operators of CompareExpr
+const (
+	OpLess           = "<"
+	OpLessOrEqual    = "<="
+	OpGreater        = ">"
+	OpGreaterOrEqual = ">="
+	OpEqual          = "=="
+)
+
+// CompareExpr is a crisp guard comparing the raw input of a variable to a number,
+// i.e. "temperature > 30". Its value is 1 if the comparison holds, 0 otherwise,
+// so that crisp conditions can be mixed with fuzzy ones in the same premise.
+type CompareExpr struct {
+	variable  string
+	operator  string
+	threshold float64
+}
+
+func (e *CompareExpr) Value(ctx *Context) (float64, error) {
+	value, err := ctx.Value(e.variable)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	var holds bool
+
+	switch e.operator {
+	case OpLess:
+		holds = value < e.threshold
+	case OpLessOrEqual:
+		holds = value <= e.threshold
+	case OpGreater:
+		holds = value > e.threshold
+	case OpGreaterOrEqual:
+		holds = value >= e.threshold
+	case OpEqual:
+		holds = value == e.threshold
+	}
+
+	if holds {
+		return 1, nil
+	}
+
+	return 0, nil
+}
+
+func (e *CompareExpr) Variable() string {
+	return e.variable
+}
+
+// Operator returns the comparison operator, i.e. OpGreater
+func (e *CompareExpr) Operator() string {
+	return e.operator
+}
+
+// Threshold returns the number the input is compared to
+func (e *CompareExpr) Threshold() float64 {
+	return e.threshold
+}
+
+// Compare returns an expression comparing the input of the variable to the threshold
+// with the given operator (OpLess, OpLessOrEqual, OpGreater, OpGreaterOrEqual or OpEqual).
+// It panics if the operator is unknown, see CompareChecked().
+func Compare(variable, operator string, threshold float64) *CompareExpr {
+	expr, err := CompareChecked(variable, operator, threshold)
+	if err != nil {
+		panic(errors.WithStack(err))
+	}
+
+	return expr
+}
+
+// CompareChecked returns an expression comparing the input of the variable to the threshold,
+// or an error wrapping ErrUnsupportedExpr if the operator is unknown
+func CompareChecked(variable, operator string, threshold float64) (*CompareExpr, error) {
+	switch operator {
+	case OpLess, OpLessOrEqual, OpGreater, OpGreaterOrEqual, OpEqual:
+		return &CompareExpr{variable, operator, threshold}, nil
+	default:
+		return nil, errors.Wrapf(ErrUnsupportedExpr, "comparison operator '%s'", operator)
+	}
+}
@@ -0,0 +1,113 @@
+package fuzzy
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestCompare(t *testing.T) {
+	testCases := []struct {
+		operator    string
+		temperature float64
+		expected    float64
+	}{
+		{operator: OpLess, temperature: 29, expected: 1},
+		{operator: OpLess, temperature: 30, expected: 0},
+		{operator: OpLessOrEqual, temperature: 30, expected: 1},
+		{operator: OpLessOrEqual, temperature: 31, expected: 0},
+		{operator: OpGreater, temperature: 31, expected: 1},
+		{operator: OpGreater, temperature: 30, expected: 0},
+		{operator: OpGreaterOrEqual, temperature: 30, expected: 1},
+		{operator: OpGreaterOrEqual, temperature: 29, expected: 0},
+		{operator: OpEqual, temperature: 30, expected: 1},
+		{operator: OpEqual, temperature: 30.5, expected: 0},
+	}
+
+	for _, tc := range testCases {
+		engine := NewEngine(nil).
+			Variables(
+				NewVariable(
+					"temperature",
+					NewTerm("hot", Linear(20, 40)),
+				),
+				NewVariable(
+					"alarm",
+					NewTerm("on", Linear(0, 1)),
+				),
+			).
+			Rules(
+				If(Compare("temperature", tc.operator, 30)).Then("alarm", "on"),
+			)
+
+		results, err := engine.Infer(Values{"temperature": tc.temperature})
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if g, e := results["alarm"]["on"].TruthDegree(), tc.expected; g != e {
+			t.Errorf("temperature %s 30 with %v: got '%v', expected '%v'", tc.operator, tc.temperature, g, e)
+		}
+	}
+}
+
+func TestCompareWithFuzzyCondition(t *testing.T) {
+	engine := NewEngine(nil).
+		Variables(
+			NewVariable(
+				"temperature",
+				NewTerm("hot", Linear(20, 40)),
+			),
+			NewVariable(
+				"alarm",
+				NewTerm("on", Linear(0, 1)),
+			),
+		).
+		Rules(
+			If(And(Is("temperature", "hot"), Compare("temperature", OpGreaterOrEqual, 25))).Then("alarm", "on"),
+		)
+
+	testCases := []struct {
+		temperature float64
+		expected    float64
+	}{
+		{temperature: 24, expected: 0},
+		{temperature: 30, expected: 0.5},
+	}
+
+	for _, tc := range testCases {
+		results, err := engine.Infer(Values{"temperature": tc.temperature})
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if g, e := results["alarm"]["on"].TruthDegree(), tc.expected; g != e {
+			t.Errorf("alarm IS on with temperature %v: got '%v', expected '%v'", tc.temperature, g, e)
+		}
+	}
+
+	if g, e := engine.rules[0].String(), "IF temperature IS hot AND temperature >= 25 THEN alarm IS on"; g != e {
+		t.Errorf("rule.String(): got '%v', expected '%v'", g, e)
+	}
+
+	data, err := json.Marshal(engine)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	restored, err := EngineFromJSON(data)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := restored.rules[0].String(), engine.rules[0].String(); g != e {
+		t.Errorf("restored rule: got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestCompareCheckedUnknownOperator(t *testing.T) {
+	if _, err := CompareChecked("temperature", "!=", 30); !errors.Is(err, ErrUnsupportedExpr) {
+		t.Errorf("CompareChecked(): got '%v', expected '%v'", err, ErrUnsupportedExpr)
+	}
+}
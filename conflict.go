@@ -0,0 +1,85 @@
+package fuzzy
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ConflictResolution is the strategy combining the rules concluding the same output variable
+type ConflictResolution int
+
+const (
+	// Aggregate combines the conclusions of all the firing rules with the aggregation
+	// function of the engine
+	Aggregate ConflictResolution = iota
+
+	// HighestPriorityWins only keeps, for each output variable, the conclusions of the
+	// firing rules with the highest priority (see Rule.WithPriority()). Rules sharing the
+	// highest priority are aggregated together.
+	HighestPriorityWins
+)
+
+// WithConflictResolution sets the strategy combining the rules concluding the same
+// output variable. Defaults to Aggregate.
+func (e *Engine) WithConflictResolution(mode ConflictResolution) *Engine {
+	e.conflictResolution = mode
+	return e
+}
+
+// firingPriorities returns, for each output variable, the highest priority of the rules
+// firing a conclusion on it, i.e. whose conclusion, or ELSE conclusion, gets a
+// truth degree above zero, premiseValue returning the truth degree of the premise of the
+// rule at the given index. It stops with the error of runCtx as soon as it is done.
+func (e *Engine) firingPriorities(runCtx context.Context, premiseValue func(i int) (float64, error), now time.Time) (map[string]int, error) {
+	priorities := make(map[string]int)
+
+	fire := func(r *Rule, conclusions []*IsExpr) {
+		for _, c := range conclusions {
+			if priority, exists := priorities[c.Variable()]; !exists || r.priority > priority {
+				priorities[c.Variable()] = r.priority
+			}
+		}
+	}
+
+	for i, r := range e.rules {
+		if err := runCtx.Err(); err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		truthDegree, err := premiseValue(i)
+		if err != nil {
+			return nil, errors.Wrapf(err, "rule #%d", i)
+		}
+
+		strength := r.weight
+		if !now.IsZero() {
+			strength *= r.DecayFactor(now)
+		}
+
+		if truthDegree*strength > 0 {
+			fire(r, r.conclusions)
+		}
+
+		if (1-truthDegree)*strength > 0 {
+			fire(r, r.alternatives)
+		}
+	}
+
+	return priorities, nil
+}
+
+// winningConclusions returns the conclusions of the rule on the variables for which
+// it has the highest firing priority, or on which no rule fires
+func winningConclusions(r *Rule, conclusions []*IsExpr, priorities map[string]int) []*IsExpr {
+	winning := make([]*IsExpr, 0, len(conclusions))
+
+	for _, c := range conclusions {
+		if priority, exists := priorities[c.Variable()]; !exists || r.priority == priority {
+			winning = append(winning, c)
+		}
+	}
+
+	return winning
+}
@@ -0,0 +1,112 @@
+package fuzzy
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestConflictResolution(t *testing.T) {
+	newEngine := func() *Engine {
+		return NewEngine(nil).
+			Variables(
+				NewVariable(
+					"temperature",
+					NewTerm("hot", Linear(20, 40)),
+				),
+				NewVariable(
+					"smoke",
+					NewTerm("detected", Linear(0, 10)),
+				),
+				NewVariable(
+					"ac_mode",
+					NewTerm("cooling", Triangular(0, 25, 50)),
+					NewTerm("off", Triangular(50, 75, 100)),
+				),
+				NewVariable(
+					"fan",
+					NewTerm("fast", Linear(0, 100)),
+				),
+			).
+			Rules(
+				If(Is("temperature", "hot")).Then("ac_mode", "cooling").Then("fan", "fast"),
+				// Safety rule overriding the comfort one
+				If(Is("smoke", "detected")).Then("ac_mode", "off").WithPriority(10),
+				// Never fires
+				If(Not(Is("temperature", "hot"))).Then("ac_mode", "cooling").WithPriority(20),
+			)
+	}
+
+	values := Values{"temperature": 40, "smoke": 5}
+
+	aggregated, err := newEngine().Infer(values)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := aggregated["ac_mode"]["cooling"].TruthDegree(), 1.0; g != e {
+		t.Errorf("aggregate: ac_mode IS cooling: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := aggregated["ac_mode"]["off"].TruthDegree(), 0.5; g != e {
+		t.Errorf("aggregate: ac_mode IS off: got '%v', expected '%v'", g, e)
+	}
+
+	prioritized, err := newEngine().WithConflictResolution(HighestPriorityWins).Infer(values)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if _, exists := prioritized["ac_mode"]["cooling"]; exists {
+		t.Errorf("highest priority wins: ac_mode IS cooling: got '%v', expected no result", prioritized["ac_mode"]["cooling"].TruthDegree())
+	}
+
+	if g, e := prioritized["ac_mode"]["off"].TruthDegree(), 0.5; g != e {
+		t.Errorf("highest priority wins: ac_mode IS off: got '%v', expected '%v'", g, e)
+	}
+
+	// The lower priority rule still concludes the outputs without conflict
+	if g, e := prioritized["fan"]["fast"].TruthDegree(), 1.0; g != e {
+		t.Errorf("highest priority wins: fan IS fast: got '%v', expected '%v'", g, e)
+	}
+
+	// Without smoke, the safety rule does not fire and the comfort rule wins
+	prioritized, err = newEngine().WithConflictResolution(HighestPriorityWins).Infer(Values{"temperature": 40, "smoke": 0})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := prioritized["ac_mode"]["cooling"].TruthDegree(), 1.0; g != e {
+		t.Errorf("highest priority wins without smoke: ac_mode IS cooling: got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestRulePriorityFormatAndJSON(t *testing.T) {
+	engine := NewEngine(nil).
+		Variables(
+			NewVariable("smoke", NewTerm("detected", Linear(0, 10))),
+			NewVariable("ac_mode", NewTerm("off", Triangular(50, 75, 100))),
+		).
+		Rules(
+			If(Is("smoke", "detected")).Then("ac_mode", "off").WithWeight(0.5).WithPriority(10),
+		)
+
+	if g, e := engine.rules[0].String(), "IF smoke IS detected THEN ac_mode IS off WEIGHT 0.5 PRIORITY 10"; g != e {
+		t.Errorf("rule.String(): got '%v', expected '%v'", g, e)
+	}
+
+	data, err := json.Marshal(engine)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	restored, err := EngineFromJSON(data)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := restored.rules[0].Priority(), 10; g != e {
+		t.Errorf("restored priority: got '%v', expected '%v'", g, e)
+	}
+}
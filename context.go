@@ -2,14 +2,26 @@ package fuzzy
 
 import (
 	"math"
+	"sync"
 
 	"github.com/pkg/errors"
 )
 
 type Context struct {
-	variables map[string]*Variable
-	inputs    map[string]float64
-	results   map[string]map[string]Result
+	variables   map[string]*Variable
+	inputs      map[string]float64
+	results     map[string]map[string]Result
+	implication ImplicationFunc
+	aggregation AggregationFunc
+	norms       Norms
+
+	// clampInputs clamps the input values to the universe of their variable
+	clampInputs bool
+
+	// memberships caches the membership degrees of the IS expressions, by variable
+	// and term, nil if disabled (see Engine.WithMembershipCache())
+	memberships      map[[2]string]float64
+	membershipsMutex sync.Mutex
 }
 
 func (c *Context) Variable(name string) (*Variable, error) {
@@ -21,15 +33,56 @@ func (c *Context) Variable(name string) (*Variable, error) {
 	return v, nil
 }
 
+// Norms returns the operators used by the AND and OR expressions
+func (c *Context) Norms() Norms {
+	return c.norms
+}
+
+// Value returns the input value of the variable, clamped to the universe of
+// the variable if the context clamps its inputs (see Engine.WithClampInputs())
 func (c *Context) Value(variable string) (float64, error) {
 	v, exists := c.inputs[variable]
 	if !exists {
 		return 0, errors.WithStack(ErrValueNotFound)
 	}
 
+	if c.clampInputs {
+		if target, exists := c.variables[variable]; exists {
+			v = math.Max(target.UniverseMin(), math.Min(target.UniverseMax(), v))
+		}
+	}
+
 	return v, nil
 }
 
+// cachedMembership returns the cached membership degree of the input of the variable
+// in the term, and false if it is not cached
+func (c *Context) cachedMembership(variable, term string) (float64, bool) {
+	if c.memberships == nil {
+		return 0, false
+	}
+
+	c.membershipsMutex.Lock()
+	defer c.membershipsMutex.Unlock()
+
+	degree, exists := c.memberships[[2]string{variable, term}]
+
+	return degree, exists
+}
+
+// cacheMembership caches the membership degree of the input of the variable in the term,
+// the inputs being fixed for the lifetime of the context
+func (c *Context) cacheMembership(variable, term string, degree float64) {
+	if c.memberships == nil {
+		return
+	}
+
+	c.membershipsMutex.Lock()
+	defer c.membershipsMutex.Unlock()
+
+	c.memberships[[2]string{variable, term}] = degree
+}
+
 func (c *Context) AddResult(variable string, term *Term, truthDegree float64) {
 	terms, exists := c.results[variable]
 	if !exists {
@@ -44,12 +97,12 @@ func (c *Context) AddResult(variable string, term *Term, truthDegree float64) {
 		}
 	}
 
-	clippedMembership := Min(Constant(truthDegree), term.Membership())
+	impliedMembership := c.implication(term.Membership(), truthDegree)
 
 	if result.membership != nil {
-		result.membership = Max(result.Membership(), clippedMembership)
+		result.membership = c.aggregation(result.Membership(), impliedMembership)
 	} else {
-		result.membership = clippedMembership
+		result.membership = impliedMembership
 	}
 
 	result.truthDegree = math.Max(result.truthDegree, truthDegree)
@@ -57,6 +110,32 @@ func (c *Context) AddResult(variable string, term *Term, truthDegree float64) {
 	c.results[variable] = terms
 }
 
+// AddCrispResult adds the conclusion of a Takagi-Sugeno rule, i.e. a crisp value
+// fired with the given truth degree. Conclusions on the same value are combined
+// with the maximum of their truth degrees.
+func (c *Context) AddCrispResult(variable string, value float64, truthDegree float64) {
+	terms, exists := c.results[variable]
+	if !exists {
+		terms = make(map[string]Result)
+	}
+
+	name := formatValue(value)
+
+	result, exists := terms[name]
+	if !exists {
+		result = Result{
+			term:        name,
+			truthDegree: truthDegree,
+			crisp:       true,
+			value:       value,
+		}
+	}
+
+	result.truthDegree = math.Max(result.truthDegree, truthDegree)
+	terms[name] = result
+	c.results[variable] = terms
+}
+
 func (c *Context) Result(variable string) map[string]Result {
 	terms, exists := c.results[variable]
 	if !exists {
@@ -70,20 +149,53 @@ func (c *Context) Results() Results {
 	return c.results
 }
 
+// NewContext creates an inference context for the given variables and inputs.
+// It panics if two variables share the same name, see NewContextChecked().
 func NewContext(variables []*Variable, inputs map[string]float64) *Context {
-	vars := make(map[string]*Variable, len(inputs))
+	ctx, err := NewContextChecked(variables, inputs)
+	if err != nil {
+		panic(errors.WithStack(err))
+	}
+
+	return ctx
+}
+
+// NewContextChecked creates an inference context for the given variables and inputs,
+// returning an error wrapping ErrVariableAlreadyExists if two variables share the same name
+func NewContextChecked(variables []*Variable, inputs map[string]float64) (*Context, error) {
+	vars, err := indexVariables(variables)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return newContext(vars, inputs), nil
+}
+
+// newContext creates an inference context for the given variables indexed by name,
+// the index being only read and thus shareable between contexts
+func newContext(variables map[string]*Variable, inputs map[string]float64) *Context {
+	return &Context{
+		variables:   variables,
+		inputs:      inputs,
+		results:     make(map[string]map[string]Result),
+		implication: MinImplication,
+		aggregation: MaxAggregation,
+		norms:       MinMax,
+	}
+}
+
+// indexVariables returns the variables indexed by name, or an error wrapping
+// ErrVariableAlreadyExists if two variables share the same name
+func indexVariables(variables []*Variable) (map[string]*Variable, error) {
+	vars := make(map[string]*Variable, len(variables))
 
 	for _, v := range variables {
 		if _, exists := vars[v.Name()]; exists {
-			panic(errors.WithStack(ErrVariableAlreadyExists))
+			return nil, errors.Wrapf(ErrVariableAlreadyExists, "variable '%s'", v.Name())
 		}
 
 		vars[v.Name()] = v
 	}
 
-	return &Context{
-		variables: vars,
-		inputs:    inputs,
-		results:   make(map[string]map[string]Result),
-	}
+	return vars, nil
 }
@@ -0,0 +1,113 @@
+package fuzzy
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	crossoverSteps      = 1000
+	crossoverIterations = 50
+)
+
+// Crossover is a point where two terms of a variable have the same membership degree
+type Crossover struct {
+	A      string
+	B      string
+	X      float64
+	Degree float64
+}
+
+// Crossovers returns the points where each pair of terms of the variable have the
+// same, non-zero, membership degree. A well-formed partition crosses at 0.5.
+//
+// The universe is sampled at 1000 regularly spaced points to locate the sign changes
+// of the difference between both memberships, each crossover being then refined by
+// bisection. Crossovers closer than a sampling step from one another may be missed,
+// as may be the ones where both memberships touch without crossing.
+func (v *Variable) Crossovers() []Crossover {
+	names := make([]string, 0, len(v.terms))
+	for name := range v.terms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	crossovers := make([]Crossover, 0)
+
+	if math.IsInf(v.universeMin, 0) || math.IsInf(v.universeMax, 0) {
+		return crossovers
+	}
+
+	step := (v.universeMax - v.universeMin) / crossoverSteps
+
+	for i, nameA := range names {
+		for _, nameB := range names[i+1:] {
+			a, b := v.terms[nameA].Membership(), v.terms[nameB].Membership()
+			diff := func(x float64) float64 {
+				return a.Value(x) - b.Value(x)
+			}
+
+			var (
+				lastX    float64
+				lastSign float64
+			)
+
+			for s := 0; s <= crossoverSteps; s++ {
+				x := v.universeMin + float64(s)*step
+				sign := sign(diff(x))
+
+				if sign == 0 && a.Value(x) > 0 {
+					crossovers = append(crossovers, Crossover{A: nameA, B: nameB, X: x, Degree: a.Value(x)})
+					lastSign = 0
+					continue
+				}
+
+				if sign != 0 && lastSign != 0 && sign != lastSign {
+					x := bisect(diff, lastX, x)
+					if degree := a.Value(x); degree > 0 {
+						crossovers = append(crossovers, Crossover{A: nameA, B: nameB, X: x, Degree: degree})
+					}
+				}
+
+				if sign != 0 {
+					lastX, lastSign = x, sign
+				}
+			}
+		}
+	}
+
+	return crossovers
+}
+
+func sign(x float64) float64 {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// bisect returns the root of fn in [low, high], fn(low) and fn(high) having opposite signs
+func bisect(fn func(x float64) float64, low, high float64) float64 {
+	lowSign := sign(fn(low))
+
+	for i := 0; i < crossoverIterations; i++ {
+		mid := (low + high) / 2
+		midSign := sign(fn(mid))
+
+		if midSign == 0 {
+			return mid
+		}
+
+		if midSign == lowSign {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+
+	return (low + high) / 2
+}
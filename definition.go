@@ -0,0 +1,499 @@
+package fuzzy
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Kinds of the built-in memberships, as used in serialized definitions
+const (
+	KindConstant    = "CONSTANT"
+	KindLinear      = "LINEAR"
+	KindTriangular  = "TRIANGULAR"
+	KindTrapezoid   = "TRAPEZOID"
+	KindInverted    = "INVERTED"
+	KindMin         = "MIN"
+	KindMax         = "MAX"
+	KindSigmoid     = "SIGMOID"
+	KindRectangular = "RECTANGULAR"
+	KindSCurve      = "SCURVE"
+	KindZCurve      = "ZCURVE"
+)
+
+// Kinds of the built-in expressions, as used in serialized definitions
+const (
+	exprKindIs          = "IS"
+	exprKindAnd         = "AND"
+	exprKindOr          = "OR"
+	exprKindXor         = "XOR"
+	exprKindNot         = "NOT"
+	exprKindHedge       = "HEDGE"
+	exprKindCompare     = "COMPARE"
+	exprKindWeightedAnd = "WEIGHTED_AND"
+)
+
+// MembershipDefinition describes a built-in membership by its kind (see KindLinear...),
+// its parameters and, for the combinators (INVERTED, MIN, MAX), its child memberships
+type MembershipDefinition struct {
+	Type     string                 `json:"type"`
+	Params   []float64              `json:"params,omitempty"`
+	Children []MembershipDefinition `json:"children,omitempty"`
+}
+
+type exprDefinition struct {
+	Type     string           `json:"type"`
+	Variable string           `json:"variable,omitempty"`
+	Term     string           `json:"term,omitempty"`
+	Exponent float64          `json:"exponent,omitempty"`
+	Operator string           `json:"operator,omitempty"`
+	Value    *float64         `json:"value,omitempty"`
+	Weights  []float64        `json:"weights,omitempty"`
+	Children []exprDefinition `json:"children,omitempty"`
+}
+
+// termDefinition always carries the weight of the term: gob omitting zero
+// values, a missing weight can not be told apart from an explicit 0.
+type termDefinition struct {
+	Name       string
+	Membership MembershipDefinition
+	Weight     float64
+}
+
+type variableDefinition struct {
+	Name     string
+	Terms    []termDefinition
+	Universe []float64
+}
+
+type conclusionDefinition struct {
+	Variable string  `json:"variable"`
+	Term     string  `json:"term,omitempty"`
+	Crisp    bool    `json:"crisp,omitempty"`
+	Value    float64 `json:"value,omitempty"`
+}
+
+// ruleDefinition always carries the weight of the rule, as termDefinition does.
+// Rule.UnmarshalJSON() defaults it to 1 when missing from the JSON document.
+type ruleDefinition struct {
+	Premise      exprDefinition         `json:"premise"`
+	Conclusions  []conclusionDefinition `json:"conclusions"`
+	Alternatives []conclusionDefinition `json:"alternatives,omitempty"`
+	Weight       float64                `json:"weight"`
+	Priority     int                    `json:"priority,omitempty"`
+	Timestamp    time.Time              `json:"timestamp,omitzero"`
+	HalfLife     time.Duration          `json:"halfLife,omitempty"`
+}
+
+type engineDefinition struct {
+	Variables []variableDefinition
+	Rules     []ruleDefinition
+}
+
+// DescribeMembership returns the definition of the given membership. It returns an
+// error wrapping ErrUnsupportedMembership for memberships not implementing Describable.
+func DescribeMembership(m Membership) (MembershipDefinition, error) {
+	describable, ok := m.(Describable)
+	if !ok {
+		return MembershipDefinition{}, errors.Wrapf(ErrUnsupportedMembership, "%T", m)
+	}
+
+	kind, params, memberships := describable.Describe()
+
+	def := MembershipDefinition{
+		Type:   kind,
+		Params: params,
+	}
+
+	for _, m := range memberships {
+		child, err := DescribeMembership(m)
+		if err != nil {
+			return MembershipDefinition{}, errors.WithStack(err)
+		}
+
+		def.Children = append(def.Children, child)
+	}
+
+	return def, nil
+}
+
+// Build creates the membership described by the definition
+func (d MembershipDefinition) Build() (Membership, error) {
+	children := make([]Membership, 0, len(d.Children))
+	for _, c := range d.Children {
+		child, err := c.Build()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		children = append(children, child)
+	}
+
+	expectParams := func(total int) error {
+		if len(d.Params) != total {
+			return errors.Errorf("membership %s expects %d parameters, got %d", d.Type, total, len(d.Params))
+		}
+		return nil
+	}
+
+	switch d.Type {
+	case KindConstant:
+		if err := expectParams(1); err != nil {
+			return nil, err
+		}
+		return Constant(d.Params[0]), nil
+	case KindLinear:
+		if err := expectParams(2); err != nil {
+			return nil, err
+		}
+		return Linear(d.Params[0], d.Params[1]), nil
+	case KindTriangular:
+		if err := expectParams(3); err != nil {
+			return nil, err
+		}
+		return Triangular(d.Params[0], d.Params[1], d.Params[2]), nil
+	case KindTrapezoid:
+		if err := expectParams(4); err != nil {
+			return nil, err
+		}
+		return Trapezoid(d.Params[0], d.Params[1], d.Params[2], d.Params[3]), nil
+	case KindRectangular:
+		if err := expectParams(2); err != nil {
+			return nil, err
+		}
+		return Rectangular(d.Params[0], d.Params[1]), nil
+	case KindSCurve:
+		if err := expectParams(2); err != nil {
+			return nil, err
+		}
+		return SCurve(d.Params[0], d.Params[1]), nil
+	case KindZCurve:
+		if err := expectParams(2); err != nil {
+			return nil, err
+		}
+		return ZCurve(d.Params[0], d.Params[1]), nil
+	case KindSigmoid:
+		if err := expectParams(2); err != nil {
+			return nil, err
+		}
+		return Sigmoid(d.Params[0], d.Params[1]), nil
+	case KindInverted:
+		if len(children) != 1 {
+			return nil, errors.Errorf("membership %s expects 1 membership, got %d", d.Type, len(children))
+		}
+		return Inverted(children[0]), nil
+	case KindMin:
+		return Min(children...), nil
+	case KindMax:
+		return Max(children...), nil
+	default:
+		return nil, errors.Wrapf(ErrUnsupportedMembership, "%s", d.Type)
+	}
+}
+
+func describeExpr(e Expr) (exprDefinition, error) {
+	var (
+		kind     string
+		exprs    []Expr
+		exponent float64
+		weights  []float64
+	)
+
+	switch typ := e.(type) {
+	case *IsExpr:
+		return exprDefinition{Type: exprKindIs, Variable: typ.variable, Term: typ.term}, nil
+	case *CompareExpr:
+		threshold := typ.threshold
+		return exprDefinition{Type: exprKindCompare, Variable: typ.variable, Operator: typ.operator, Value: &threshold}, nil
+	case *AndExpr:
+		kind, exprs = exprKindAnd, typ.exprs
+	case *OrExpr:
+		kind, exprs = exprKindOr, typ.exprs
+	case *XorExpr:
+		kind, exprs = exprKindXor, typ.exprs
+	case *WeightedAndExpr:
+		kind, exprs = exprKindWeightedAnd, typ.exprs
+		weights = typ.weights
+	case *NotExpr:
+		kind, exprs = exprKindNot, []Expr{typ.expr}
+	case *HedgeExpr:
+		kind, exprs = exprKindHedge, []Expr{typ.expr}
+		exponent = typ.exponent
+	default:
+		return exprDefinition{}, errors.Wrapf(ErrUnsupportedExpr, "%T", e)
+	}
+
+	def := exprDefinition{
+		Type:     kind,
+		Exponent: exponent,
+		Weights:  weights,
+		Children: make([]exprDefinition, 0, len(exprs)),
+	}
+
+	for _, e := range exprs {
+		child, err := describeExpr(e)
+		if err != nil {
+			return exprDefinition{}, errors.WithStack(err)
+		}
+
+		def.Children = append(def.Children, child)
+	}
+
+	return def, nil
+}
+
+func (d exprDefinition) build() (Expr, error) {
+	children := make([]Expr, 0, len(d.Children))
+	for _, c := range d.Children {
+		child, err := c.build()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		children = append(children, child)
+	}
+
+	switch d.Type {
+	case exprKindIs:
+		return Is(d.Variable, d.Term), nil
+	case exprKindCompare:
+		if d.Value == nil {
+			return nil, errors.Errorf("expression %s expects a value", d.Type)
+		}
+
+		expr, err := CompareChecked(d.Variable, d.Operator, *d.Value)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		return expr, nil
+	case exprKindAnd:
+		if len(children) == 0 {
+			return nil, errors.WithStack(ErrMissingArguments)
+		}
+		return And(children...), nil
+	case exprKindOr:
+		if len(children) == 0 {
+			return nil, errors.WithStack(ErrMissingArguments)
+		}
+		return Or(children...), nil
+	case exprKindXor:
+		if len(children) == 0 {
+			return nil, errors.WithStack(ErrMissingArguments)
+		}
+		return Xor(children...), nil
+	case exprKindWeightedAnd:
+		expr, err := WeightedAndChecked(d.Weights, children...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "expression %s", d.Type)
+		}
+		return expr, nil
+	case exprKindNot:
+		if len(children) != 1 {
+			return nil, errors.Errorf("expression %s expects 1 expression, got %d", d.Type, len(children))
+		}
+		return Not(children[0]), nil
+	case exprKindHedge:
+		if len(children) != 1 {
+			return nil, errors.Errorf("expression %s expects 1 expression, got %d", d.Type, len(children))
+		}
+		return Hedge(children[0], d.Exponent), nil
+	default:
+		return nil, errors.Wrapf(ErrUnsupportedExpr, "%s", d.Type)
+	}
+}
+
+func describeVariable(v *Variable) (variableDefinition, error) {
+	def := variableDefinition{
+		Name:  v.Name(),
+		Terms: make([]termDefinition, 0, len(v.terms)),
+	}
+
+	for _, t := range v.Terms() {
+		membership, err := DescribeMembership(t.Membership())
+		if err != nil {
+			return variableDefinition{}, errors.Wrapf(err, "term '%s' of variable '%s'", t.Name(), v.Name())
+		}
+
+		def.Terms = append(def.Terms, termDefinition{
+			Name:       t.Name(),
+			Membership: membership,
+			Weight:     t.Weight(),
+		})
+	}
+
+	if v.customUniverse {
+		def.Universe = []float64{v.universeMin, v.universeMax}
+	}
+
+	return def, nil
+}
+
+func (d variableDefinition) build() (*Variable, error) {
+	terms := make([]*Term, 0, len(d.Terms))
+	for _, t := range d.Terms {
+		membership, err := t.Membership.Build()
+		if err != nil {
+			return nil, errors.Wrapf(err, "term '%s' of variable '%s'", t.Name, d.Name)
+		}
+
+		terms = append(terms, NewTerm(t.Name, membership).WithWeight(t.Weight))
+	}
+
+	variable, err := NewVariableChecked(d.Name, terms...)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if err := applyUniverse(variable, d.Universe); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return variable, nil
+}
+
+// applyUniverse overrides the universe of the variable with the given [min, max]
+// bounds, if any
+func applyUniverse(v *Variable, universe []float64) error {
+	if universe == nil {
+		return nil
+	}
+
+	if len(universe) != 2 || universe[0] > universe[1] {
+		return errors.Errorf("invalid universe %v for variable '%s', expected [min, max]", universe, v.Name())
+	}
+
+	v.WithUniverse(universe[0], universe[1])
+
+	return nil
+}
+
+func describeRule(r *Rule) (ruleDefinition, error) {
+	if len(r.conclusions) == 0 {
+		return ruleDefinition{}, errors.WithStack(ErrMissingArguments)
+	}
+
+	premise, err := describeExpr(r.premise)
+	if err != nil {
+		return ruleDefinition{}, errors.WithStack(err)
+	}
+
+	timestamp, halfLife := r.Decay()
+
+	return ruleDefinition{
+		Premise:      premise,
+		Conclusions:  describeConclusions(r.conclusions),
+		Alternatives: describeConclusions(r.alternatives),
+		Weight:       r.Weight(),
+		Priority:     r.Priority(),
+		Timestamp:    timestamp,
+		HalfLife:     halfLife,
+	}, nil
+}
+
+func describeConclusions(conclusions []*IsExpr) []conclusionDefinition {
+	defs := make([]conclusionDefinition, 0, len(conclusions))
+
+	for _, c := range conclusions {
+		value, crisp := c.CrispValue()
+
+		defs = append(defs, conclusionDefinition{
+			Variable: c.Variable(),
+			Term:     c.Term(),
+			Crisp:    crisp,
+			Value:    value,
+		})
+	}
+
+	return defs
+}
+
+func (d ruleDefinition) build() (*Rule, error) {
+	premise, err := d.Premise.build()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if len(d.Conclusions) == 0 {
+		return nil, errors.WithStack(ErrMissingArguments)
+	}
+
+	rule := If(premise)
+	for _, c := range d.Conclusions {
+		if c.Crisp {
+			rule.ThenValue(c.Variable, c.Value)
+		} else {
+			rule.Then(c.Variable, c.Term)
+		}
+	}
+
+	for _, c := range d.Alternatives {
+		if c.Crisp {
+			rule.ElseValue(c.Variable, c.Value)
+		} else {
+			rule.Else(c.Variable, c.Term)
+		}
+	}
+
+	rule.WithWeight(d.Weight).WithPriority(d.Priority).WithDecay(d.Timestamp, d.HalfLife)
+
+	return rule, nil
+}
+
+func describeEngine(e *Engine) (engineDefinition, error) {
+	def := engineDefinition{
+		Variables: make([]variableDefinition, 0, len(e.variables)),
+		Rules:     make([]ruleDefinition, 0, len(e.rules)),
+	}
+
+	for _, v := range e.variables {
+		variable, err := describeVariable(v)
+		if err != nil {
+			return engineDefinition{}, errors.WithStack(err)
+		}
+
+		def.Variables = append(def.Variables, variable)
+	}
+
+	for i, r := range e.rules {
+		rule, err := describeRule(r)
+		if err != nil {
+			return engineDefinition{}, errors.Wrap(err, fmt.Sprintf("rule #%d", i))
+		}
+
+		def.Rules = append(def.Rules, rule)
+	}
+
+	return def, nil
+}
+
+func (d engineDefinition) build(defuzzify Defuzzifier) (*Engine, error) {
+	variables := make([]*Variable, 0, len(d.Variables))
+	for _, v := range d.Variables {
+		variable, err := v.build()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		variables = append(variables, variable)
+	}
+
+	rules := make([]*Rule, 0, len(d.Rules))
+	for i, r := range d.Rules {
+		rule, err := r.build()
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("rule #%d", i))
+		}
+
+		rules = append(rules, rule)
+	}
+
+	engine := NewEngineWith(defuzzify)
+	if err := engine.AddVariables(variables...); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	engine.Rules(rules...)
+
+	return engine, nil
+}
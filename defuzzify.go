@@ -2,43 +2,153 @@ package fuzzy
 
 import "math"
 
-func Centroid(steps int) func(m Membership, min, max float64) float64 {
-	return func(m Membership, min, max float64) float64 {
-		var (
-			num float64
-			den float64
-		)
+// Defuzzifier converts an aggregated membership back to a crisp value
+type Defuzzifier interface {
+	Defuzzify(m Membership, min, max float64) float64
+	Name() string
+}
+
+// Names of the defuzzification functions, as reported by Defuzzifier.Name()
+const (
+	DefuzzifierCentroid      = "centroid"
+	DefuzzifierMeanOfMaximum = "mean-max"
+	DefuzzifierCustom        = "custom"
+)
+
+// DefuzzifyFunc is a bare defuzzification function, as returned by Centroid() or
+// MeanOfMaximum(). It implements Defuzzifier under the "custom" name.
+type DefuzzifyFunc func(m Membership, min, max float64) float64
+
+func (fn DefuzzifyFunc) Defuzzify(m Membership, min, max float64) float64 {
+	return fn(m, min, max)
+}
+
+// Name returns "custom", as a bare function does not carry a name.
+// See NamedDefuzzify().
+func (fn DefuzzifyFunc) Name() string {
+	return DefuzzifierCustom
+}
+
+// NamedDefuzzifier is a defuzzification function carrying an identifier
+type NamedDefuzzifier struct {
+	name string
+	fn   DefuzzifyFunc
+}
+
+func (d *NamedDefuzzifier) Defuzzify(m Membership, min, max float64) float64 {
+	return d.fn(m, min, max)
+}
+
+func (d *NamedDefuzzifier) Name() string {
+	return d.name
+}
+
+// NamedDefuzzify associates the given name to the defuzzification function,
+// i.e. NamedDefuzzify("mean-max", MeanOfMaximum(100))
+func NamedDefuzzify(name string, fn DefuzzifyFunc) *NamedDefuzzifier {
+	return &NamedDefuzzifier{name, fn}
+}
 
+// NamedCentroid returns the centroid defuzzification, reported as "centroid"
+func NamedCentroid(steps int) *NamedDefuzzifier {
+	return NamedDefuzzify(DefuzzifierCentroid, Centroid(steps))
+}
+
+// NamedMeanOfMaximum returns the mean of maximum defuzzification, reported as "mean-max"
+func NamedMeanOfMaximum(steps int) *NamedDefuzzifier {
+	return NamedDefuzzify(DefuzzifierMeanOfMaximum, MeanOfMaximum(steps))
+}
+
+// defaultDefuzzifier returns the centroid used when no defuzzification function is given
+func defaultDefuzzifier() Defuzzifier {
+	return NamedCentroid(defaultCentroidSteps)
+}
+
+func Centroid(steps int) DefuzzifyFunc {
+	return func(m Membership, min, max float64) float64 {
 		if math.IsInf(min, 0) || math.IsInf(max, 0) || min >= max {
 			return 0
 		}
 
-		step := math.Max(1.0/float64(steps), (max-min)/float64(steps))
+		area, moment := integrate(m, min, max, steps)
+		if area == 0 {
+			return (min + max) / 2
+		}
+
+		return moment / area
+	}
+}
+
+// Area returns the area under the membership over its domain, integrated with the
+// trapezoidal rule over steps+1 samples. It returns 0 for unbounded or empty domains.
+func Area(m Membership, steps int) float64 {
+	min, max := m.Domain()
+	if math.IsInf(min, 0) || math.IsInf(max, 0) || min >= max {
+		return 0
+	}
 
-		for x := min; x <= max; x += step {
-			y := m.Value(x)
-			num += y * x
-			den += y
+	if steps < 1 {
+		steps = 1
+	}
+
+	area, _ := integrate(m, min, max, steps)
+
+	// The endpoints only bound half a step
+	step := (max - min) / float64(steps)
+	area -= step * (m.Value(min) + m.Value(max)) / 2
+
+	return area
+}
+
+// CentroidOf returns the center of mass of the membership over its domain, sampled like
+// Centroid(). It returns the midpoint of the domain if the membership is zero everywhere,
+// and 0 for unbounded or empty domains.
+func CentroidOf(m Membership, steps int) float64 {
+	min, max := m.Domain()
+	return Centroid(steps).Defuzzify(m, min, max)
+}
+
+// maxAdaptiveSteps bounds the number of samples of AdaptiveCentroid
+const maxAdaptiveSteps = 100000
+
+// AdaptiveCentroid computes the center of mass of the membership, sampled every
+// resolution units over the universe, i.e. (max-min)/resolution samples bounded
+// to 100000, which gives an accuracy independent of the universe scale
+func AdaptiveCentroid(resolution float64) DefuzzifyFunc {
+	return func(m Membership, min, max float64) float64 {
+		if math.IsInf(min, 0) || math.IsInf(max, 0) || min >= max {
+			return 0
+		}
+
+		steps := maxAdaptiveSteps
+		if resolution > 0 {
+			steps = int(math.Min(math.Ceil((max-min)/resolution), maxAdaptiveSteps))
 		}
 
-		if den == 0 {
+		area, moment := integrate(m, min, max, steps)
+		if area == 0 {
 			return (min + max) / 2
 		}
 
-		return num / den
+		return moment / area
 	}
 }
 
-func MeanOfMaximum(steps int) func(m Membership, min, max float64) float64 {
+func MeanOfMaximum(steps int) DefuzzifyFunc {
 	return func(m Membership, min, max float64) float64 {
 		if math.IsInf(min, 0) || math.IsInf(max, 0) || min >= max {
 			return (min + max) / 2
 		}
 
+		if steps < 1 {
+			steps = 1
+		}
+
 		step := (max - min) / float64(steps)
 
 		maxMembershipValue := 0.0
-		for x := min; x <= max; x += step {
+		for i := 0; i <= steps; i++ {
+			x := sampleAt(min, max, step, i, steps)
 			y := m.Value(x)
 			if y > maxMembershipValue {
 				maxMembershipValue = y
@@ -51,7 +161,8 @@ func MeanOfMaximum(steps int) func(m Membership, min, max float64) float64 {
 
 		var maxValues []float64
 		const epsilon = 1e-9
-		for x := min; x <= max; x += step {
+		for i := 0; i <= steps; i++ {
+			x := sampleAt(min, max, step, i, steps)
 			y := m.Value(x)
 			if math.Abs(y-maxMembershipValue) < epsilon {
 				maxValues = append(maxValues, x)
@@ -70,3 +181,49 @@ func MeanOfMaximum(steps int) func(m Membership, min, max float64) float64 {
 		return sum / float64(len(maxValues))
 	}
 }
+
+// integrate samples the membership at steps+1 evenly spaced points of [min, max], each
+// sample standing for a step, and returns the resulting area under the membership and its
+// first moment (the integral of x*m(x)). Their ratio is the centroid of the membership.
+func integrate(m Membership, min, max float64, steps int) (area, moment float64) {
+	if steps < 1 {
+		steps = 1
+	}
+
+	step := (max - min) / float64(steps)
+
+	for i := 0; i <= steps; i++ {
+		x := sampleAt(min, max, step, i, steps)
+		y := m.Value(x)
+		area += y
+		moment += y * x
+	}
+
+	return area * step, moment * step
+}
+
+// sampleAt returns the i-th of the steps+1 samples of [min, max]. Iterating over integer
+// indices avoids the floating point accumulation which could miss max.
+func sampleAt(min, max, step float64, i, steps int) float64 {
+	if i == steps {
+		return max
+	}
+
+	return min + float64(i)*step
+}
+
+// ConvergenceProfile returns the defuzzified values of the membership for increasing
+// step counts, i.e. profile[i] is the value obtained with method(i+1), up to maxSteps.
+// It helps choosing the minimum steps reaching a given accuracy.
+func ConvergenceProfile(m Membership, min, max float64, method func(steps int) DefuzzifyFunc, maxSteps int) []float64 {
+	if maxSteps < 1 {
+		return []float64{}
+	}
+
+	profile := make([]float64, maxSteps)
+	for i := range profile {
+		profile[i] = method(i+1)(m, min, max)
+	}
+
+	return profile
+}
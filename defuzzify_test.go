@@ -0,0 +1,155 @@
+package fuzzy
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConvergenceProfile(t *testing.T) {
+	membership := Triangular(0, 20, 100)
+	expected := 40.0
+
+	profile := ConvergenceProfile(membership, 0, 100, Centroid, 200)
+
+	if g, e := len(profile), 200; g != e {
+		t.Fatalf("len(profile): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := profile[0], Centroid(1)(membership, 0, 100); g != e {
+		t.Errorf("profile[0]: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := profile[199], Centroid(200)(membership, 0, 100); g != e {
+		t.Errorf("profile[199]: got '%v', expected '%v'", g, e)
+	}
+
+	if math.Abs(profile[199]-expected) >= math.Abs(profile[0]-expected) {
+		t.Errorf("profile: expected the centroid to converge toward '%v', got '%v' with 1 step and '%v' with 200 steps", expected, profile[0], profile[199])
+	}
+
+	if g, e := math.Abs(profile[199]-expected), 0.1; g > e {
+		t.Errorf("profile[199] error: got '%v', expected at most '%v'", g, e)
+	}
+
+	if g, e := len(ConvergenceProfile(membership, 0, 100, MeanOfMaximum, 0)), 0; g != e {
+		t.Errorf("len(ConvergenceProfile(..., 0)): got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestDefuzzifierName(t *testing.T) {
+	testCases := []struct {
+		defuzzify Defuzzifier
+		expected  string
+	}{
+		{defuzzify: nil, expected: "centroid"},
+		{defuzzify: DefuzzifyFunc(nil), expected: "centroid"},
+		{defuzzify: Centroid(100), expected: "custom"},
+		{defuzzify: NamedCentroid(100), expected: "centroid"},
+		{defuzzify: NamedMeanOfMaximum(100), expected: "mean-max"},
+		{defuzzify: NamedDefuzzify("mean-max", MeanOfMaximum(100)), expected: "mean-max"},
+		{defuzzify: NamedDefuzzify("min", func(m Membership, min, max float64) float64 { return min }), expected: "min"},
+	}
+
+	for _, tc := range testCases {
+		if g, e := NewEngineWith(tc.defuzzify).DefuzzifierName(), tc.expected; g != e {
+			t.Errorf("NewEngineWith(%T).DefuzzifierName(): got '%v', expected '%v'", tc.defuzzify, g, e)
+		}
+	}
+
+	// Bare functions are still accepted by NewEngine() and WithDefuzzify()
+	engine := NewEngine(func(m Membership, min, max float64) float64 { return min })
+	if g, e := engine.DefuzzifierName(), "custom"; g != e {
+		t.Errorf("NewEngine(func).DefuzzifierName(): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := NewEngine(nil).DefuzzifierName(), "centroid"; g != e {
+		t.Errorf("NewEngine(nil).DefuzzifierName(): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := engine.WithDefuzzifier(NamedDefuzzify("mean-max", MeanOfMaximum(100))).DefuzzifierName(), "mean-max"; g != e {
+		t.Errorf("engine.WithDefuzzifier().DefuzzifierName(): got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestAdaptiveCentroid(t *testing.T) {
+	defuzzify := AdaptiveCentroid(0.01)
+
+	small := defuzzify.Defuzzify(Triangular(0, 0.2, 1), 0, 1)
+	large := defuzzify.Defuzzify(Triangular(0, 200, 1000), 0, 1000)
+
+	if g, e := small, 0.4; math.Abs(g-e) > 1e-3 {
+		t.Errorf("small centroid: got '%v', expected '%v'", g, e)
+	}
+
+	// The large shape is sampled with more steps, up to the maximum, for the same accuracy
+	if g, e := large/1000, small; math.Abs(g-e) > 1e-3 {
+		t.Errorf("scaled centroid: got '%v', expected '%v'", g, e)
+	}
+
+	scaled := AdaptiveCentroid(10).Defuzzify(Triangular(0, 200, 1000), 0, 1000)
+	if g, e := scaled/1000, small; math.Abs(g-e) > 1e-9 {
+		t.Errorf("scaled centroid with scaled resolution: got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestCentroidSymmetricShapes(t *testing.T) {
+	testCases := []struct {
+		name       string
+		membership Membership
+		min, max   float64
+		steps      int
+		expected   float64
+	}{
+		{name: "triangle", membership: Triangular(0, 50, 100), min: 0, max: 100, steps: 100, expected: 50},
+		{name: "narrow triangle", membership: Triangular(0.1, 0.2, 0.3), min: 0.1, max: 0.3, steps: 100, expected: 0.2},
+		{name: "offset triangle", membership: Triangular(-30, 20, 70), min: -30, max: 70, steps: 7, expected: 20},
+		// Both endpoints have a full membership, missing one of them biases the centroid
+		{name: "rectangle", membership: Rectangular(0, 100), min: 0, max: 100, steps: 100, expected: 50},
+		{name: "constant", membership: Constant(1), min: 0, max: 7, steps: 100, expected: 3.5},
+		{name: "unit constant", membership: Constant(1), min: 0, max: 1, steps: 100, expected: 0.5},
+	}
+
+	for _, tc := range testCases {
+		if g, e := Centroid(tc.steps).Defuzzify(tc.membership, tc.min, tc.max), tc.expected; math.Abs(g-e) > 1e-9 {
+			t.Errorf("centroid of %s with %d steps: got '%v', expected '%v'", tc.name, tc.steps, g, e)
+		}
+	}
+}
+
+func TestAreaAndCentroidOf(t *testing.T) {
+	testCases := []struct {
+		membership Membership
+		area       float64
+		centroid   float64
+	}{
+		{membership: Triangular(0, 10, 20), area: 10, centroid: 10},
+		{membership: Triangular(0, 0, 30), area: 15, centroid: 10},
+		{membership: Triangular(0, 30, 30), area: 15, centroid: 20},
+		{membership: Trapezoid(0, 10, 20, 30), area: 20, centroid: 15},
+		{membership: Trapezoid(0, 10, 40, 40), area: 35, centroid: (5*20.0/3 + 30*25) / 35},
+		{membership: Rectangular(10, 20), area: 10, centroid: 15},
+		{membership: Linear(0, 10), area: 5, centroid: 20.0 / 3},
+		{membership: Inverted(Linear(0, 10)), area: 5, centroid: 10.0 / 3},
+	}
+
+	for i, tc := range testCases {
+		if g, e := Area(tc.membership, 1000), tc.area; math.Abs(g-e) > 1e-3 {
+			t.Errorf("test case #%d: Area(): got '%v', expected '%v'", i, g, e)
+		}
+
+		if g, e := CentroidOf(tc.membership, 1000), tc.centroid; math.Abs(g-e) > 0.05 {
+			t.Errorf("test case #%d: CentroidOf(): got '%v', expected '%v'", i, g, e)
+		}
+	}
+
+	// Unbounded or empty domains
+	if g, e := Area(Constant(1), 1000), 0.0; g != e {
+		t.Errorf("Area(Constant()): got '%v', expected '%v'", g, e)
+	}
+
+	// The centroid matches the engine defuzzification over the same interval
+	m := Trapezoid(0, 10, 40, 40)
+	if g, e := CentroidOf(m, 100), Centroid(100).Defuzzify(m, 0, 40); g != e {
+		t.Errorf("CentroidOf(): got '%v', expected '%v'", g, e)
+	}
+}
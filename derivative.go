@@ -0,0 +1,67 @@
+package fuzzy
+
+import "math"
+
+// Differentiable is implemented by the memberships able to report their derivative,
+// i.e. to tune their parameters with gradient descent. Like Describable, it is an
+// optional interface checked with a type assertion.
+//
+// Piecewise linear memberships are not differentiable at their breakpoints: by
+// convention, the right-hand derivative is returned there, i.e. the slope of the
+// piece starting at x. Vertical edges (i.e. Step()) have a zero derivative.
+type Differentiable interface {
+	Derivative(x float64) float64
+}
+
+var (
+	_ Differentiable = &LinearMembership{}
+	_ Differentiable = &TriangularMembership{}
+	_ Differentiable = &TrapezoidalMembership{}
+	_ Differentiable = &InvertedMembership{}
+)
+
+// Derivative returns the slope of the ramp in [x1, x2), 0 elsewhere
+func (m *LinearMembership) Derivative(x float64) float64 {
+	if m.x1 <= x && x < m.x2 {
+		return 1 / (m.x2 - m.x1)
+	}
+
+	return 0
+}
+
+// Derivative returns the positive slope in [x1, x2), the negative one in [x2, x3), 0 elsewhere
+func (m *TriangularMembership) Derivative(x float64) float64 {
+	if m.x1 <= x && x < m.x2 {
+		return 1 / (m.x2 - m.x1)
+	}
+
+	if m.x2 <= x && x < m.x3 {
+		return -1 / (m.x3 - m.x2)
+	}
+
+	return 0
+}
+
+// Derivative returns the positive slope in [x1, x2), the negative one in [x3, x4), 0 elsewhere
+func (m *TrapezoidalMembership) Derivative(x float64) float64 {
+	if m.x1 <= x && x < m.x2 {
+		return 1 / (m.x2 - m.x1)
+	}
+
+	if m.x3 <= x && x < m.x4 {
+		return -1 / (m.x4 - m.x3)
+	}
+
+	return 0
+}
+
+// Derivative returns the opposite of the derivative of the inverted membership,
+// NaN if it does not implement Differentiable
+func (m *InvertedMembership) Derivative(x float64) float64 {
+	differentiable, ok := m.membership.(Differentiable)
+	if !ok {
+		return math.NaN()
+	}
+
+	return -differentiable.Derivative(x)
+}
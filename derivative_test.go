@@ -0,0 +1,75 @@
+package fuzzy
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDerivative(t *testing.T) {
+	testCases := []struct {
+		membership Differentiable
+		x          float64
+		expected   float64
+	}{
+		// Ramp regions
+		{membership: Linear(0, 10), x: 5, expected: 0.1},
+		{membership: Triangular(0, 10, 30), x: 5, expected: 0.1},
+		{membership: Triangular(0, 10, 30), x: 20, expected: -0.05},
+		{membership: Trapezoid(0, 20, 30, 35), x: 10, expected: 0.05},
+		{membership: Trapezoid(0, 20, 30, 35), x: 32, expected: -0.2},
+		{membership: Inverted(Linear(0, 10)), x: 5, expected: -0.1},
+		{membership: Inverted(Triangular(0, 10, 30)), x: 20, expected: 0.05},
+
+		// Saturated regions
+		{membership: Linear(0, 10), x: -5, expected: 0},
+		{membership: Linear(0, 10), x: 15, expected: 0},
+		{membership: Triangular(0, 10, 30), x: 40, expected: 0},
+		{membership: Trapezoid(0, 20, 30, 35), x: 25, expected: 0},
+		{membership: Trapezoid(0, 20, 30, 35), x: 40, expected: 0},
+
+		// Breakpoints report the right-hand derivative
+		{membership: Linear(0, 10), x: 0, expected: 0.1},
+		{membership: Linear(0, 10), x: 10, expected: 0},
+		{membership: Triangular(0, 10, 30), x: 10, expected: -0.05},
+		{membership: Triangular(0, 10, 30), x: 30, expected: 0},
+		{membership: Trapezoid(0, 20, 30, 35), x: 20, expected: 0},
+		{membership: Trapezoid(0, 20, 30, 35), x: 30, expected: -0.2},
+
+		// Vertical edges
+		{membership: Step(10), x: 10, expected: 0},
+		{membership: Trapezoid(0, 0, 10, 10), x: 0, expected: 0},
+	}
+
+	for i, tc := range testCases {
+		if g, e := tc.membership.Derivative(tc.x), tc.expected; math.Abs(g-e) > 1e-9 {
+			t.Errorf("test case #%d: Derivative(%v): got '%v', expected '%v'", i, tc.x, g, e)
+		}
+	}
+
+	if g := Inverted(Sigmoid(1, 0)).Derivative(0); !math.IsNaN(g) {
+		t.Errorf("Inverted(Sigmoid()).Derivative(0): got '%v', expected NaN", g)
+	}
+}
+
+func TestDerivativeMatchesSlope(t *testing.T) {
+	const h = 1e-6
+
+	memberships := []Membership{
+		Linear(0, 10),
+		Triangular(0, 10, 30),
+		Trapezoid(0, 20, 30, 35),
+		Inverted(Trapezoid(0, 20, 30, 35)),
+	}
+
+	for i, m := range memberships {
+		differentiable := m.(Differentiable)
+
+		for _, x := range []float64{2.5, 7.5, 12.5, 22.5, 27.5, 33.5} {
+			slope := (m.Value(x+h) - m.Value(x-h)) / (2 * h)
+
+			if g, e := differentiable.Derivative(x), slope; math.Abs(g-e) > 1e-6 {
+				t.Errorf("membership #%d: Derivative(%v): got '%v', expected '%v'", i, x, g, e)
+			}
+		}
+	}
+}
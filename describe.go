@@ -0,0 +1,73 @@
+package fuzzy
+
+// Describable is implemented by the memberships able to report their kind (see
+// KindLinear...), their numeric parameters and, for combinators such as INVERTED,
+// MIN and MAX, the memberships they combine. Serialization (Snapshot(), JSON,
+// dsl.Marshal()) and visualization rely on it through DescribeMembership().
+//
+// It is an optional interface, checked with a type assertion, rather than a method
+// of Membership: adding a method to Membership would break every third-party
+// implementation providing only Value() and Domain(). The tradeoff is that the
+// compiler cannot enforce it, memberships not implementing it are only reported
+// at runtime, as an error wrapping ErrUnsupportedMembership.
+type Describable interface {
+	Describe() (kind string, params []float64, children []Membership)
+}
+
+var (
+	_ Describable = &ConstantMembership{}
+	_ Describable = &LinearMembership{}
+	_ Describable = &TriangularMembership{}
+	_ Describable = &TrapezoidalMembership{}
+	_ Describable = &RectangularMembership{}
+	_ Describable = &SMembership{}
+	_ Describable = &ZMembership{}
+	_ Describable = &SigmoidMembership{}
+	_ Describable = &InvertedMembership{}
+	_ Describable = &MinMembership{}
+	_ Describable = &MaxMembership{}
+)
+
+func (m *ConstantMembership) Describe() (string, []float64, []Membership) {
+	return KindConstant, []float64{m.y}, nil
+}
+
+func (m *LinearMembership) Describe() (string, []float64, []Membership) {
+	return KindLinear, []float64{m.x1, m.x2}, nil
+}
+
+func (m *TriangularMembership) Describe() (string, []float64, []Membership) {
+	return KindTriangular, []float64{m.x1, m.x2, m.x3}, nil
+}
+
+func (m *TrapezoidalMembership) Describe() (string, []float64, []Membership) {
+	return KindTrapezoid, []float64{m.x1, m.x2, m.x3, m.x4}, nil
+}
+
+func (m *RectangularMembership) Describe() (string, []float64, []Membership) {
+	return KindRectangular, []float64{m.a, m.b}, nil
+}
+
+func (m *SMembership) Describe() (string, []float64, []Membership) {
+	return KindSCurve, []float64{m.a, m.b}, nil
+}
+
+func (m *ZMembership) Describe() (string, []float64, []Membership) {
+	return KindZCurve, []float64{m.a, m.b}, nil
+}
+
+func (m *SigmoidMembership) Describe() (string, []float64, []Membership) {
+	return KindSigmoid, []float64{m.a, m.c}, nil
+}
+
+func (m *InvertedMembership) Describe() (string, []float64, []Membership) {
+	return KindInverted, nil, []Membership{m.membership}
+}
+
+func (m *MinMembership) Describe() (string, []float64, []Membership) {
+	return KindMin, nil, m.memberships
+}
+
+func (m *MaxMembership) Describe() (string, []float64, []Membership) {
+	return KindMax, nil, m.memberships
+}
@@ -0,0 +1,73 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+type gaussianMembership struct {
+	mean, sigma float64
+}
+
+func (m *gaussianMembership) Value(x float64) float64 { return 0 }
+func (m *gaussianMembership) Domain() (float64, float64) {
+	return m.mean - 3*m.sigma, m.mean + 3*m.sigma
+}
+
+func (m *gaussianMembership) Describe() (string, []float64, []Membership) {
+	return "GAUSSIAN", []float64{m.mean, m.sigma}, nil
+}
+
+func TestDescribeMembership(t *testing.T) {
+	testCases := []struct {
+		membership Membership
+		expected   MembershipDefinition
+	}{
+		{
+			membership: Constant(0.5),
+			expected:   MembershipDefinition{Type: KindConstant, Params: []float64{0.5}},
+		},
+		{
+			membership: Sigmoid(0.2, 75),
+			expected:   MembershipDefinition{Type: KindSigmoid, Params: []float64{0.2, 75}},
+		},
+		{
+			membership: Max(Triangular(0, 5, 10), Inverted(Linear(0, 10))),
+			expected: MembershipDefinition{
+				Type: KindMax,
+				Children: []MembershipDefinition{
+					{Type: KindTriangular, Params: []float64{0, 5, 10}},
+					{Type: KindInverted, Children: []MembershipDefinition{{Type: KindLinear, Params: []float64{0, 10}}}},
+				},
+			},
+		},
+		{
+			// Third-party memberships can be described by implementing Describable
+			membership: Min(&gaussianMembership{mean: 5, sigma: 1}, Trapezoid(0, 2, 8, 10)),
+			expected: MembershipDefinition{
+				Type: KindMin,
+				Children: []MembershipDefinition{
+					{Type: "GAUSSIAN", Params: []float64{5, 1}},
+					{Type: KindTrapezoid, Params: []float64{0, 2, 8, 10}},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		def, err := DescribeMembership(tc.membership)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if g, e := def, tc.expected; !reflect.DeepEqual(g, e) {
+			t.Errorf("test case #%d: got '%+v', expected '%+v'", i, g, e)
+		}
+	}
+
+	if _, err := DescribeMembership(Inverted(&customMembership{})); !errors.Is(err, ErrUnsupportedMembership) {
+		t.Errorf("DescribeMembership(): got error '%v', expected '%v'", err, ErrUnsupportedMembership)
+	}
+}
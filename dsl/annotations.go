@@ -0,0 +1,47 @@
+package dsl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseAnnotations parses the metadata annotations (@key="value") preceding a rule or a variable definition
+func (p *Parser) parseAnnotations() (map[string]string, error) {
+	annotations := make(map[string]string)
+
+	for p.current < len(p.tokens) && p.tokens[p.current].Type == tokenANNOTATION {
+		annotationToken := p.tokens[p.current]
+		key := strings.TrimPrefix(annotationToken.Value, "@")
+		if key == "" {
+			return nil, newParseError("expected annotation name after @", annotationToken.Position, nil)
+		}
+		p.current++
+
+		// Expect =
+		if p.current >= len(p.tokens) || p.tokens[p.current].Type != tokenEQUAL {
+			return nil, newParseError(fmt.Sprintf("expected = after annotation @%s", key), annotationToken.Position, nil)
+		}
+		p.current++
+
+		// Expect value
+		if p.current >= len(p.tokens) || p.tokens[p.current].Type != tokenVAR {
+			return nil, newParseError(fmt.Sprintf("expected value for annotation @%s", key), p.tokens[p.current-1].Position, nil)
+		}
+
+		// Quotes are stripped by the tokenizer
+		value := p.tokens[p.current].Value
+		p.current++
+
+		if _, exists := annotations[key]; exists {
+			return nil, newParseError(fmt.Sprintf("duplicate annotation @%s", key), annotationToken.Position, nil)
+		}
+
+		annotations[key] = value
+	}
+
+	if len(annotations) > 0 && p.current >= len(p.tokens) {
+		return nil, newParseError("expected rule or variable definition after annotations", p.tokens[p.current-1].Position, nil)
+	}
+
+	return annotations, nil
+}
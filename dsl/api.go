@@ -1,19 +1,29 @@
 package dsl
 
 import (
+	"io"
+	"maps"
+	"strings"
+
 	"github.com/bornholm/go-fuzzy"
 	"github.com/pkg/errors"
 )
 
 type Options struct {
-	Memberships map[string]MembershipParser
+	Memberships   map[string]MembershipParser
+	CommentStyles []CommentStyle
+
+	// IncludeResolver returns the content of the files included with
+	// INCLUDE "path", nil to reject the inclusions
+	IncludeResolver IncludeResolver
 }
 
 type OptionFunc func(opts *Options)
 
 func NewOptions(funcs ...OptionFunc) *Options {
 	opts := &Options{
-		Memberships: DefaultMemberships,
+		Memberships:   maps.Clone(DefaultMemberships),
+		CommentStyles: append([]CommentStyle{}, DefaultCommentStyles...),
 	}
 	for _, fn := range funcs {
 		fn(opts)
@@ -27,9 +37,40 @@ func WithMembershipParser(funcType string, parser MembershipParser) OptionFunc {
 	}
 }
 
+// WithMembershipParsers replaces the membership parsers by a copy of the given ones,
+// so that the following options do not modify the caller's map
 func WithMembershipParsers(parsers map[string]MembershipParser) OptionFunc {
 	return func(opts *Options) {
-		opts.Memberships = parsers
+		opts.Memberships = maps.Clone(parsers)
+	}
+}
+
+// WithRawLinear disables the rewrite of descending LINEAR(x1, x2) membership
+// functions into INVERTED(LINEAR(x2, x1)) when raw is true: they are reported
+// as parsing errors instead (see ParseRawLinear)
+func WithRawLinear(raw bool) OptionFunc {
+	return func(opts *Options) {
+		if raw {
+			opts.Memberships[tokenLINEAR] = ParseMembershipFunc(ParseRawLinear)
+		} else {
+			opts.Memberships[tokenLINEAR] = ParseMembershipFunc(ParseLinear)
+		}
+	}
+}
+
+// WithCommentStyles recognizes the given comment styles in addition to the current ones,
+// i.e. WithCommentStyles(HashComment) to allow shell-style "# comment" comments
+func WithCommentStyles(styles ...CommentStyle) OptionFunc {
+	return func(opts *Options) {
+		opts.CommentStyles = append(opts.CommentStyles, styles...)
+	}
+}
+
+// WithIncludeResolver allows the INCLUDE "path" directive, the content of the
+// included files being returned by the given resolver, i.e. read from the filesystem
+func WithIncludeResolver(resolver IncludeResolver) OptionFunc {
+	return func(opts *Options) {
+		opts.IncludeResolver = resolver
 	}
 }
 
@@ -44,12 +85,29 @@ func ParseRules(dsl string, funcs ...OptionFunc) ([]*fuzzy.Rule, error) {
 
 // ParseRulesAndVariables parses DSL text into both rules and variables
 func ParseRulesAndVariables(dsl string, funcs ...OptionFunc) (*ParseResult, error) {
+	return ParseReader(strings.NewReader(dsl), funcs...)
+}
+
+// ParseReader parses the DSL text read from r into both rules and variables.
+// The text is tokenized as it is read, one line at a time, so that large
+// definition files do not have to be loaded in memory first.
+func ParseReader(r io.Reader, funcs ...OptionFunc) (*ParseResult, error) {
 	opts := NewOptions(funcs...)
-	tokens, err := tokenize(dsl)
+	tokens, err := tokenizeReader(r, opts.CommentStyles)
 	if err != nil {
 		return nil, errors.Wrap(err, "tokenization error")
 	}
 
+	tokens, err = expandIncludes(tokens, opts, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "include error")
+	}
+
+	tokens, err = expandConstants(tokens)
+	if err != nil {
+		return nil, errors.Wrap(err, "constant error")
+	}
+
 	parser := &Parser{
 		tokens:      tokens,
 		current:     0,
@@ -0,0 +1,59 @@
+package dsl
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/bornholm/go-fuzzy"
+	"github.com/pkg/errors"
+)
+
+// InputsMarker separates the DSL definition from the JSON inputs in a combined file
+const InputsMarker = "---INPUTS---"
+
+// LoadCombined reads a combined file holding both a DSL definition and a JSON inputs block,
+// separated by a line containing only the InputsMarker, i.e.:
+//
+//	DEFINE temperature ( TERM hot LINEAR (20, 30) );
+//	DEFINE ac_mode ( TERM cooling LINEAR (0, -100) );
+//	IF temperature IS hot THEN ac_mode IS cooling;
+//	---INPUTS---
+//	{ "temperature": 25 }
+//
+// If the marker is missing, the whole content is parsed as a definition and the returned inputs are empty.
+func LoadCombined(r io.Reader, funcs ...OptionFunc) (*ParseResult, fuzzy.Values, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	definition, rawInputs := splitCombined(string(content))
+
+	result, err := ParseRulesAndVariables(definition, funcs...)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	inputs := fuzzy.Values{}
+
+	if strings.TrimSpace(rawInputs) != "" {
+		if err := json.Unmarshal([]byte(rawInputs), &inputs); err != nil {
+			return nil, nil, errors.Wrap(err, "could not parse inputs")
+		}
+	}
+
+	return result, inputs, nil
+}
+
+func splitCombined(content string) (string, string) {
+	lines := strings.SplitAfter(content, "\n")
+
+	for i, line := range lines {
+		if strings.TrimSpace(line) == InputsMarker {
+			return strings.Join(lines[:i], ""), strings.Join(lines[i+1:], "")
+		}
+	}
+
+	return content, ""
+}
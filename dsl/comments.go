@@ -2,76 +2,137 @@ package dsl
 
 import "strings"
 
-// removeComments removes all comments from the input text while precisely preserving code structure
-func removeComments(input string) string {
-	var result strings.Builder
-	inMultilineComment := false
-	i := 0
+// CommentStyle describes the delimiters of a comment
+type CommentStyle struct {
+	// Start is the opening delimiter of the comment, i.e. "//" or "/*"
+	Start string
+	// End is the closing delimiter of a block comment, i.e. "*/".
+	// An empty End describes a single-line comment, running until the end of the line.
+	End string
+}
 
-	for i < len(input) {
-		// If we're in a multi-line comment, look for the end
-		if inMultilineComment {
-			if i+1 < len(input) && input[i] == '*' && input[i+1] == '/' {
-				inMultilineComment = false
-				i += 2 // Skip the */
+// LineComment returns the style of a single-line comment starting with the given delimiter
+func LineComment(start string) CommentStyle {
+	return CommentStyle{Start: start}
+}
 
-				// Always add a space to ensure tokens don't merge
-				result.WriteByte(' ')
-				continue
-			}
+// BlockComment returns the style of a (possibly multi-line) comment enclosed by the given delimiters
+func BlockComment(start, end string) CommentStyle {
+	return CommentStyle{Start: start, End: end}
+}
 
-			// Preserve all newlines in multi-line comments to maintain line numbers
-			if input[i] == '\n' {
-				result.WriteByte('\n')
-			} else {
-				// Replace other comment characters with spaces to maintain token separation
-				result.WriteByte(' ')
-			}
+var (
+	// SlashComment is the C-style single-line comment, i.e. "// comment"
+	SlashComment = LineComment("//")
+	// HashComment is the shell-style single-line comment, i.e. "# comment"
+	HashComment = LineComment("#")
+	// StarComment is the C-style block comment, i.e. "/* comment */"
+	StarComment = BlockComment("/*", "*/")
+)
 
-			i++ // Move to next character
-			continue
+// DefaultCommentStyles are the comment styles recognized by default
+var DefaultCommentStyles = []CommentStyle{SlashComment, StarComment}
+
+// commentStripper removes the comments of the input, one line at a time, while precisely
+// preserving code structure: comments are replaced by spaces to keep the token columns.
+// It tracks the block comments spanning several lines.
+// Comment delimiters inside quoted identifiers are left untouched.
+type commentStripper struct {
+	styles []CommentStyle
+
+	// blockEnd is the closing delimiter of the block comment the
+	// current line starts in, empty outside of block comments
+	blockEnd string
+}
+
+// strip returns the given line, without its line terminator, with its comments blanked
+func (c *commentStripper) strip(line string) string {
+	var result strings.Builder
+	i := 0
+
+	// blank writes the given text as spaces
+	blank := func(text string) {
+		result.WriteString(strings.Repeat(" ", len(text)))
+	}
+
+	// Block comment started on a previous line
+	if c.blockEnd != "" {
+		end := strings.Index(line, c.blockEnd)
+		if end == -1 {
+			blank(line)
+			return result.String()
 		}
 
-		// Check for start of single-line comment
-		if i+1 < len(input) && input[i] == '/' && input[i+1] == '/' {
-			// Skip to the end of this line
-			endOfLine := strings.IndexByte(input[i:], '\n')
-			if endOfLine == -1 {
-				// No more newlines (end of file), we're done
-				// Add a newline to ensure proper parsing of the last line
-				result.WriteByte('\n')
-				break
-			}
+		end += len(c.blockEnd)
+		blank(line[:end])
+		i = end
+		c.blockEnd = ""
+	}
 
-			// Replace all characters in the comment with spaces
-			// This preserves column alignment and ensures proper token separation
-			for j := 0; j < endOfLine; j++ {
-				result.WriteByte(' ')
+	for i < len(line) {
+		// Quoted identifier, copied as is until its closing quote on the same line
+		if line[i] == '"' {
+			end := strings.IndexByte(line[i+1:], '"')
+			if end == -1 {
+				result.WriteString(line[i:])
+				break
 			}
 
-			// Move to the newline
-			i += endOfLine
+			end += i + 2
+			result.WriteString(line[i:end])
+			i = end
+			continue
+		}
 
-			// Don't skip the newline itself, preserve it
-			result.WriteByte('\n')
+		style, isComment := matchCommentStyle(line[i:], c.styles)
+		if !isComment {
+			// Not in a comment, add this character to the result
+			result.WriteByte(line[i])
 			i++
 			continue
 		}
 
-		// Check for start of multi-line comment
-		if i+1 < len(input) && input[i] == '/' && input[i+1] == '*' {
-			inMultilineComment = true
-			i += 2 // Skip the /*
+		// Single-line comment, runs until the end of the line
+		if style.End == "" {
+			blank(line[i:])
+			break
+		}
 
-			// Add a space to ensure tokens don't merge
-			result.WriteByte(' ')
-			continue
+		// Block comment, skip to its closing delimiter
+		end := strings.Index(line[i+len(style.Start):], style.End)
+		if end == -1 {
+			// Continues on the next lines
+			blank(line[i:])
+			c.blockEnd = style.End
+			break
 		}
 
-		// Not in a comment, add this character to the result
-		result.WriteByte(input[i])
-		i++
+		end += i + len(style.Start) + len(style.End)
+		blank(line[i:end])
+		i = end
 	}
 
 	return result.String()
-}
\ No newline at end of file
+}
+
+// matchCommentStyle returns the comment style starting the given text, if any.
+// The longest matching delimiter wins.
+func matchCommentStyle(text string, styles []CommentStyle) (CommentStyle, bool) {
+	var (
+		match CommentStyle
+		found bool
+	)
+
+	for _, s := range styles {
+		if s.Start == "" || !strings.HasPrefix(text, s.Start) {
+			continue
+		}
+
+		if !found || len(s.Start) > len(match.Start) {
+			match = s
+			found = true
+		}
+	}
+
+	return match, found
+}
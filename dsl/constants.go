@@ -0,0 +1,81 @@
+package dsl
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+// expandConstants removes the CONST name = value; declarations from the tokens and
+// marks the following tokens naming a declared constant with its value, which is used
+// wherever a number is expected (see parseFloat()). The value of a constant can be
+// a number or a previously declared constant.
+func expandConstants(tokens []Token) ([]Token, error) {
+	constants := make(map[string]float64)
+	expanded := make([]Token, 0, len(tokens))
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+
+		if token.Type != tokenCONST {
+			if value, exists := constants[token.Value]; exists && token.Type == tokenVAR && !token.quoted {
+				token.constant = &value
+			}
+
+			expanded = append(expanded, token)
+			continue
+		}
+
+		if err := identifierError(tokens, i+1, "constant"); err != nil {
+			return nil, err
+		}
+
+		if i+1 >= len(tokens) || !isConstantName(tokens[i+1]) {
+			return nil, newParseError("expected constant name after CONST", token.Position, nil)
+		}
+
+		nameToken := tokens[i+1]
+		if _, exists := constants[nameToken.Value]; exists {
+			return nil, newParseError(fmt.Sprintf("constant '%s' is already defined", nameToken.Value), nameToken.Position, nil)
+		}
+
+		if i+2 >= len(tokens) || tokens[i+2].Type != tokenEQUAL {
+			return nil, newParseError(fmt.Sprintf("expected = after constant '%s'", nameToken.Value), nameToken.Position, nil)
+		}
+
+		if i+3 >= len(tokens) || tokens[i+3].Type != tokenVAR {
+			return nil, newParseError(fmt.Sprintf("expected value for constant '%s'", nameToken.Value), tokens[i+2].Position, nil)
+		}
+
+		valueToken := tokens[i+3]
+		if value, exists := constants[valueToken.Value]; exists && !valueToken.quoted {
+			valueToken.constant = &value
+		}
+
+		value, err := parseFloat(valueToken)
+		if err != nil {
+			return nil, err
+		}
+
+		if i+4 >= len(tokens) || tokens[i+4].Type != tokenSEMI {
+			return nil, newParseError(fmt.Sprintf("expected ; after constant '%s'", nameToken.Value), valueToken.Position, nil)
+		}
+
+		constants[nameToken.Value] = value
+		i += 4
+	}
+
+	return expanded, nil
+}
+
+// isConstantName returns true if the token is an unquoted identifier which
+// can name a constant, i.e. starting with a letter or an underscore
+func isConstantName(token Token) bool {
+	if token.Type != tokenVAR || token.quoted || token.Value == "" {
+		return false
+	}
+
+	first, _ := utf8.DecodeRuneInString(token.Value)
+
+	return unicode.IsLetter(first) || first == '_'
+}
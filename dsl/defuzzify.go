@@ -0,0 +1,99 @@
+package dsl
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/bornholm/go-fuzzy"
+)
+
+// defaultDefuzzifySteps is the number of steps of the DEFUZZIFY methods
+// when not given, as for the default engine centroid
+const defaultDefuzzifySteps = 1000
+
+// defuzzifyMethods associates the DEFUZZIFY methods, in lower case, to their defuzzification functions
+var defuzzifyMethods = map[string]func(steps int) fuzzy.Defuzzifier{
+	fuzzy.DefuzzifierCentroid:      func(steps int) fuzzy.Defuzzifier { return fuzzy.NamedCentroid(steps) },
+	fuzzy.DefuzzifierMeanOfMaximum: func(steps int) fuzzy.Defuzzifier { return fuzzy.NamedMeanOfMaximum(steps) },
+}
+
+// ApplyDefuzzifiers sets the defuzzification functions declared with DEFUZZIFY
+// on the engine, see Engine.SetDefuzzify()
+func (r *ParseResult) ApplyDefuzzifiers(engine *fuzzy.Engine) *fuzzy.Engine {
+	for variable, defuzzify := range r.Defuzzifiers {
+		engine.SetDefuzzify(variable, defuzzify)
+	}
+
+	return engine
+}
+
+// parseDefuzzify parses a DEFUZZIFY variable USING method [(steps)]; statement
+// and returns the variable and its defuzzification function
+func (p *Parser) parseDefuzzify() (string, fuzzy.Defuzzifier, error) {
+	defuzzifyToken := p.tokens[p.current]
+	p.current++ // Skip DEFUZZIFY
+
+	if err := identifierError(p.tokens, p.current, "variable"); err != nil {
+		return "", nil, err
+	}
+
+	if p.current >= len(p.tokens) || p.tokens[p.current].Type != tokenVAR {
+		return "", nil, newParseError("expected variable name after DEFUZZIFY", defuzzifyToken.Position, nil)
+	}
+
+	variableToken := p.tokens[p.current]
+	p.current++
+
+	if p.current >= len(p.tokens) || p.tokens[p.current].Type != tokenUSING {
+		return "", nil, newParseError(fmt.Sprintf("expected USING after variable '%s'", variableToken.Value), variableToken.Position, nil)
+	}
+	usingToken := p.tokens[p.current]
+	p.current++
+
+	if p.current >= len(p.tokens) || p.tokens[p.current].Type != tokenVAR {
+		return "", nil, newParseError("expected defuzzification method after USING", usingToken.Position, nil)
+	}
+
+	methodToken := p.tokens[p.current]
+	p.current++
+
+	method, exists := defuzzifyMethods[strings.ToLower(methodToken.Value)]
+	if !exists {
+		return "", nil, newParseError(fmt.Sprintf("unknown defuzzification method: %s", methodToken.Value), methodToken.Position, nil)
+	}
+
+	steps := defaultDefuzzifySteps
+
+	if p.current < len(p.tokens) && p.tokens[p.current].Type == tokenLPAREN {
+		p.current++ // Skip (
+
+		if p.current >= len(p.tokens) {
+			return "", nil, newParseError("expected number of steps", methodToken.Position, nil)
+		}
+
+		stepsToken := p.tokens[p.current]
+		value, err := parseFloat(stepsToken)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if value < 1 || value != math.Trunc(value) {
+			return "", nil, newParseError(fmt.Sprintf("number of steps must be a positive integer, got %v", value), stepsToken.Position, nil)
+		}
+		steps = int(value)
+		p.current++
+
+		if p.current >= len(p.tokens) || p.tokens[p.current].Type != tokenRPAREN {
+			return "", nil, newParseError("expected ) after number of steps", stepsToken.Position, nil)
+		}
+		p.current++ // Skip )
+	}
+
+	if p.current >= len(p.tokens) || p.tokens[p.current].Type != tokenSEMI {
+		return "", nil, newParseError("missing semicolon at end of DEFUZZIFY", p.tokens[p.current-1].Position, nil)
+	}
+	p.current++ // Skip semicolon
+
+	return variableToken.Value, method(steps), nil
+}
@@ -12,6 +12,8 @@ package dsl
 // - expressions.go: Parsing of logical expressions (IF/THEN/AND/OR/NOT)
 // - variables.go: Variable definition handling
 // - membership.go: Membership function parsing 
+// - annotations.go: Metadata annotations of rules and variables
+// - combined.go: Loading of combined definition and inputs files
 // - api.go: Public API methods
 
 // The package exposes several primary methods:
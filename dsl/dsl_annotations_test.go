@@ -0,0 +1,78 @@
+package dsl
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseAnnotations(t *testing.T) {
+	dsl := `
+	@unit="celsius"
+	DEFINE temperature (
+		TERM cold LINEAR (10, 0),
+		TERM hot LINEAR (20, 30)
+	);
+
+	@category="safety" @source=ISO-7730
+	IF temperature IS hot THEN ac_mode IS cooling;
+	IF temperature IS cold THEN ac_mode IS heating;
+	`
+
+	result, err := ParseRulesAndVariables(dsl)
+	if err != nil {
+		t.Fatalf("Failed to parse annotations: %v", err)
+	}
+
+	if g, e := result.Variables[0].Metadata()["unit"], "celsius"; g != e {
+		t.Errorf("variable metadata 'unit': got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := result.Rules[0].Metadata()["category"], "safety"; g != e {
+		t.Errorf("rule #0 metadata 'category': got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := result.Rules[0].Metadata()["source"], "ISO-7730"; g != e {
+		t.Errorf("rule #0 metadata 'source': got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := len(result.Rules[1].Metadata()), 0; g != e {
+		t.Errorf("len(rule #1 metadata): got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestParseInvalidAnnotations(t *testing.T) {
+	testCases := []struct {
+		name    string
+		dsl     string
+		message string
+	}{
+		{
+			name:    "Missing value",
+			dsl:     "@category IF temperature IS hot THEN ac_mode IS cooling;",
+			message: "expected = after annotation @category",
+		},
+		{
+			name:    "Duplicate annotation",
+			dsl:     `@category="a" @category="b" IF temperature IS hot THEN ac_mode IS cooling;`,
+			message: "duplicate annotation @category",
+		},
+		{
+			name:    "Dangling annotation",
+			dsl:     `IF temperature IS hot THEN ac_mode IS cooling; @category="a"`,
+			message: "expected rule or variable definition after annotations",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseRulesAndVariables(tc.dsl)
+			if err == nil {
+				t.Fatalf("ParseRulesAndVariables(): expected an error")
+			}
+
+			if !strings.Contains(err.Error(), tc.message) {
+				t.Errorf("ParseRulesAndVariables(): got error '%v', expected it to contain '%v'", err, tc.message)
+			}
+		})
+	}
+}
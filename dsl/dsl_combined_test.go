@@ -0,0 +1,66 @@
+package dsl
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadCombined(t *testing.T) {
+	combined := `
+	DEFINE temperature (
+		TERM cold LINEAR (10, 0),
+		TERM hot LINEAR (20, 30)
+	);
+
+	DEFINE ac_mode (
+		TERM heating LINEAR (0, 100),
+		TERM cooling LINEAR (0, -100)
+	);
+
+	IF temperature IS cold THEN ac_mode IS heating;
+	IF temperature IS hot THEN ac_mode IS cooling;
+	---INPUTS---
+	{
+		"temperature": 25
+	}
+	`
+
+	result, inputs, err := LoadCombined(strings.NewReader(combined))
+	if err != nil {
+		t.Fatalf("LoadCombined(): %v", err)
+	}
+
+	if g, e := len(result.Variables), 2; g != e {
+		t.Errorf("len(result.Variables): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := len(result.Rules), 2; g != e {
+		t.Errorf("len(result.Rules): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := inputs["temperature"], 25.0; g != e {
+		t.Errorf("inputs[\"temperature\"]: got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestLoadCombinedWithoutInputs(t *testing.T) {
+	result, inputs, err := LoadCombined(strings.NewReader("IF temperature IS cold THEN ac_mode IS heating;"))
+	if err != nil {
+		t.Fatalf("LoadCombined(): %v", err)
+	}
+
+	if g, e := len(result.Rules), 1; g != e {
+		t.Errorf("len(result.Rules): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := len(inputs), 0; g != e {
+		t.Errorf("len(inputs): got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestLoadCombinedInvalidInputs(t *testing.T) {
+	_, _, err := LoadCombined(strings.NewReader("IF temperature IS cold THEN ac_mode IS heating;\n---INPUTS---\n{ invalid"))
+	if err == nil {
+		t.Fatalf("LoadCombined(): expected an error")
+	}
+}
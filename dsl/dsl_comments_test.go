@@ -1,6 +1,7 @@
 package dsl
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/bornholm/go-fuzzy"
@@ -138,3 +139,62 @@ func TestCommentHandling(t *testing.T) {
 		}
 	})
 }
+
+func TestCommentStyles(t *testing.T) {
+	dsl := `
+	# Shell-style comment
+	DEFINE temperature ( # Comment after a definition
+		TERM cold LINEAR (10, 0), // C-style comment
+		TERM hot LINEAR (20, 30) /* Block comment */
+	);
+	IF temperature IS cold THEN ac_mode IS heating; # Comment at end of line
+	// Another comment
+	`
+
+	t.Run("WithHashComments", func(t *testing.T) {
+		result, err := ParseRulesAndVariables(dsl, WithCommentStyles(HashComment))
+		if err != nil {
+			t.Fatalf("Failed to parse with shell-style comments: %v", err)
+		}
+
+		if len(result.Rules) != 1 {
+			t.Fatalf("Expected 1 rule, got %d", len(result.Rules))
+		}
+
+		if len(result.Variables) != 1 {
+			t.Fatalf("Expected 1 variable, got %d", len(result.Variables))
+		}
+	})
+
+	t.Run("WithoutHashComments", func(t *testing.T) {
+		if _, err := ParseRulesAndVariables(dsl); err == nil {
+			t.Fatalf("Expected shell-style comments to be rejected by default")
+		}
+	})
+
+	t.Run("CustomBlockComments", func(t *testing.T) {
+		rules, err := ParseRules(`
+		(* Pascal-style
+		   comment *)
+		IF temperature IS cold THEN ac_mode IS heating;
+		`, WithCommentStyles(BlockComment("(*", "*)")))
+		if err != nil {
+			t.Fatalf("Failed to parse with custom block comments: %v", err)
+		}
+
+		if len(rules) != 1 {
+			t.Fatalf("Expected 1 rule, got %d", len(rules))
+		}
+	})
+
+	t.Run("PreservedPositions", func(t *testing.T) {
+		_, err := ParseRules("/* comment */ IF temperature IS THEN ac_mode IS heating;")
+		if err == nil {
+			t.Fatalf("Expected an error")
+		}
+
+		if e := "line 1, column 33"; !strings.Contains(err.Error(), e) {
+			t.Errorf("Got error '%v', expected it to contain '%v'", err, e)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package dsl
+
+import (
+	"testing"
+
+	"github.com/bornholm/go-fuzzy"
+	"github.com/pkg/errors"
+)
+
+func TestComparisons(t *testing.T) {
+	testCases := []struct {
+		condition string
+		expected  map[float64]float64
+	}{
+		{condition: "temperature < 30", expected: map[float64]float64{29: 1, 30: 0}},
+		{condition: "temperature <= 30", expected: map[float64]float64{30: 1, 31: 0}},
+		{condition: "temperature > 30", expected: map[float64]float64{31: 1, 30: 0}},
+		{condition: "temperature >= 30", expected: map[float64]float64{30: 1, 29: 0}},
+		{condition: "temperature == 30", expected: map[float64]float64{30: 1, 31: 0}},
+		{condition: "temperature>=limit", expected: map[float64]float64{30: 1, 29: 0}},
+	}
+
+	for _, tc := range testCases {
+		result, err := ParseRulesAndVariables(`
+			CONST limit = 30;
+
+			DEFINE temperature ( TERM hot LINEAR (20, 40) );
+			DEFINE alarm ( TERM on LINEAR (0, 1) );
+
+			IF ` + tc.condition + ` THEN alarm IS on;
+		`)
+		if err != nil {
+			t.Fatalf("%s: %+v", tc.condition, errors.WithStack(err))
+		}
+
+		engine := fuzzy.NewEngine(nil).
+			Variables(result.Variables...).
+			Rules(result.Rules...)
+
+		for temperature, expected := range tc.expected {
+			results, err := engine.Infer(fuzzy.Values{"temperature": temperature})
+			if err != nil {
+				t.Fatalf("%+v", errors.WithStack(err))
+			}
+
+			if g, e := results["alarm"]["on"].TruthDegree(), expected; g != e {
+				t.Errorf("%s with %v: got '%v', expected '%v'", tc.condition, temperature, g, e)
+			}
+		}
+	}
+}
+
+func TestComparisonWithFuzzyCondition(t *testing.T) {
+	result, err := ParseRulesAndVariables(`
+		DEFINE temperature ( TERM hot LINEAR (20, 40) );
+		DEFINE alarm ( TERM on LINEAR (0, 1) );
+
+		IF temperature IS hot AND NOT temperature > 35 THEN alarm IS on;
+	`)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := result.Rules[0].String(), "IF temperature IS hot AND NOT temperature > 35 THEN alarm IS on"; g != e {
+		t.Errorf("rule: got '%v', expected '%v'", g, e)
+	}
+
+	engine := fuzzy.NewEngine(nil).
+		Variables(result.Variables...).
+		Rules(result.Rules...)
+
+	for temperature, expected := range map[float64]float64{30: 0.5, 36: 0} {
+		results, err := engine.Infer(fuzzy.Values{"temperature": temperature})
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if g, e := results["alarm"]["on"].TruthDegree(), expected; g != e {
+			t.Errorf("alarm IS on with %v: got '%v', expected '%v'", temperature, g, e)
+		}
+	}
+}
+
+func TestComparisonMissingNumber(t *testing.T) {
+	_, err := ParseRulesAndVariables(`
+		DEFINE temperature ( TERM hot LINEAR (20, 40) );
+		DEFINE alarm ( TERM on LINEAR (0, 1) );
+
+		IF temperature > THEN alarm IS on;
+	`)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -0,0 +1,52 @@
+package dsl
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestParseMultipleConclusions(t *testing.T) {
+	rules, err := ParseRules(`
+IF temperature IS hot THEN ac_mode IS cooling AND fan IS high;
+IF temperature IS cold THEN ac_mode IS heating, fan IS low, fan_speed = 20 WEIGHT 0.5;`)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	expected := [][]string{
+		{"ac_mode IS cooling", "fan IS high"},
+		{"ac_mode IS heating", "fan IS low", "fan_speed IS 20"},
+	}
+
+	if g, e := len(rules), len(expected); g != e {
+		t.Fatalf("len(rules): got '%v', expected '%v'", g, e)
+	}
+
+	for i, e := range expected {
+		conclusions := rules[i].Conclusions()
+
+		if g := len(conclusions); g != len(e) {
+			t.Errorf("rule #%d len(conclusions): got '%v', expected '%v'", i, g, len(e))
+			continue
+		}
+
+		for j, c := range conclusions {
+			if g := c.Variable() + " IS " + c.Term(); g != e[j] {
+				t.Errorf("rule #%d conclusion #%d: got '%v', expected '%v'", i, j, g, e[j])
+			}
+		}
+	}
+
+	if value, crisp := rules[1].Conclusions()[2].CrispValue(); !crisp || value != 20 {
+		t.Errorf("rule #1 conclusion #2: got '%v' (crisp: %v), expected '%v'", value, crisp, 20)
+	}
+
+	if g, e := rules[1].Weight(), 0.5; g != e {
+		t.Errorf("rule #1 weight: got '%v', expected '%v'", g, e)
+	}
+
+	if _, err := ParseRules("IF temperature IS hot THEN ac_mode IS cooling AND;"); err == nil {
+		t.Errorf("ParseRules(): expected an error on a dangling AND")
+	}
+}
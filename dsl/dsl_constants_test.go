@@ -0,0 +1,144 @@
+package dsl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bornholm/go-fuzzy"
+	"github.com/pkg/errors"
+)
+
+func TestConstants(t *testing.T) {
+	result, err := ParseRulesAndVariables(`
+		CONST freezing = -10;
+		CONST comfort = 20;
+		CONST limit = comfort;
+		CONST full = 1_000;
+		CONST urgent = 2;
+
+		DEFINE temperature (
+			TERM mild LINEAR (freezing, 10),
+			TERM comfort TRIANGULAR (a = freezing, b = comfort, c = 40),
+			TERM hot WEIGHT urgent LINEAR (limit, 40)
+		);
+
+		DEFINE fan ( TERM fast LINEAR (0, full) );
+
+		IF temperature IS comfort THEN fan = full;
+	`)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	temperature := result.Variables[0]
+
+	testCases := []struct {
+		term     string
+		x        float64
+		expected float64
+	}{
+		{term: "mild", x: 10, expected: 1},
+		{term: "mild", x: 0, expected: 0.5},
+		{term: "comfort", x: 20, expected: 1},
+		{term: "comfort", x: 5, expected: 0.5},
+		{term: "hot", x: 30, expected: 0.5},
+	}
+
+	for _, tc := range testCases {
+		term, err := temperature.Term(tc.term)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if g, e := term.Membership().Value(tc.x), tc.expected; g != e {
+			t.Errorf("%s.Value(%v): got '%v', expected '%v'", tc.term, tc.x, g, e)
+		}
+	}
+
+	hot, err := temperature.Term("hot")
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := hot.Weight(), 2.0; g != e {
+		t.Errorf("hot.Weight(): got '%v', expected '%v'", g, e)
+	}
+
+	// Constants named like a term are still usable as term names
+	if g, e := result.Rules[0].String(), "IF temperature IS comfort THEN fan = 1000"; g != e {
+		t.Errorf("result.Rules[0]: got '%v', expected '%v'", g, e)
+	}
+
+	engine := fuzzy.NewEngine(nil).Variables(result.Variables...).Rules(result.Rules...)
+	if err := engine.Validate(); err != nil {
+		t.Errorf("%+v", errors.WithStack(err))
+	}
+}
+
+func TestConstantErrors(t *testing.T) {
+	testCases := []struct {
+		dsl      string
+		expected string
+		line     int
+		column   int
+	}{
+		{
+			dsl:      "DEFINE temperature (\n  TERM cold LINEAR (freezing, 10)\n);",
+			expected: "invalid number: freezing (undefined constant)",
+			line:     2, column: 21,
+		},
+		{
+			// Constants must be declared before being used
+			dsl:      "DEFINE x ( TERM a LINEAR (low, 10) );\nCONST low = 0;",
+			expected: "invalid number: low (undefined constant)",
+			line:     1, column: 27,
+		},
+		{
+			dsl:      "CONST low = 0;\nCONST low = 1;",
+			expected: "constant 'low' is already defined",
+			line:     2, column: 7,
+		},
+		{
+			dsl:      "CONST low = high;",
+			expected: "invalid number: high (undefined constant)",
+			line:     1, column: 13,
+		},
+		{
+			dsl:      "CONST if = 0;",
+			expected: "'if' is a reserved word and cannot be used as a constant name",
+			line:     1, column: 7,
+		},
+		{
+			dsl:      "CONST low 0;",
+			expected: "expected = after constant 'low'",
+			line:     1, column: 7,
+		},
+		{
+			dsl:      "CONST low = 0",
+			expected: "expected ; after constant 'low'",
+			line:     1, column: 13,
+		},
+	}
+
+	for i, tc := range testCases {
+		_, err := ParseRulesAndVariables(tc.dsl)
+		if err == nil {
+			t.Errorf("test case #%d: expected an error", i)
+			continue
+		}
+
+		if !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("test case #%d: got '%v', expected an error containing '%v'", i, err, tc.expected)
+		}
+
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("test case #%d: expected a parse error, got '%v'", i, err)
+			continue
+		}
+
+		if g, e := parseErr.Pos, (Position{Line: tc.line, Column: tc.column}); g != e {
+			t.Errorf("test case #%d: parseErr.Pos: got '%v', expected '%v'", i, g, e)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package dsl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bornholm/go-fuzzy"
+	"github.com/pkg/errors"
+)
+
+func TestDefuzzifyDeclarations(t *testing.T) {
+	result, err := ParseRulesAndVariables(`
+		CONST fine = 2_000;
+
+		DEFINE temperature ( TERM hot LINEAR (20, 30) );
+		DEFINE fan ( TERM fast TRAPEZOID (0, 50, 100, 100) );
+		DEFINE valve ( TERM open LINEAR (0, 100) );
+
+		DEFUZZIFY fan USING mean-max;
+		defuzzify valve using CENTROID (fine);
+
+		IF temperature IS hot THEN fan IS fast AND valve IS open;
+	`)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := len(result.Defuzzifiers), 2; g != e {
+		t.Fatalf("len(result.Defuzzifiers): got '%v', expected '%v'", g, e)
+	}
+
+	testCases := []struct {
+		variable string
+		name     string
+	}{
+		{variable: "fan", name: "mean-max"},
+		{variable: "valve", name: "centroid"},
+	}
+
+	for _, tc := range testCases {
+		named, ok := result.Defuzzifiers[tc.variable].(*fuzzy.NamedDefuzzifier)
+		if !ok {
+			t.Fatalf("%s: got '%T', expected a named defuzzifier", tc.variable, result.Defuzzifiers[tc.variable])
+		}
+
+		if g, e := named.Name(), tc.name; g != e {
+			t.Errorf("%s defuzzifier: got '%v', expected '%v'", tc.variable, g, e)
+		}
+	}
+
+	engine := fuzzy.NewEngine(fuzzy.Centroid(100)).
+		Variables(result.Variables...).
+		Rules(result.Rules...)
+
+	result.ApplyDefuzzifiers(engine)
+
+	results, err := engine.Infer(fuzzy.Values{"temperature": 30})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	// The mean of maximum of the plateau of fast, instead of its centroid
+	fan, err := engine.Defuzzify("fan", results)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := fan, 75.0; !almostEqual(g, e) {
+		t.Errorf("fan: got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestDefuzzifyDeclarationErrors(t *testing.T) {
+	testCases := []struct {
+		dsl     string
+		message string
+	}{
+		{dsl: "DEFUZZIFY fan USING median;", message: "unknown defuzzification method: median"},
+		{dsl: "DEFUZZIFY fan centroid;", message: "expected USING after variable 'fan'"},
+		{dsl: "DEFUZZIFY fan USING centroid (0);", message: "number of steps must be a positive integer, got 0"},
+		{dsl: "DEFUZZIFY fan USING centroid", message: "missing semicolon at end of DEFUZZIFY"},
+		{dsl: "DEFUZZIFY fan USING centroid; DEFUZZIFY fan USING mean-max;", message: "defuzzification of variable 'fan' is already declared"},
+	}
+
+	for _, tc := range testCases {
+		_, err := ParseRulesAndVariables(tc.dsl)
+		if err == nil {
+			t.Errorf("%s: expected an error", tc.dsl)
+			continue
+		}
+
+		if !strings.Contains(err.Error(), tc.message) {
+			t.Errorf("%s: got '%v', expected '%v'", tc.dsl, err, tc.message)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package dsl
+
+import (
+	"testing"
+
+	"github.com/bornholm/go-fuzzy"
+	"github.com/pkg/errors"
+)
+
+func TestParseElse(t *testing.T) {
+	result, err := ParseRulesAndVariables(`
+DEFINE temperature ( TERM hot LINEAR (20, 30) );
+DEFINE ac_mode ( TERM off TRIANGULAR (0, 10, 20), TERM cooling TRIANGULAR (80, 90, 100) );
+
+IF temperature IS hot THEN ac_mode IS cooling ELSE ac_mode IS off;
+IF temperature IS hot THEN fan_speed = 80 ELSE fan_speed = 0, ac_mode IS off WEIGHT 0.5;`)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	expected := []string{
+		"IF temperature IS hot THEN ac_mode IS cooling ELSE ac_mode IS off",
+		"IF temperature IS hot THEN fan_speed = 80 ELSE fan_speed = 0 AND ac_mode IS off WEIGHT 0.5",
+	}
+
+	for i, e := range expected {
+		if g := fuzzy.FormatRule(result.Rules[i]); g != e {
+			t.Errorf("rule #%d: got '%v', expected '%v'", i, g, e)
+		}
+	}
+
+	// All rules are silent, the ELSE conclusions take over
+	engine := fuzzy.NewEngine(nil).Variables(result.Variables...).Rules(result.Rules...)
+
+	results, err := engine.Infer(fuzzy.Values{"temperature": 0})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := results["ac_mode"]["off"].TruthDegree(), 1.0; g != e {
+		t.Errorf("ac_mode IS off: got '%v', expected '%v'", g, e)
+	}
+
+	if _, err := ParseRules("IF temperature IS hot THEN ac_mode IS cooling ELSE;"); err == nil {
+		t.Errorf("ParseRules(): expected an error on an empty ELSE")
+	}
+}
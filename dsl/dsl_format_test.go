@@ -0,0 +1,39 @@
+package dsl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestRuleStringRoundTrip(t *testing.T) {
+	rules, err := ParseRules(`
+IF temperature IS hot THEN ac_mode IS cooling;
+IF (temperature IS cold OR humidity IS high) AND NOT pressure IS low THEN ac_mode IS heating;
+IF temperature IS cold AND humidity IS low AND pressure IS high THEN ac_mode IS heating;
+IF NOT (temperature IS cold OR temperature IS hot) THEN ac_mode IS off, fan IS low;
+IF temperature IS VERY hot THEN fan_speed = 80 ELSE fan_speed = 20 WEIGHT 0.5;`)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	for i, rule := range rules {
+		text := rule.String()
+
+		reparsed, err := ParseRules(text + ";")
+		if err != nil {
+			t.Errorf("rule #%d: could not reparse '%s': %+v", i, text, errors.WithStack(err))
+			continue
+		}
+
+		if g, e := reparsed[0].String(), text; g != e {
+			t.Errorf("rule #%d: got '%v', expected '%v'", i, g, e)
+		}
+	}
+
+	// Parentheses are only emitted where the operators differ
+	if g := rules[2].String(); strings.Contains(g, "(") {
+		t.Errorf("rule #2: got '%v', expected no parentheses", g)
+	}
+}
@@ -0,0 +1,57 @@
+package dsl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bornholm/go-fuzzy"
+	"github.com/pkg/errors"
+)
+
+func TestParseHedges(t *testing.T) {
+	rules, err := ParseRules(`
+IF temperature IS VERY cold THEN heating IS high;
+IF temperature IS somewhat cold AND NOT humidity IS VERY high THEN heating IS medium;
+IF temperature IS VERY SOMEWHAT cold THEN heating IS low;`)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	expected := []string{
+		"IF temperature IS VERY cold THEN heating IS high",
+		"IF temperature IS SOMEWHAT cold AND NOT humidity IS VERY high THEN heating IS medium",
+		"IF temperature IS VERY SOMEWHAT cold THEN heating IS low",
+	}
+
+	for i, e := range expected {
+		if g := fuzzy.FormatRule(rules[i]); g != e {
+			t.Errorf("rule #%d: got '%v', expected '%v'", i, g, e)
+		}
+	}
+
+	testCases := []struct {
+		dsl      string
+		expected string
+	}{
+		{
+			dsl:      "IF temperature IS cold THEN heating IS VERY high;",
+			expected: "unexpected hedge VERY in conclusion",
+		},
+		{
+			dsl:      "IF temperature IS VERY THEN heating IS high;",
+			expected: "is a reserved word",
+		},
+	}
+
+	for _, tc := range testCases {
+		_, err := ParseRules(tc.dsl)
+		if err == nil {
+			t.Errorf("ParseRules(%q): expected an error", tc.dsl)
+			continue
+		}
+
+		if !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("err.Error(): got '%v', expected to contain '%v'", err.Error(), tc.expected)
+		}
+	}
+}
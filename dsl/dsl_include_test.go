@@ -0,0 +1,89 @@
+package dsl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func newMockResolver(files map[string]string) IncludeResolver {
+	return func(path string) (string, error) {
+		content, exists := files[path]
+		if !exists {
+			return "", errors.Errorf("file '%s' not found", path)
+		}
+
+		return content, nil
+	}
+}
+
+func TestInclude(t *testing.T) {
+	resolver := newMockResolver(map[string]string{
+		"variables.dsl": `
+			DEFINE temperature ( TERM cold LINEAR (15, 0), TERM hot LINEAR (25, 40) );
+			INCLUDE "outputs.dsl";
+		`,
+		"outputs.dsl": `DEFINE ac_mode ( TERM cooling LINEAR (0, -100), TERM heating LINEAR (0, 100) );`,
+	})
+
+	result, err := ParseRulesAndVariables(`
+		INCLUDE "variables.dsl"
+		IF temperature IS cold THEN ac_mode IS heating;
+		IF temperature IS hot THEN ac_mode IS cooling;
+	`, WithIncludeResolver(resolver))
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := len(result.Variables), 2; g != e {
+		t.Fatalf("len(result.Variables): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := result.Variables[1].Name(), "ac_mode"; g != e {
+		t.Errorf("result.Variables[1].Name(): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := len(result.Rules), 2; g != e {
+		t.Errorf("len(result.Rules): got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestIncludeErrors(t *testing.T) {
+	resolver := newMockResolver(map[string]string{
+		"a.dsl":       `INCLUDE "b.dsl";`,
+		"b.dsl":       `INCLUDE "a.dsl";`,
+		"self.dsl":    `INCLUDE "self.dsl";`,
+		"invalid.dsl": "DEFINE x ( TERM a LINEAR (0, 1) );\nIF x IS THEN;",
+	})
+
+	testCases := []struct {
+		dsl             string
+		withoutResolver bool
+		expected        string
+	}{
+		{dsl: `INCLUDE "a.dsl";`, expected: "include cycle a.dsl -> b.dsl -> a.dsl"},
+		{dsl: `INCLUDE "self.dsl";`, expected: "include cycle self.dsl -> self.dsl"},
+		{dsl: `INCLUDE "missing.dsl";`, expected: "could not include 'missing.dsl'"},
+		{dsl: `INCLUDE missing;`, expected: "expected a quoted path after INCLUDE"},
+		{dsl: `INCLUDE "a.dsl";`, withoutResolver: true, expected: "cannot include 'a.dsl' without include resolver"},
+		{dsl: `INCLUDE "invalid.dsl";`, expected: "invalid.dsl: line 2"},
+	}
+
+	for i, tc := range testCases {
+		funcs := []OptionFunc{WithIncludeResolver(resolver)}
+		if tc.withoutResolver {
+			funcs = nil
+		}
+
+		_, err := ParseRulesAndVariables(tc.dsl, funcs...)
+		if err == nil {
+			t.Errorf("test case #%d: expected an error", i)
+			continue
+		}
+
+		if !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("test case #%d: got '%v', expected an error containing '%v'", i, err, tc.expected)
+		}
+	}
+}
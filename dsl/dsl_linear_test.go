@@ -0,0 +1,66 @@
+package dsl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bornholm/go-fuzzy"
+	"github.com/pkg/errors"
+)
+
+func TestRawLinear(t *testing.T) {
+	variables, err := ParseVariables("DEFINE temperature ( TERM cold LINEAR (10, 0) );")
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	term, err := variables[0].Term("cold")
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if _, ok := term.Membership().(*fuzzy.InvertedMembership); !ok {
+		t.Errorf("term.Membership(): got '%T', expected '%T'", term.Membership(), &fuzzy.InvertedMembership{})
+	}
+
+	variables, err = ParseVariables("DEFINE temperature ( TERM hot LINEAR (0, 10) );", WithRawLinear(true))
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	term, err = variables[0].Term("hot")
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if _, ok := term.Membership().(*fuzzy.LinearMembership); !ok {
+		t.Errorf("term.Membership(): got '%T', expected '%T'", term.Membership(), &fuzzy.LinearMembership{})
+	}
+
+	_, err = ParseVariables("DEFINE temperature ( TERM cold LINEAR (10, 0) );", WithRawLinear(true))
+	if err == nil {
+		t.Fatalf("ParseVariables(): expected an error")
+	}
+
+	if e := "descending LINEAR(10, 0) is not allowed"; !strings.Contains(err.Error(), e) {
+		t.Errorf("err.Error(): got '%v', expected to contain '%v'", err.Error(), e)
+	}
+
+	// Options must not leak into the default parsers
+	if _, err := ParseVariables("DEFINE temperature ( TERM cold LINEAR (10, 0) );"); err != nil {
+		t.Errorf("%+v", errors.WithStack(err))
+	}
+
+	// ... nor into the parsers given by the caller
+	parsers := map[string]MembershipParser{
+		tokenLINEAR: ParseMembershipFunc(ParseLinear),
+	}
+
+	if _, err := ParseVariables("DEFINE temperature ( TERM hot LINEAR (0, 10) );", WithMembershipParsers(parsers), WithRawLinear(true)); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if _, err := ParseVariables("DEFINE temperature ( TERM cold LINEAR (10, 0) );", WithMembershipParsers(parsers)); err != nil {
+		t.Errorf("%+v", errors.WithStack(err))
+	}
+}
@@ -0,0 +1,160 @@
+package dsl
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bornholm/go-fuzzy"
+	"github.com/pkg/errors"
+)
+
+func TestMarshalRoundTrip(t *testing.T) {
+	result, err := ParseRulesAndVariables(`
+@unit="celsius"
+DEFINE temperature (
+	TERM cold LINEAR (10, 0),
+	TERM mild TRAPEZOID (a=5, b=10, c=20, d=25),
+	TERM hot WEIGHT 1.5 LINEAR (20, 30),
+	TERM extreme MAX (SCURVE (35, 45), INVERTED (ZCURVE (-20, -10)))
+);
+DEFINE fan (
+	TERM slow TRIANGULAR (0, 25, 50),
+	TERM fast SIGMOID (0.2, 75),
+	TERM boost MIN (RECTANGULAR (80, 100), INVERTED (TRIANGULAR (85, 90, 95)))
+);
+
+@id="r1"
+IF temperature IS cold OR temperature IS VERY mild THEN fan IS slow;
+IF temperature IS hot AND NOT temperature IS extreme THEN fan IS fast ELSE fan IS slow WEIGHT 0.5;
+IF temperature IS VERY SOMEWHAT extreme THEN fan IS boost, fan_speed = 100;`)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	text, err := Marshal(result.Variables, result.Rules)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	reparsed, err := ParseRulesAndVariables(text)
+	if err != nil {
+		t.Fatalf("could not reparse '%s': %+v", text, errors.WithStack(err))
+	}
+
+	if g, e := len(reparsed.Variables), len(result.Variables); g != e {
+		t.Fatalf("len(reparsed.Variables): got '%v', expected '%v'", g, e)
+	}
+
+	for i, v := range result.Variables {
+		r := reparsed.Variables[i]
+
+		if g, e := r.Name(), v.Name(); g != e {
+			t.Errorf("variable #%d name: got '%v', expected '%v'", i, g, e)
+		}
+
+		if g, e := r.Metadata(), v.Metadata(); !reflect.DeepEqual(g, e) {
+			t.Errorf("variable '%s' metadata: got '%v', expected '%v'", v.Name(), g, e)
+		}
+
+		if g, e := len(r.Terms()), len(v.Terms()); g != e {
+			t.Errorf("variable '%s' terms: got '%v', expected '%v'", v.Name(), g, e)
+		}
+
+		for _, term := range v.Terms() {
+			reparsedTerm, err := r.Term(term.Name())
+			if err != nil {
+				t.Errorf("variable '%s' term '%s': %+v", v.Name(), term.Name(), errors.WithStack(err))
+				continue
+			}
+
+			if g, e := reparsedTerm.Weight(), term.Weight(); g != e {
+				t.Errorf("term '%s' weight: got '%v', expected '%v'", term.Name(), g, e)
+			}
+
+			g, err := fuzzy.DescribeMembership(reparsedTerm.Membership())
+			if err != nil {
+				t.Fatalf("%+v", errors.WithStack(err))
+			}
+
+			e, err := fuzzy.DescribeMembership(term.Membership())
+			if err != nil {
+				t.Fatalf("%+v", errors.WithStack(err))
+			}
+
+			if !reflect.DeepEqual(g, e) {
+				t.Errorf("term '%s' membership: got '%v', expected '%v'", term.Name(), g, e)
+			}
+		}
+	}
+
+	if g, e := len(reparsed.Rules), len(result.Rules); g != e {
+		t.Fatalf("len(reparsed.Rules): got '%v', expected '%v'", g, e)
+	}
+
+	for i, rule := range result.Rules {
+		if g, e := reparsed.Rules[i].String(), rule.String(); g != e {
+			t.Errorf("rule #%d: got '%v', expected '%v'", i, g, e)
+		}
+
+		if g, e := reparsed.Rules[i].Metadata(), rule.Metadata(); !reflect.DeepEqual(g, e) {
+			t.Errorf("rule #%d metadata: got '%v', expected '%v'", i, g, e)
+		}
+	}
+}
+
+func TestMarshalDescendingLinear(t *testing.T) {
+	variables := []*fuzzy.Variable{
+		fuzzy.NewVariable("temperature", fuzzy.NewTerm("cold", fuzzy.Inverted(fuzzy.Linear(0, 10)))),
+	}
+
+	text, err := Marshal(variables, nil)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := text, "DEFINE temperature (\n\tTERM cold LINEAR (10, 0)\n);\n"; g != e {
+		t.Errorf("Marshal(): got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestMarshalUnsupported(t *testing.T) {
+	testCases := []struct {
+		variables []*fuzzy.Variable
+		rules     []*fuzzy.Rule
+		expected  error
+	}{
+		{
+			variables: []*fuzzy.Variable{
+				fuzzy.NewVariable("x", fuzzy.NewTerm("a", fuzzy.Constant(0.5))),
+			},
+			expected: fuzzy.ErrUnsupportedMembership,
+		},
+		{
+			rules: []*fuzzy.Rule{
+				fuzzy.If(fuzzy.Hedge(fuzzy.Is("x", "a"), 3)).Then("y", "b"),
+			},
+			expected: fuzzy.ErrUnsupportedExpr,
+		},
+		{
+			rules: []*fuzzy.Rule{
+				fuzzy.If(fuzzy.Very(fuzzy.Or(fuzzy.Is("x", "a"), fuzzy.Is("x", "b")))).Then("y", "b"),
+			},
+			expected: fuzzy.ErrUnsupportedExpr,
+		},
+	}
+
+	for i, tc := range testCases {
+		_, err := Marshal(tc.variables, tc.rules)
+		if !errors.Is(err, tc.expected) {
+			t.Errorf("test case #%d: got '%v', expected '%v'", i, err, tc.expected)
+		}
+	}
+
+	rules := []*fuzzy.Rule{
+		fuzzy.If(fuzzy.Is("x", "a")).Then("y", "b").WithMetadata("description", `say "hello"`),
+	}
+
+	if _, err := Marshal(nil, rules); err == nil {
+		t.Errorf("Marshal(): expected an error for an annotation value with quotes")
+	}
+}
@@ -0,0 +1,84 @@
+package dsl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestNumericLiterals(t *testing.T) {
+	testCases := []struct {
+		literal  string
+		expected float64
+	}{
+		{literal: "1e3", expected: 1000},
+		{literal: "1.5e-3", expected: 0.0015},
+		{literal: "10_000", expected: 10000},
+		{literal: "1_000.000_5", expected: 1000.0005},
+		{literal: "-2_5e1_0", expected: -25e10},
+	}
+
+	for _, tc := range testCases {
+		result, err := ParseRulesAndVariables(`
+DEFINE power (
+	TERM high LINEAR (0, ` + tc.literal + `)
+);
+IF power IS high THEN consumption = ` + tc.literal + `;`)
+		if err != nil {
+			t.Errorf("%s: %+v", tc.literal, errors.WithStack(err))
+			continue
+		}
+
+		value, crisp := result.Rules[0].Conclusion().CrispValue()
+		if !crisp {
+			t.Errorf("%s: expected a crisp conclusion", tc.literal)
+			continue
+		}
+
+		if g, e := value, tc.expected; g != e {
+			t.Errorf("%s: got '%v', expected '%v'", tc.literal, g, e)
+		}
+
+		term, err := result.Variables[0].Term("high")
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if g, e := term.Membership().Value(tc.expected), 1.0; g != e {
+			t.Errorf("%s: membership at %v: got '%v', expected '%v'", tc.literal, tc.expected, g, e)
+		}
+	}
+}
+
+func TestNumericLiteralsInvalid(t *testing.T) {
+	testCases := []struct {
+		source   string
+		expected string
+	}{
+		{source: `DEFINE power ( TERM high LINEAR (0, 1__000) );`, expected: "invalid number: 1__000"},
+		{source: `DEFINE power ( TERM high LINEAR (0, _1000) );`, expected: "invalid number: _1000"},
+		{source: `DEFINE power ( TERM high LINEAR (0, 1000_) );`, expected: "invalid number: 1000_"},
+		{source: `DEFINE power ( TERM high LINEAR (0, 1_.5) );`, expected: "invalid number: 1_.5"},
+		{source: `DEFINE 10_000 ( TERM high LINEAR (0, 1) );`, expected: "'10_000' is a number and cannot be used as a variable name"},
+		{source: `DEFINE power ( TERM 1_5 LINEAR (0, 1) );`, expected: "'1_5' is a number and cannot be used as a term name"},
+		{source: `IF 10_000 IS high THEN fan IS fast;`, expected: "'10_000' is a number and cannot be used as a variable name"},
+	}
+
+	for _, tc := range testCases {
+		_, err := ParseRulesAndVariables(tc.source)
+		if err == nil {
+			t.Errorf("%s: expected an error", tc.source)
+			continue
+		}
+
+		if !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("%s: got '%v', expected '%v'", tc.source, err, tc.expected)
+		}
+	}
+
+	// Quoted identifiers are never numbers
+	if _, err := ParseRulesAndVariables(`DEFINE "10_000" ( TERM high LINEAR (0, 1) );`); err != nil {
+		t.Errorf("%+v", errors.WithStack(err))
+	}
+}
@@ -0,0 +1,124 @@
+package dsl
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseNamedMembershipParameters(t *testing.T) {
+	variables, err := ParseVariables(`
+	DEFINE temperature (
+		TERM hot LINEAR (b=30, a=20),
+		TERM cold LINEAR (a=10, b=0),
+		TERM pleasant TRIANGULAR (c=25, a=10, b=20),
+		TERM comfortable TRAPEZOID (a=15, b=20, c=25, d=30)
+	);`)
+	if err != nil {
+		t.Fatalf("Failed to parse variable definition: %v", err)
+	}
+
+	temp := variables[0]
+
+	hot, _ := temp.Term("hot")
+	checkLinearMembership(t, hot.Membership(), 20, 30)
+
+	cold, _ := temp.Term("cold")
+	checkDescendingLinearMembership(t, cold.Membership(), 10, 0)
+
+	pleasant, _ := temp.Term("pleasant")
+	checkTriangularMembership(t, pleasant.Membership(), 10, 20, 25)
+
+	comfortable, _ := temp.Term("comfortable")
+	checkTrapezoidMembership(t, comfortable.Membership(), 15, 20, 25, 30)
+}
+
+func TestParseInvalidNamedMembershipParameters(t *testing.T) {
+	testCases := []struct {
+		name    string
+		dsl     string
+		message string
+		column  string
+	}{
+		{
+			name:    "Unknown parameter",
+			dsl:     "DEFINE temperature ( TERM hot TRIANGULAR (a=1, b=2, e=3) );",
+			message: "unknown parameter 'e' for TRIANGULAR",
+			column:  "column 53",
+		},
+		{
+			name:    "Duplicate parameter",
+			dsl:     "DEFINE temperature ( TERM hot LINEAR (a=1, a=2) );",
+			message: "duplicate parameter 'a' for LINEAR",
+			column:  "column 44",
+		},
+		{
+			name:    "Mixed positional and named parameters",
+			dsl:     "DEFINE temperature ( TERM hot LINEAR (a=1, 2) );",
+			message: "cannot mix positional and named parameters for LINEAR",
+			column:  "column 44",
+		},
+		{
+			name:    "Missing named parameter value",
+			dsl:     "DEFINE temperature ( TERM hot LINEAR (a=1, b=) );",
+			message: "expected value for parameter 'b' of LINEAR",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseVariables(tc.dsl)
+			if err == nil {
+				t.Fatalf("ParseVariables(): expected an error")
+			}
+
+			if !strings.Contains(err.Error(), tc.message) {
+				t.Errorf("ParseVariables(): got error '%v', expected it to contain '%v'", err, tc.message)
+			}
+
+			if !strings.Contains(err.Error(), tc.column) {
+				t.Errorf("ParseVariables(): got error '%v', expected it to contain '%v'", err, tc.column)
+			}
+		})
+	}
+}
+
+func TestParseDecreasingMembershipParameters(t *testing.T) {
+	testCases := []struct {
+		name    string
+		dsl     string
+		message string
+	}{
+		{
+			name:    "Descending trapezoid",
+			dsl:     "DEFINE temperature ( TERM hot TRAPEZOID (30, 25, 20, 15) );",
+			message: "TRAPEZOID parameters must be in non-decreasing order, got (30, 25, 20, 15) at line 1, column 31",
+		},
+		{
+			name:    "Unordered triangular",
+			dsl:     "DEFINE temperature ( TERM hot TRIANGULAR (10, 30, 20.5) );",
+			message: "TRIANGULAR parameters must be in non-decreasing order, got (10, 30, 20.5) at line 1, column 31",
+		},
+		{
+			name:    "Unordered named parameters",
+			dsl:     "DEFINE temperature ( TERM hot TRIANGULAR (a=20, b=10, c=30) );",
+			message: "TRIANGULAR parameters must be in non-decreasing order, got (20, 10, 30)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseVariables(tc.dsl)
+			if err == nil {
+				t.Fatalf("ParseVariables(): expected an error")
+			}
+
+			if !strings.Contains(err.Error(), tc.message) {
+				t.Errorf("err.Error(): got '%v', expected to contain '%v'", err.Error(), tc.message)
+			}
+		})
+	}
+
+	if _, err := ParseVariables("DEFINE temperature ( TERM hot TRAPEZOID (10, 20, 20, 30), TERM warm TRIANGULAR (10, 10, 20) );"); err != nil {
+		t.Errorf("ParseVariables(): got error '%v', expected none", err)
+	}
+}
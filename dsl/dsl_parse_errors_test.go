@@ -0,0 +1,65 @@
+package dsl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bornholm/go-fuzzy"
+	"github.com/pkg/errors"
+)
+
+func TestParseErrors(t *testing.T) {
+	_, err := ParseRulesAndVariables(`DEFINE temperature ( TERM hot LINEAR (20, 30) );
+DEFINE temperature ( TERM cold LINEAR (10, 0) );
+IF temperature IS hot THEN;
+IF temperature IS freezing THEN ac_mode IS cooling;`)
+	if err == nil {
+		t.Fatalf("ParseRulesAndVariables(): expected an error")
+	}
+
+	var parseErrors ParseErrors
+	if !errors.As(err, &parseErrors) {
+		t.Fatalf("ParseRulesAndVariables(): got '%T', expected to wrap '%T'", err, parseErrors)
+	}
+
+	expected := []struct {
+		line    int
+		column  int
+		message string
+	}{
+		{line: 2, column: 1, message: "variable 'temperature' is already defined"},
+		{line: 3, column: 27, message: "expected variable name"},
+		{line: 3, column: 27, message: "expected rule to start with IF"},
+		{line: 4, column: 19, message: "term 'freezing' is not defined by variable 'temperature'"},
+	}
+
+	if g, e := len(parseErrors), len(expected); g != e {
+		t.Fatalf("len(parseErrors): got '%v', expected '%v' (%v)", g, e, err)
+	}
+
+	for i, e := range expected {
+		parseErr := parseErrors[i]
+
+		if g := parseErr.Line(); g != e.line {
+			t.Errorf("parseErrors[%d].Line(): got '%v', expected '%v'", i, g, e.line)
+		}
+
+		if g := parseErr.Column(); g != e.column {
+			t.Errorf("parseErrors[%d].Column(): got '%v', expected '%v'", i, g, e.column)
+		}
+
+		if g := parseErr.Msg; !strings.Contains(g, e.message) {
+			t.Errorf("parseErrors[%d].Msg: got '%v', expected to contain '%v'", i, g, e.message)
+		}
+	}
+
+	// The human-readable message lists all the errors
+	if g, e := err.Error(), "parsing errors: "; !strings.Contains(g, e) {
+		t.Errorf("err.Error(): got '%v', expected to contain '%v'", g, e)
+	}
+
+	// The causes of the individual errors are preserved
+	if !errors.Is(err, fuzzy.ErrVariableAlreadyExists) {
+		t.Errorf("ParseRulesAndVariables(): expected to wrap '%v'", fuzzy.ErrVariableAlreadyExists)
+	}
+}
@@ -0,0 +1,144 @@
+package dsl
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bornholm/go-fuzzy"
+	"github.com/pkg/errors"
+)
+
+func TestOperatorPrecedence(t *testing.T) {
+	testCases := []struct {
+		premise  string
+		expected string
+	}{
+		{
+			premise:  "temperature IS cold OR humidity IS high AND pressure IS low",
+			expected: "temperature IS cold OR (humidity IS high AND pressure IS low)",
+		},
+		{
+			premise:  "temperature IS cold AND humidity IS high OR pressure IS low",
+			expected: "(temperature IS cold AND humidity IS high) OR pressure IS low",
+		},
+		{
+			premise:  "(temperature IS cold OR humidity IS high) AND pressure IS low",
+			expected: "(temperature IS cold OR humidity IS high) AND pressure IS low",
+		},
+		{
+			premise:  "NOT temperature IS cold AND humidity IS high",
+			expected: "NOT temperature IS cold AND humidity IS high",
+		},
+		{
+			premise:  "NOT NOT temperature IS cold OR humidity IS high",
+			expected: "NOT (NOT temperature IS cold) OR humidity IS high",
+		},
+		{
+			premise:  "temperature IS cold AND humidity IS high OR pressure IS low AND NOT temperature IS hot",
+			expected: "(temperature IS cold AND humidity IS high) OR (pressure IS low AND NOT temperature IS hot)",
+		},
+		{
+			premise:  "temperature IS cold OR humidity IS high XOR pressure IS low AND temperature IS hot",
+			expected: "temperature IS cold OR (humidity IS high XOR (pressure IS low AND temperature IS hot))",
+		},
+		{
+			premise:  "temperature IS cold AND humidity IS high AND pressure IS low OR temperature IS hot OR humidity IS low",
+			expected: "(temperature IS cold AND humidity IS high AND pressure IS low) OR temperature IS hot OR humidity IS low",
+		},
+		{
+			premise:  "temperature IS cold XOR (humidity IS high XOR pressure IS low)",
+			expected: "temperature IS cold XOR (humidity IS high XOR pressure IS low)",
+		},
+		{
+			premise:  "(temperature IS cold XOR humidity IS high) XOR pressure IS low",
+			expected: "temperature IS cold XOR humidity IS high XOR pressure IS low",
+		},
+		{
+			premise:  "temperature IS cold NAND humidity IS high OR pressure IS low",
+			expected: "NOT (temperature IS cold AND humidity IS high) OR pressure IS low",
+		},
+		{
+			premise:  "temperature IS cold NOR humidity IS high NOR pressure IS low",
+			expected: "NOT (NOT (temperature IS cold OR humidity IS high) OR pressure IS low)",
+		},
+	}
+
+	for _, tc := range testCases {
+		result, err := ParseRulesAndVariables("IF " + tc.premise + " THEN fan IS fast;")
+		if err != nil {
+			t.Errorf("%s: %+v", tc.premise, errors.WithStack(err))
+			continue
+		}
+
+		if g, e := fuzzy.FormatRule(result.Rules[0]), "IF "+tc.expected+" THEN fan IS fast"; g != e {
+			t.Errorf("%s: got '%v', expected '%v'", tc.premise, g, e)
+		}
+	}
+}
+
+func TestOperatorPrecedenceInference(t *testing.T) {
+	result, err := ParseRulesAndVariables(`
+DEFINE temperature ( TERM cold LINEAR (10, 0) );
+DEFINE humidity ( TERM high LINEAR (0, 100) );
+DEFINE pressure ( TERM low LINEAR (1000, 900) );
+
+IF temperature IS cold OR humidity IS high AND pressure IS low THEN alert = 1;`)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	engine := fuzzy.NewEngine(fuzzy.Centroid(100)).
+		Variables(result.Variables...).
+		Rules(result.Rules...)
+
+	// cold: 1, high: 0, low: 0
+	results, err := engine.Infer(fuzzy.Values{"temperature": 0, "humidity": 0, "pressure": 1000})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	// cold OR (high AND low) = max(1, min(0, 0)), whereas (cold OR high) AND low would be 0
+	if g, e := results["alert"]["1"].TruthDegree(), 1.0; g != e {
+		t.Errorf("alert: got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestXorGroupingWithProductNorms(t *testing.T) {
+	result, err := ParseRulesAndVariables(`
+DEFINE a ( TERM high LINEAR (0, 10) );
+DEFINE b ( TERM high LINEAR (0, 10) );
+DEFINE c ( TERM high LINEAR (0, 10) );
+
+IF a IS high XOR (b IS high XOR c IS high) THEN right = 1;
+IF (a IS high XOR b IS high) XOR c IS high THEN left = 1;
+IF a IS high XOR b IS high XOR c IS high THEN chained = 1;`)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	// The exclusive disjunction is not associative with the product norms
+	engine := fuzzy.NewEngine(fuzzy.Centroid(100)).
+		WithNorms(fuzzy.ProductProbabilisticSum).
+		Variables(result.Variables...).
+		Rules(result.Rules...)
+
+	results, err := engine.Infer(fuzzy.Values{"a": 3, "b": 6, "c": 8})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	testCases := []struct {
+		variable string
+		expected float64
+	}{
+		{variable: "right", expected: 0.410173},
+		{variable: "left", expected: 0.466257},
+		{variable: "chained", expected: 0.466257},
+	}
+
+	for _, tc := range testCases {
+		if g, e := results[tc.variable]["1"].TruthDegree(), tc.expected; math.Abs(g-e) > 1e-6 {
+			t.Errorf("%s: got '%v', expected '%v'", tc.variable, g, e)
+		}
+	}
+}
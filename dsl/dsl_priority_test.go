@@ -0,0 +1,66 @@
+package dsl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bornholm/go-fuzzy"
+	"github.com/pkg/errors"
+)
+
+func TestRulePriority(t *testing.T) {
+	result, err := ParseRulesAndVariables(`
+		DEFINE temperature ( TERM hot LINEAR (20, 40) );
+		DEFINE smoke ( TERM detected LINEAR (0, 10) );
+		DEFINE ac_mode (
+			TERM cooling TRIANGULAR (0, 25, 50),
+			TERM off TRIANGULAR (50, 75, 100)
+		);
+
+		IF temperature IS hot THEN ac_mode IS cooling;
+		IF smoke IS detected THEN ac_mode IS off WEIGHT 0.8 PRIORITY 5;
+		IF temperature IS hot THEN ac_mode IS cooling PRIORITY -1;
+	`)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	for i, expected := range []int{0, 5, -1} {
+		if g, e := result.Rules[i].Priority(), expected; g != e {
+			t.Errorf("rule #%d priority: got '%v', expected '%v'", i, g, e)
+		}
+	}
+
+	if g, e := result.Rules[1].Weight(), 0.8; g != e {
+		t.Errorf("rule #1 weight: got '%v', expected '%v'", g, e)
+	}
+
+	engine := fuzzy.NewEngine(nil).
+		Variables(result.Variables...).
+		Rules(result.Rules...).
+		WithConflictResolution(fuzzy.HighestPriorityWins)
+
+	results, err := engine.Infer(fuzzy.Values{"temperature": 30, "smoke": 5})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if _, exists := results["ac_mode"]["cooling"]; exists {
+		t.Errorf("ac_mode IS cooling: expected no result")
+	}
+
+	if g, e := results["ac_mode"]["off"].TruthDegree(), 0.4; !almostEqual(g, e) {
+		t.Errorf("ac_mode IS off: got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestInvalidRulePriority(t *testing.T) {
+	_, err := ParseRules(`IF smoke IS detected THEN ac_mode IS off PRIORITY 1.5;`)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if e := "invalid priority 1.5, expected an integer"; !strings.Contains(err.Error(), e) {
+		t.Errorf("err: got '%v', expected '%v'", err, e)
+	}
+}
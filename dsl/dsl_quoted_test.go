@@ -0,0 +1,103 @@
+package dsl
+
+import (
+	"testing"
+
+	"github.com/bornholm/go-fuzzy"
+	"github.com/pkg/errors"
+)
+
+func TestQuotedIdentifiers(t *testing.T) {
+	result, err := ParseRulesAndVariables(`
+// Quoted names may contain spaces, punctuation and comment delimiters
+DEFINE "outdoor temperature" (
+	TERM "very cold" LINEAR (10, 0),
+	TERM "hot // not a comment" LINEAR (20, 30)
+);
+DEFINE "fan speed" (
+	TERM "IF" TRIANGULAR (0, 25, 50),
+	TERM fast LINEAR (50, 100)
+);
+
+@description="cold /* outside */ (brr)"
+IF "outdoor temperature" IS "very cold" THEN "fan speed" IS "IF";
+IF "outdoor temperature" IS VERY "hot // not a comment" THEN "fan speed" IS fast;`)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := len(result.Variables), 2; g != e {
+		t.Fatalf("len(result.Variables): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := result.Variables[0].Name(), "outdoor temperature"; g != e {
+		t.Errorf("result.Variables[0].Name(): got '%v', expected '%v'", g, e)
+	}
+
+	if _, err := result.Variables[0].Term("hot // not a comment"); err != nil {
+		t.Errorf("%+v", errors.WithStack(err))
+	}
+
+	if _, err := result.Variables[1].Term("IF"); err != nil {
+		t.Errorf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := len(result.Rules), 2; g != e {
+		t.Fatalf("len(result.Rules): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := result.Rules[0].Metadata()["description"], "cold /* outside */ (brr)"; g != e {
+		t.Errorf("rule #0 description: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := result.Rules[0].String(), `IF "outdoor temperature" IS "very cold" THEN "fan speed" IS IF`; g != e {
+		t.Errorf("rule #0: got '%v', expected '%v'", g, e)
+	}
+
+	engine := fuzzy.NewEngine(fuzzy.Centroid(100)).
+		Variables(result.Variables...).
+		Rules(result.Rules...)
+
+	results, err := engine.Infer(fuzzy.Values{"outdoor temperature": 0})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := results["fan speed"]["IF"].TruthDegree(), 1.0; g != e {
+		t.Errorf("fan speed IS IF: got '%v', expected '%v'", g, e)
+	}
+
+	if _, err := Marshal(result.Variables, result.Rules); err == nil {
+		t.Errorf("Marshal(): expected an error for a rule referencing the 'IF' term")
+	}
+
+	text, err := Marshal(result.Variables, result.Rules[1:])
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	reparsed, err := ParseRulesAndVariables(text)
+	if err != nil {
+		t.Fatalf("could not reparse '%s': %+v", text, errors.WithStack(err))
+	}
+
+	if g, e := reparsed.Variables[1].Name(), "fan speed"; g != e {
+		t.Errorf("reparsed.Variables[1].Name(): got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestUnterminatedQuotedIdentifier(t *testing.T) {
+	_, err := ParseRulesAndVariables(`IF "outdoor temperature IS cold THEN fan IS fast;`)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected a ParseError, got '%v'", err)
+	}
+
+	if g, e := parseErr.Pos, (Position{Line: 1, Column: 4}); g != e {
+		t.Errorf("parseErr.Pos: got '%v', expected '%v'", g, e)
+	}
+}
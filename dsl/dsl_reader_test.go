@@ -0,0 +1,75 @@
+package dsl
+
+import (
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/pkg/errors"
+)
+
+func TestParseReader(t *testing.T) {
+	definition := `
+	DEFINE temperature (
+		TERM cold LINEAR (15, 0),
+		TERM hot LINEAR (25, 40)
+	);
+
+	/* Block comments can
+	   span several lines */
+	DEFINE ac_mode ( TERM cooling LINEAR (0, -100), TERM heating LINEAR (0, 100) );
+
+	IF temperature IS cold THEN ac_mode IS heating; // Comment at end of line
+	IF temperature IS hot THEN ac_mode IS cooling;`
+
+	result, err := ParseReader(strings.NewReader(definition))
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := len(result.Variables), 2; g != e {
+		t.Errorf("len(result.Variables): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := len(result.Rules), 2; g != e {
+		t.Fatalf("len(result.Rules): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := result.Rules[1].String(), "IF temperature IS hot THEN ac_mode IS cooling"; g != e {
+		t.Errorf("result.Rules[1]: got '%v', expected '%v'", g, e)
+	}
+
+	// Reading one byte at a time gives the same result
+	other, err := ParseReader(iotest.OneByteReader(strings.NewReader(definition)))
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	for i, rule := range result.Rules {
+		if g, e := other.Rules[i].String(), rule.String(); g != e {
+			t.Errorf("rule #%d: got '%v', expected '%v'", i, g, e)
+		}
+	}
+}
+
+func TestParseReaderErrors(t *testing.T) {
+	// Positions are kept across lines
+	_, err := ParseReader(strings.NewReader("DEFINE x ( TERM a LINEAR (0, 1) );\n/* comment\n*/ IF x IS a THEN;"))
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("err: got '%v', expected a parse error", err)
+	}
+
+	if g, e := parseErr.Pos.Line, 3; g != e {
+		t.Errorf("parseErr.Pos.Line: got '%v', expected '%v'", g, e)
+	}
+
+	// Read errors are reported
+	readErr := errors.New("read error")
+
+	_, err = ParseReader(iotest.ErrReader(readErr))
+	if !errors.Is(err, readErr) {
+		t.Errorf("err: got '%v', expected '%v'", err, readErr)
+	}
+}
@@ -0,0 +1,77 @@
+package dsl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bornholm/go-fuzzy"
+)
+
+func TestReservedWordAsIdentifier(t *testing.T) {
+	testCases := []struct {
+		name string
+		dsl  string
+		word string
+	}{
+		{
+			name: "Variable named after a keyword in a rule",
+			dsl:  "IF if IS cold THEN ac_mode IS heating;",
+			word: "if",
+		},
+		{
+			name: "Term named after a keyword in a rule",
+			dsl:  "IF temperature IS not THEN ac_mode IS heating;",
+			word: "not",
+		},
+		{
+			name: "Conclusion variable named after a keyword",
+			dsl:  "IF temperature IS cold THEN and IS heating;",
+			word: "and",
+		},
+		{
+			name: "Variable definition named after a keyword",
+			dsl:  "DEFINE term ( TERM cold LINEAR (0, 10) );",
+			word: "term",
+		},
+		{
+			name: "Term definition named after a membership function",
+			dsl:  "DEFINE temperature ( TERM Linear LINEAR (0, 10) );",
+			word: "Linear",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseRulesAndVariables(tc.dsl)
+			if err == nil {
+				t.Fatalf("ParseRulesAndVariables(): expected an error")
+			}
+
+			if e := "'" + tc.word + "' is a reserved word"; !strings.Contains(err.Error(), e) {
+				t.Errorf("ParseRulesAndVariables(): got error '%v', expected it to contain '%v'", err, e)
+			}
+		})
+	}
+}
+
+func TestIdentifiersAreCaseSensitive(t *testing.T) {
+	result, err := ParseRulesAndVariables(`
+		DEFINE Temperature ( TERM Cold LINEAR (10, 0) );
+		if Temperature is Cold then ac_mode is heating;
+	`)
+	if err != nil {
+		t.Fatalf("ParseRulesAndVariables(): %v", err)
+	}
+
+	if g, e := result.Variables[0].Name(), "Temperature"; g != e {
+		t.Errorf("variable name: got '%v', expected '%v'", g, e)
+	}
+
+	if _, err := result.Variables[0].Term("Cold"); err != nil {
+		t.Errorf("variable.Term(\"Cold\"): %v", err)
+	}
+
+	if g, e := result.Rules[0].Premise().(*fuzzy.IsExpr).Term(), "Cold"; g != e {
+		t.Errorf("premise term: got '%v', expected '%v'", g, e)
+	}
+}
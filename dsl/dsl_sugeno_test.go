@@ -0,0 +1,69 @@
+package dsl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestParseCrispConclusion(t *testing.T) {
+	rules, err := ParseRules(`
+IF temperature IS hot THEN fan_speed = 80;
+IF temperature IS cold THEN fan_speed = -12.5 WEIGHT 0.5;
+IF temperature IS warm THEN fan_speed IS medium;`)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	expected := []struct {
+		value float64
+		crisp bool
+		term  string
+	}{
+		{value: 80, crisp: true, term: "80"},
+		{value: -12.5, crisp: true, term: "-12.5"},
+		{crisp: false, term: "medium"},
+	}
+
+	for i, e := range expected {
+		value, crisp := rules[i].Value()
+		if crisp != e.crisp || value != e.value {
+			t.Errorf("rule #%d value: got '%v' (crisp: %v), expected '%v' (crisp: %v)", i, value, crisp, e.value, e.crisp)
+		}
+
+		if g := rules[i].Conclusion().Term(); g != e.term {
+			t.Errorf("rule #%d term: got '%v', expected '%v'", i, g, e.term)
+		}
+	}
+
+	if g, e := rules[1].Weight(), 0.5; g != e {
+		t.Errorf("rule #1 weight: got '%v', expected '%v'", g, e)
+	}
+
+	testCases := []struct {
+		dsl      string
+		expected string
+	}{
+		{
+			dsl:      "IF temperature IS hot THEN fan_speed = ;",
+			expected: "expected number after =",
+		},
+		{
+			dsl:      "IF temperature IS hot THEN fan_speed = fast;",
+			expected: "invalid number: fast",
+		},
+	}
+
+	for _, tc := range testCases {
+		_, err := ParseRules(tc.dsl)
+		if err == nil {
+			t.Errorf("ParseRules(%q): expected an error", tc.dsl)
+			continue
+		}
+
+		if !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("err.Error(): got '%v', expected to contain '%v'", err.Error(), tc.expected)
+		}
+	}
+}
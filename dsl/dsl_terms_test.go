@@ -0,0 +1,72 @@
+package dsl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestUndefinedTermReference(t *testing.T) {
+	source := `
+DEFINE temperature ( TERM cold LINEAR (10, 0), TERM hot LINEAR (20, 30) );
+DEFINE ac_mode ( TERM heating LINEAR (0, 100), TERM cooling LINEAR (0, -100) );
+
+IF temperature IS cold THEN ac_mode IS heating;
+IF temperature IS warm THEN ac_mode IS cool;
+IF humidity IS high THEN ac_mode IS cooling;
+`
+
+	_, err := ParseRulesAndVariables(source)
+	if err == nil {
+		t.Fatalf("ParseRulesAndVariables(): expected an error")
+	}
+
+	expected := []string{
+		"term 'warm' is not defined by variable 'temperature' at line 6, column 19",
+		"term 'cool' is not defined by variable 'ac_mode' at line 6, column 40",
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(err.Error(), e) {
+			t.Errorf("err.Error(): got '%v', expected to contain '%v'", err.Error(), e)
+		}
+	}
+
+	// Variables defined elsewhere are not checked
+	if strings.Contains(err.Error(), "humidity") {
+		t.Errorf("err.Error(): got '%v', expected no error about variable 'humidity'", err.Error())
+	}
+
+	if _, err := ParseRules("IF temperature IS warm THEN ac_mode IS cool;"); err != nil {
+		t.Errorf("%+v", errors.WithStack(err))
+	}
+}
+
+func TestParseDuplicateDefinitions(t *testing.T) {
+	testCases := []struct {
+		dsl      string
+		expected string
+	}{
+		{
+			dsl:      "DEFINE temperature ( TERM hot LINEAR (20, 30), TERM hot LINEAR (25, 35) );",
+			expected: "term 'hot' is already defined by variable 'temperature'",
+		},
+		{
+			dsl:      "DEFINE temperature ( TERM hot LINEAR (20, 30) ); DEFINE temperature ( TERM cold LINEAR (0, 10) );",
+			expected: "variable 'temperature' is already defined",
+		},
+	}
+
+	for _, tc := range testCases {
+		_, err := ParseVariables(tc.dsl)
+		if err == nil {
+			t.Errorf("ParseVariables(%q): expected an error", tc.dsl)
+			continue
+		}
+
+		if !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("err.Error(): got '%v', expected to contain '%v'", err.Error(), tc.expected)
+		}
+	}
+}
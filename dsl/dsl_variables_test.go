@@ -160,6 +160,226 @@ func TestParseInvertedMembershipFunction(t *testing.T) {
 	}
 }
 
+func TestParseSigmoidMembershipFunction(t *testing.T) {
+	dsl := `DEFINE pressure (
+		TERM high SIGMOID (2, 10),
+		TERM low SIGMOID (a=-2, c=10)
+	);`
+
+	variables, err := ParseVariables(dsl)
+	if err != nil {
+		t.Fatalf("Failed to parse variable definition: %v", err)
+	}
+
+	pressure := variables[0]
+
+	highTerm, err := pressure.Term("high")
+	if err != nil {
+		t.Fatalf("Term 'high' not found: %v", err)
+	}
+
+	lowTerm, err := pressure.Term("low")
+	if err != nil {
+		t.Fatalf("Term 'low' not found: %v", err)
+	}
+
+	if _, ok := highTerm.Membership().(*fuzzy.SigmoidMembership); !ok {
+		t.Errorf("Expected SigmoidMembership, got %T", highTerm.Membership())
+	}
+
+	// Both sigmoids cross at 10, the first one ascending and the second one descending
+	if !almostEqual(highTerm.Membership().Value(10), 0.5) || !almostEqual(lowTerm.Membership().Value(10), 0.5) {
+		t.Errorf("Expected value at 10 to be 0.5, got %f and %f", highTerm.Membership().Value(10), lowTerm.Membership().Value(10))
+	}
+
+	if highTerm.Membership().Value(15) <= 0.99 {
+		t.Errorf("Expected ascending value at 15 to be near 1.0, got %f", highTerm.Membership().Value(15))
+	}
+
+	if lowTerm.Membership().Value(15) >= 0.01 {
+		t.Errorf("Expected descending value at 15 to be near 0.0, got %f", lowTerm.Membership().Value(15))
+	}
+}
+
+func TestParseRectangularMembershipFunction(t *testing.T) {
+	dsl := `DEFINE hour (
+		TERM office RECTANGULAR (9, 17)
+	);`
+
+	variables, err := ParseVariables(dsl)
+	if err != nil {
+		t.Fatalf("Failed to parse variable definition: %v", err)
+	}
+
+	officeTerm, err := variables[0].Term("office")
+	if err != nil {
+		t.Fatalf("Term 'office' not found: %v", err)
+	}
+
+	membership := officeTerm.Membership()
+	if _, ok := membership.(*fuzzy.RectangularMembership); !ok {
+		t.Errorf("Expected RectangularMembership, got %T", membership)
+	}
+
+	// Both bounds are included
+	for x, expected := range map[float64]float64{8.5: 0, 9: 1, 12: 1, 17: 1, 17.5: 0} {
+		if !almostEqual(membership.Value(x), expected) {
+			t.Errorf("Expected value at %v to be %v, got %f", x, expected, membership.Value(x))
+		}
+	}
+}
+
+func TestParseBetweenMembershipFunction(t *testing.T) {
+	dsl := `DEFINE frequency (
+		TERM midband BETWEEN (300, 3_000),
+		TERM hum BETWEEN (b=60, a=50)
+	);`
+
+	variables, err := ParseVariables(dsl)
+	if err != nil {
+		t.Fatalf("Failed to parse variable definition: %v", err)
+	}
+
+	midbandTerm, err := variables[0].Term("midband")
+	if err != nil {
+		t.Fatalf("Term 'midband' not found: %v", err)
+	}
+
+	// BETWEEN is a crisp band, identical to RECTANGULAR
+	membership := midbandTerm.Membership()
+	if _, ok := membership.(*fuzzy.RectangularMembership); !ok {
+		t.Errorf("Expected RectangularMembership, got %T", membership)
+	}
+
+	for x, expected := range map[float64]float64{299: 0, 300: 1, 1000: 1, 3000: 1, 3001: 0} {
+		if !almostEqual(membership.Value(x), expected) {
+			t.Errorf("Expected value at %v to be %v, got %f", x, expected, membership.Value(x))
+		}
+	}
+
+	humTerm, err := variables[0].Term("hum")
+	if err != nil {
+		t.Fatalf("Term 'hum' not found: %v", err)
+	}
+
+	for x, expected := range map[float64]float64{49: 0, 50: 1, 60: 1, 61: 0} {
+		if !almostEqual(humTerm.Membership().Value(x), expected) {
+			t.Errorf("Expected value at %v to be %v, got %f", x, expected, humTerm.Membership().Value(x))
+		}
+	}
+
+	if _, err := ParseVariables(`DEFINE frequency ( TERM midband BETWEEN (3000, 300) );`); err == nil {
+		t.Error("Expected an error for decreasing BETWEEN bounds")
+	}
+}
+
+func TestParseSZCurveMembershipFunctions(t *testing.T) {
+	dsl := `DEFINE temperature (
+		TERM hot SCURVE (20, 30),
+		TERM cold ZCURVE (a=0, b=10)
+	);`
+
+	variables, err := ParseVariables(dsl)
+	if err != nil {
+		t.Fatalf("Failed to parse variable definition: %v", err)
+	}
+
+	hotTerm, err := variables[0].Term("hot")
+	if err != nil {
+		t.Fatalf("Term 'hot' not found: %v", err)
+	}
+
+	if _, ok := hotTerm.Membership().(*fuzzy.SMembership); !ok {
+		t.Errorf("Expected SMembership, got %T", hotTerm.Membership())
+	}
+
+	coldTerm, err := variables[0].Term("cold")
+	if err != nil {
+		t.Fatalf("Term 'cold' not found: %v", err)
+	}
+
+	if _, ok := coldTerm.Membership().(*fuzzy.ZMembership); !ok {
+		t.Errorf("Expected ZMembership, got %T", coldTerm.Membership())
+	}
+
+	for x, expected := range map[float64]float64{20: 0, 25: 0.5, 30: 1} {
+		if !almostEqual(hotTerm.Membership().Value(x), expected) {
+			t.Errorf("Expected S-curve value at %v to be %v, got %f", x, expected, hotTerm.Membership().Value(x))
+		}
+	}
+
+	for x, expected := range map[float64]float64{0: 1, 5: 0.5, 10: 0} {
+		if !almostEqual(coldTerm.Membership().Value(x), expected) {
+			t.Errorf("Expected Z-curve value at %v to be %v, got %f", x, expected, coldTerm.Membership().Value(x))
+		}
+	}
+}
+
+func TestParseMinMaxMembershipFunctions(t *testing.T) {
+	dsl := `DEFINE temperature (
+		TERM warmish MAX (TRIANGULAR (10, 20, 30), TRIANGULAR (25, 35, 45)),
+		TERM mild MIN (LINEAR (0, 20), INVERTED (LINEAR (20, 40))),
+		TERM nested MAX (MIN (LINEAR (0, 10), INVERTED (LINEAR (10, 20))), TRIANGULAR (30, 40, 50))
+	);`
+
+	variables, err := ParseVariables(dsl)
+	if err != nil {
+		t.Fatalf("Failed to parse variable definition: %v", err)
+	}
+
+	temp := variables[0]
+
+	warmishTerm, err := temp.Term("warmish")
+	if err != nil {
+		t.Fatalf("Term 'warmish' not found: %v", err)
+	}
+
+	if _, ok := warmishTerm.Membership().(*fuzzy.MaxMembership); !ok {
+		t.Errorf("Expected MaxMembership, got %T", warmishTerm.Membership())
+	}
+
+	for x, expected := range map[float64]float64{20: 1, 27.5: 0.25, 35: 1, 50: 0} {
+		if !almostEqual(warmishTerm.Membership().Value(x), expected) {
+			t.Errorf("Expected 'warmish' value at %v to be %v, got %f", x, expected, warmishTerm.Membership().Value(x))
+		}
+	}
+
+	mildTerm, err := temp.Term("mild")
+	if err != nil {
+		t.Fatalf("Term 'mild' not found: %v", err)
+	}
+
+	if _, ok := mildTerm.Membership().(*fuzzy.MinMembership); !ok {
+		t.Errorf("Expected MinMembership, got %T", mildTerm.Membership())
+	}
+
+	for x, expected := range map[float64]float64{10: 0.5, 20: 1, 30: 0.5} {
+		if !almostEqual(mildTerm.Membership().Value(x), expected) {
+			t.Errorf("Expected 'mild' value at %v to be %v, got %f", x, expected, mildTerm.Membership().Value(x))
+		}
+	}
+
+	nestedTerm, err := temp.Term("nested")
+	if err != nil {
+		t.Fatalf("Term 'nested' not found: %v", err)
+	}
+
+	for x, expected := range map[float64]float64{10: 1, 25: 0, 40: 1} {
+		if !almostEqual(nestedTerm.Membership().Value(x), expected) {
+			t.Errorf("Expected 'nested' value at %v to be %v, got %f", x, expected, nestedTerm.Membership().Value(x))
+		}
+	}
+
+	for _, invalid := range []string{
+		"DEFINE temperature ( TERM empty MAX () );",
+		"DEFINE temperature ( TERM unclosed MAX (LINEAR (0, 10) );",
+	} {
+		if _, err := ParseVariables(invalid); err == nil {
+			t.Errorf("ParseVariables(%q): expected an error", invalid)
+		}
+	}
+}
+
 func TestVariablesAndRulesCombined(t *testing.T) {
 	dsl := `
 	DEFINE temperature (
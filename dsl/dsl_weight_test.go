@@ -0,0 +1,109 @@
+package dsl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestTermWeight(t *testing.T) {
+	variables, err := ParseVariables(`
+DEFINE valve (
+	TERM normal TRIANGULAR (0, 25, 50),
+	TERM emergency WEIGHT 2.5 TRIANGULAR (50, 75, 100)
+);`)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	expected := map[string]float64{
+		"normal":    1,
+		"emergency": 2.5,
+	}
+
+	for name, e := range expected {
+		term, err := variables[0].Term(name)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if g := term.Weight(); g != e {
+			t.Errorf("term '%s' weight: got '%v', expected '%v'", name, g, e)
+		}
+	}
+
+	testCases := []struct {
+		dsl      string
+		expected string
+	}{
+		{
+			dsl:      "DEFINE valve ( TERM emergency WEIGHT heavy TRIANGULAR (50, 75, 100) );",
+			expected: "invalid number: heavy",
+		},
+		{
+			dsl:      "DEFINE valve ( TERM emergency WEIGHT -1 TRIANGULAR (50, 75, 100) );",
+			expected: "invalid weight -1",
+		},
+		{
+			dsl:      "DEFINE valve ( TERM weight TRIANGULAR (50, 75, 100) );",
+			expected: "'weight' is a reserved word",
+		},
+	}
+
+	for _, tc := range testCases {
+		_, err := ParseVariables(tc.dsl)
+		if err == nil {
+			t.Errorf("ParseVariables(%q): expected an error", tc.dsl)
+			continue
+		}
+
+		if !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("err.Error(): got '%v', expected to contain '%v'", err.Error(), tc.expected)
+		}
+	}
+}
+
+func TestRuleWeight(t *testing.T) {
+	rules, err := ParseRules(`
+IF load IS low THEN fan IS slow;
+IF load IS high THEN fan IS fast WEIGHT 0.7;
+IF load IS high THEN fan IS fast WEIGHT 0;`)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	expected := []float64{1, 0.7, 0}
+
+	for i, e := range expected {
+		if g := rules[i].Weight(); g != e {
+			t.Errorf("rule #%d weight: got '%v', expected '%v'", i, g, e)
+		}
+	}
+
+	testCases := []struct {
+		dsl      string
+		expected string
+	}{
+		{
+			dsl:      "IF load IS high THEN fan IS fast WEIGHT;",
+			expected: "invalid number: ;",
+		},
+		{
+			dsl:      "IF load IS high THEN fan IS fast WEIGHT -0.5;",
+			expected: "invalid weight -0.5",
+		},
+	}
+
+	for _, tc := range testCases {
+		_, err := ParseRules(tc.dsl)
+		if err == nil {
+			t.Errorf("ParseRules(%q): expected an error", tc.dsl)
+			continue
+		}
+
+		if !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("err.Error(): got '%v', expected to contain '%v'", err.Error(), tc.expected)
+		}
+	}
+}
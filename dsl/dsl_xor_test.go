@@ -0,0 +1,94 @@
+package dsl
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bornholm/go-fuzzy"
+	"github.com/pkg/errors"
+)
+
+func TestXorNandNor(t *testing.T) {
+	result, err := ParseRulesAndVariables(`
+DEFINE heater ( TERM on LINEAR (0, 10) );
+DEFINE cooler ( TERM on LINEAR (0, 10) );
+DEFINE fan ( TERM on LINEAR (0, 10) );
+
+IF heater IS on XOR cooler IS on THEN out_xor = 1;
+IF heater IS on xor cooler IS on XOR fan IS on THEN out_xor3 = 1;
+IF heater IS on NAND cooler IS on THEN out_nand = 1;
+IF heater IS on NOR cooler IS on THEN out_nor = 1;
+IF (heater IS on OR fan IS on) XOR cooler IS on THEN out_grouped = 1;`)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	expectedRules := []string{
+		"IF heater IS on XOR cooler IS on THEN out_xor = 1",
+		"IF heater IS on XOR cooler IS on XOR fan IS on THEN out_xor3 = 1",
+		"IF NOT (heater IS on AND cooler IS on) THEN out_nand = 1",
+		"IF NOT (heater IS on OR cooler IS on) THEN out_nor = 1",
+		"IF (heater IS on OR fan IS on) XOR cooler IS on THEN out_grouped = 1",
+	}
+
+	if g, e := len(result.Rules), len(expectedRules); g != e {
+		t.Fatalf("len(result.Rules): got '%v', expected '%v'", g, e)
+	}
+
+	for i, e := range expectedRules {
+		if g := result.Rules[i].String(); g != e {
+			t.Errorf("rule #%d: got '%v', expected '%v'", i, g, e)
+		}
+	}
+
+	engine := fuzzy.NewEngine(fuzzy.Centroid(100)).
+		Variables(result.Variables...).
+		Rules(result.Rules...)
+
+	testCases := []struct {
+		inputs   fuzzy.Values
+		expected map[string]float64
+	}{
+		{
+			inputs:   fuzzy.Values{"heater": 10, "cooler": 0, "fan": 0},
+			expected: map[string]float64{"out_xor": 1, "out_xor3": 1, "out_nand": 1, "out_nor": 0, "out_grouped": 1},
+		},
+		{
+			inputs:   fuzzy.Values{"heater": 10, "cooler": 10, "fan": 10},
+			expected: map[string]float64{"out_xor": 0, "out_xor3": 1, "out_nand": 0, "out_nor": 0, "out_grouped": 0},
+		},
+		{
+			inputs:   fuzzy.Values{"heater": 8, "cooler": 3, "fan": 0},
+			expected: map[string]float64{"out_xor": 0.7, "out_xor3": 0.7, "out_nand": 0.7, "out_nor": 0.2, "out_grouped": 0.7},
+		},
+	}
+
+	for i, tc := range testCases {
+		results, err := engine.Infer(tc.inputs)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		for variable, e := range tc.expected {
+			if g := results[variable]["1"].TruthDegree(); math.Abs(g-e) > 1e-9 {
+				t.Errorf("test case #%d: %s: got '%v', expected '%v'", i, variable, g, e)
+			}
+		}
+	}
+
+	text, err := Marshal(result.Variables, result.Rules)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	reparsed, err := ParseRulesAndVariables(text)
+	if err != nil {
+		t.Fatalf("could not reparse '%s': %+v", text, errors.WithStack(err))
+	}
+
+	for i, e := range expectedRules {
+		if g := reparsed.Rules[i].String(); g != e {
+			t.Errorf("reparsed rule #%d: got '%v', expected '%v'", i, g, e)
+		}
+	}
+}
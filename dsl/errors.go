@@ -2,6 +2,7 @@ package dsl
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 )
@@ -52,4 +53,47 @@ func newParseError(msg string, pos Position, cause error) *ParseError {
 		cause:    cause,
 		stackErr: stackErr,
 	}
-}
\ No newline at end of file
+}
+
+// ParseErrors holds all the errors found while parsing a DSL source, in order
+// of appearance. Callers can retrieve it with errors.As() to access the position
+// of each error, i.e. to highlight them in an editor.
+type ParseErrors []*ParseError
+
+// Error implements the error interface, joining the messages of the errors
+func (e ParseErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return fmt.Sprintf("parsing errors: %s", strings.Join(messages, "; "))
+}
+
+// Unwrap returns the individual errors, i.e. for errors.Is(err, fuzzy.ErrVariableAlreadyExists)
+func (e ParseErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// toParseError returns the ParseError wrapped by err or, failing that, a new ParseError
+// located at the current token
+func (p *Parser) toParseError(err error) *ParseError {
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		return parseErr
+	}
+
+	var pos Position
+	if p.current < len(p.tokens) {
+		pos = p.tokens[p.current].Position
+	} else if len(p.tokens) > 0 {
+		pos = p.tokens[len(p.tokens)-1].Position
+	}
+
+	return newParseError(err.Error(), pos, err)
+}
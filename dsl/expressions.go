@@ -2,6 +2,7 @@ package dsl
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/bornholm/go-fuzzy"
 )
@@ -59,8 +60,31 @@ func (p *Parser) parseRule() (*fuzzy.Rule, error) {
 	}
 	p.current++ // Skip THEN
 
-	// Parse conclusion (which is always an IS expression)
-	variable, term, err := p.parseIsExpression()
+	// Parse conclusions, separated by commas or AND
+	conclusions, err := p.parseConclusions()
+	if err != nil {
+		return nil, err
+	}
+
+	// Optional alternative conclusions (ELSE conclusions)
+	var alternatives []*fuzzy.IsExpr
+	if p.current < len(p.tokens) && p.tokens[p.current].Type == tokenELSE {
+		p.current++ // Skip ELSE
+
+		alternatives, err = p.parseConclusions()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Optional rule weight (WEIGHT number)
+	weight, err := p.parseWeight()
+	if err != nil {
+		return nil, err
+	}
+
+	// Optional rule priority (PRIORITY integer)
+	priority, err := p.parsePriority()
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +102,7 @@ func (p *Parser) parseRule() (*fuzzy.Rule, error) {
 		}
 
 		// Save the current state to create the rule even without a semicolon
-		ruleWithoutSemicolon := fuzzy.If(premise).Then(variable, term)
+		ruleWithoutSemicolon := newRule(premise, conclusions, alternatives).WithWeight(weight).WithPriority(priority)
 
 		// Try to find the next IF token to continue parsing
 		for p.current < len(p.tokens) && p.tokens[p.current].Type != tokenIF {
@@ -90,61 +114,135 @@ func (p *Parser) parseRule() (*fuzzy.Rule, error) {
 	p.current++ // Skip semicolon
 
 	// Create and return the rule
-	rule := fuzzy.If(premise).Then(variable, term)
+	rule := newRule(premise, conclusions, alternatives).WithWeight(weight).WithPriority(priority)
 	return rule, nil
 }
 
+// newRule creates a rule with the given conclusions and alternative (ELSE) conclusions
+func newRule(premise fuzzy.Expr, conclusions, alternatives []*fuzzy.IsExpr) *fuzzy.Rule {
+	rule := fuzzy.NewRule(premise, conclusions...)
+
+	for _, a := range alternatives {
+		if value, crisp := a.CrispValue(); crisp {
+			rule.ElseValue(a.Variable(), value)
+		} else {
+			rule.Else(a.Variable(), a.Term())
+		}
+	}
+
+	return rule
+}
+
+// parseConclusions parses the conclusions of a rule, i.e. "a IS b, c IS d AND e = 5"
+func (p *Parser) parseConclusions() ([]*fuzzy.IsExpr, error) {
+	conclusions := make([]*fuzzy.IsExpr, 0, 1)
+
+	for {
+		conclusion, err := p.parseConclusion()
+		if err != nil {
+			return nil, err
+		}
+
+		conclusions = append(conclusions, conclusion)
+
+		if p.current >= len(p.tokens) || (p.tokens[p.current].Type != tokenCOMMA && p.tokens[p.current].Type != tokenAND) {
+			return conclusions, nil
+		}
+
+		p.current++ // Skip separator
+	}
+}
+
+// parseConclusion parses a single conclusion, either an IS expression or a crisp value (variable = number)
+func (p *Parser) parseConclusion() (*fuzzy.IsExpr, error) {
+	if p.current+1 < len(p.tokens) && p.tokens[p.current].Type == tokenVAR && p.tokens[p.current+1].Type == tokenEQUAL {
+		variable, value, err := p.parseCrispConclusion()
+		if err != nil {
+			return nil, err
+		}
+
+		return fuzzy.SetValue(variable, value), nil
+	}
+
+	variable, term, err := p.parseIsExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	return fuzzy.Set(variable, term), nil
+}
+
+// parseCrispConclusion parses a Takagi-Sugeno conclusion (variable = number)
+func (p *Parser) parseCrispConclusion() (string, float64, error) {
+	variable := p.tokens[p.current].Value
+	equalToken := p.tokens[p.current+1]
+	p.current += 2 // Skip variable and =
+
+	if p.current >= len(p.tokens) || p.tokens[p.current].Type == tokenSEMI {
+		return "", 0, newParseError("expected number after =", equalToken.Position, nil)
+	}
+
+	value, err := parseFloat(p.tokens[p.current])
+	if err != nil {
+		return "", 0, err
+	}
+	p.current++
+
+	return variable, value, nil
+}
+
+// binaryOperators lists the binary operators by increasing precedence: NOT binds
+// tighter than AND and NAND, which bind tighter than XOR, which binds tighter than OR and NOR
+var binaryOperators = [][]string{
+	{tokenOR, tokenNOR},
+	{tokenXOR},
+	{tokenAND, tokenNAND},
+}
+
 // parseExpression parses an expression (which can be an IS expression or a logical combination)
 func (p *Parser) parseExpression() (fuzzy.Expr, error) {
-	// Handle NOT
-	if p.current < len(p.tokens) && p.tokens[p.current].Type == tokenNOT {
-		p.current++ // Skip NOT
+	return p.parseBinaryExpression(0)
+}
 
-		// Handle parentheses after NOT
-		if p.current < len(p.tokens) && p.tokens[p.current].Type == tokenLPAREN {
-			p.current++ // Skip (
-			expr, err := p.parseExpression()
-			if err != nil {
-				return nil, err
-			}
+// parseBinaryExpression parses the operands combined with the operators of the given
+// precedence level or higher. Operators of the same level are left-associative.
+func (p *Parser) parseBinaryExpression(level int) (fuzzy.Expr, error) {
+	if level >= len(binaryOperators) {
+		return p.parseUnaryExpression()
+	}
 
-			if p.current >= len(p.tokens) || p.tokens[p.current].Type != tokenRPAREN {
-				var pos Position
-				if p.current < len(p.tokens) {
-					pos = p.tokens[p.current].Position
-				} else if p.current > 0 && p.current-1 < len(p.tokens) {
-					pos = p.tokens[p.current-1].Position
-				} else {
-					pos = Position{Line: 1, Column: 1} // Fallback
-				}
-				return nil, newParseError("missing closing parenthesis", pos, nil)
-			}
-			p.current++ // Skip )
+	left, err := p.parseBinaryExpression(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for p.current < len(p.tokens) && slices.Contains(binaryOperators[level], p.tokens[p.current].Type) {
+		operator := p.tokens[p.current].Type
+		p.current++ // Skip operator
 
-			// Apply NOT to the expression and check for logical combinations
-			notExpr := fuzzy.Not(expr)
-			return p.parseLogicalCombination(notExpr)
+		right, err := p.parseBinaryExpression(level + 1)
+		if err != nil {
+			return nil, err
 		}
 
-		// Parse the next expression (which could be a simple expression or another complex one)
-		// For "NOT pressure IS low", we need to properly handle it as a simple expression
-		var expr fuzzy.Expr
-		var err error
+		left = combineExprs(operator, left, right)
+	}
 
-		// Check if next token is a variable (indicating a simple expression like "pressure IS low")
-		if p.current < len(p.tokens) && p.tokens[p.current].Type == tokenVAR {
-			expr, err = p.parseSimpleExpression()
-		} else {
-			expr, err = p.parseExpression()
-		}
+	return left, nil
+}
 
+// parseUnaryExpression parses a negated, parenthesized or simple expression
+func (p *Parser) parseUnaryExpression() (fuzzy.Expr, error) {
+	// Handle NOT
+	if p.current < len(p.tokens) && p.tokens[p.current].Type == tokenNOT {
+		p.current++ // Skip NOT
+
+		expr, err := p.parseUnaryExpression()
 		if err != nil {
 			return nil, err
 		}
 
-		// Apply NOT and check for logical combinations (AND/OR) that might follow
-		notExpr := fuzzy.Not(expr)
-		return p.parseLogicalCombination(notExpr)
+		return fuzzy.Not(expr), nil
 	}
 
 	// Handle parenthesized expression
@@ -168,32 +266,121 @@ func (p *Parser) parseExpression() (fuzzy.Expr, error) {
 		}
 		p.current++ // Skip )
 
-		// Check for AND or OR after this expression
-		return p.parseLogicalCombination(expr)
+		return expr, nil
 	}
 
 	// Parse a simple expression (like "temperature IS hot")
-	expr, err := p.parseSimpleExpression()
+	return p.parseSimpleExpression()
+}
+
+// parseSimpleExpression parses a simple expression (variable IS [hedges] term),
+// or a comparison (variable > number)
+func (p *Parser) parseSimpleExpression() (fuzzy.Expr, error) {
+	if p.current+1 < len(p.tokens) && p.tokens[p.current].Type == tokenVAR {
+		if _, exists := comparisonOperators[p.tokens[p.current+1].Type]; exists {
+			return p.parseComparison()
+		}
+	}
+
+	variable, hedges, term, err := p.parseHedgedIsExpression()
 	if err != nil {
 		return nil, err
 	}
 
-	// Check for AND or OR after this expression
-	return p.parseLogicalCombination(expr)
+	var expr fuzzy.Expr = fuzzy.Is(variable, term)
+
+	// The hedge closest to the term applies first
+	for i := len(hedges) - 1; i >= 0; i-- {
+		expr = hedges[i](expr)
+	}
+
+	return expr, nil
 }
 
-// parseSimpleExpression parses a simple expression (variable IS term)
-func (p *Parser) parseSimpleExpression() (fuzzy.Expr, error) {
-	variable, term, err := p.parseIsExpression()
+// parseComparison parses a comparison of the input of a variable to a number,
+// i.e. "temperature >= 30"
+func (p *Parser) parseComparison() (fuzzy.Expr, error) {
+	variable := p.tokens[p.current].Value
+	operatorToken := p.tokens[p.current+1]
+	p.current += 2 // Skip variable and operator
+
+	if p.current >= len(p.tokens) || p.tokens[p.current].Type == tokenSEMI {
+		return nil, newParseError(fmt.Sprintf("expected number after %s", operatorToken.Value), operatorToken.Position, nil)
+	}
+
+	value, err := parseFloat(p.tokens[p.current])
 	if err != nil {
 		return nil, err
 	}
+	p.current++
 
-	return fuzzy.Is(variable, term), nil
+	return fuzzy.Compare(variable, comparisonOperators[operatorToken.Type], value), nil
 }
 
-// parseIsExpression parses a variable IS term expression and returns the variable and term
+// parseIsExpression parses a variable IS term expression and returns the variable and term.
+// Hedges are not allowed.
 func (p *Parser) parseIsExpression() (string, string, error) {
+	hedgeIndex := p.current + 2
+
+	variable, hedges, term, err := p.parseHedgedIsExpression()
+	if err != nil {
+		return "", "", err
+	}
+
+	if len(hedges) > 0 {
+		return "", "", newParseError(
+			fmt.Sprintf("unexpected hedge %s in conclusion", p.tokens[hedgeIndex].Value),
+			p.tokens[hedgeIndex].Position, nil,
+		)
+	}
+
+	return variable, term, nil
+}
+
+// parseHedges parses the hedges preceding a term, i.e. VERY or SOMEWHAT
+func (p *Parser) parseHedges() []func(fuzzy.Expr) *fuzzy.HedgeExpr {
+	var hedges []func(fuzzy.Expr) *fuzzy.HedgeExpr
+
+	for p.current < len(p.tokens) {
+		switch p.tokens[p.current].Type {
+		case tokenVERY:
+			hedges = append(hedges, fuzzy.Very)
+		case tokenSOMEWHAT:
+			hedges = append(hedges, fuzzy.Somewhat)
+		default:
+			return hedges
+		}
+
+		p.current++
+	}
+
+	return hedges
+}
+
+// parseHedgedIsExpression parses a variable IS [hedges] term expression and
+// returns the variable, the hedges and the term
+func (p *Parser) parseHedgedIsExpression() (string, []func(fuzzy.Expr) *fuzzy.HedgeExpr, string, error) {
+	variable, err := p.parseIsPrefix()
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	hedges := p.parseHedges()
+
+	term, err := p.parseIsTerm(variable)
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	return variable, hedges, term, nil
+}
+
+// parseIsPrefix parses the "variable IS" prefix of an IS expression and returns the variable
+func (p *Parser) parseIsPrefix() (string, error) {
+	if err := identifierError(p.tokens, p.current, "variable"); err != nil {
+		return "", err
+	}
+
 	if p.current >= len(p.tokens) || p.tokens[p.current].Type != tokenVAR {
 		var pos Position
 		if p.current < len(p.tokens) {
@@ -203,7 +390,7 @@ func (p *Parser) parseIsExpression() (string, string, error) {
 		} else {
 			pos = Position{Line: 1, Column: 1} // Fallback
 		}
-		return "", "", newParseError("expected variable name", pos, nil)
+		return "", newParseError("expected variable name", pos, nil)
 	}
 	variable := p.tokens[p.current].Value
 	varToken := p.tokens[p.current]
@@ -215,10 +402,19 @@ func (p *Parser) parseIsExpression() (string, string, error) {
 			Line:   varToken.Position.Line,
 			Column: varToken.Position.Column + len(varToken.Value) + 1,
 		}
-		return "", "", newParseError("expected IS after variable", pos, nil)
+		return "", newParseError("expected IS after variable", pos, nil)
 	}
 	p.current++ // Skip IS
 
+	return variable, nil
+}
+
+// parseIsTerm parses the term of an IS expression on the given variable
+func (p *Parser) parseIsTerm(variable string) (string, error) {
+	if err := identifierError(p.tokens, p.current, "term"); err != nil {
+		return "", err
+	}
+
 	if p.current >= len(p.tokens) || p.tokens[p.current].Type != tokenVAR {
 		var pos Position
 		if p.current < len(p.tokens) {
@@ -228,95 +424,50 @@ func (p *Parser) parseIsExpression() (string, string, error) {
 		} else {
 			pos = Position{Line: 1, Column: 1} // Fallback
 		}
-		return "", "", newParseError("expected term name after IS", pos, nil)
+		return "", newParseError("expected term name after IS", pos, nil)
 	}
 	term := p.tokens[p.current].Value
+	p.references = append(p.references, termReference{
+		Variable: variable,
+		Term:     term,
+		Pos:      p.tokens[p.current].Position,
+	})
 	p.current++ // Skip term
 
-	return variable, term, nil
+	return term, nil
 }
 
-// parseLogicalCombination handles AND/OR combinations
-func (p *Parser) parseLogicalCombination(left fuzzy.Expr) (fuzzy.Expr, error) {
-	// Check if there's an AND or OR following
-	if p.current < len(p.tokens) {
-		if p.tokens[p.current].Type == tokenAND {
-			p.current++ // Skip AND
-
-			// Parse the right side expression
-			right, err := p.parseExpression()
-			if err != nil {
-				return nil, err
-			}
-
-			// If left is already an AndExpr, add to it
-			if leftAnd, ok := left.(*fuzzy.AndExpr); ok {
-				// Create new slice to avoid modifying the original
-				newExprs := make([]fuzzy.Expr, len(leftAnd.Exprs())+1)
-				copy(newExprs, leftAnd.Exprs())
-
-				// Check if right is also an AndExpr
-				if rightAnd, ok := right.(*fuzzy.AndExpr); ok {
-					// Flatten nested AND expressions
-					newExprs = append(newExprs[:len(leftAnd.Exprs())], rightAnd.Exprs()...)
-				} else {
-					newExprs[len(leftAnd.Exprs())] = right
-				}
-
-				return fuzzy.And(newExprs...), nil
-			}
-
-			// Check if right is an AndExpr
-			if rightAnd, ok := right.(*fuzzy.AndExpr); ok {
-				// Create new slice with left as first element
-				newExprs := make([]fuzzy.Expr, len(rightAnd.Exprs())+1)
-				newExprs[0] = left
-				copy(newExprs[1:], rightAnd.Exprs())
-
-				return fuzzy.And(newExprs...), nil
-			}
-
-			return fuzzy.And(left, right), nil
-		} else if p.tokens[p.current].Type == tokenOR {
-			p.current++ // Skip OR
-
-			// Parse the right side expression
-			right, err := p.parseExpression()
-			if err != nil {
-				return nil, err
-			}
-
-			// If left is already an OrExpr, add to it
-			if leftOr, ok := left.(*fuzzy.OrExpr); ok {
-				// Create new slice to avoid modifying the original
-				newExprs := make([]fuzzy.Expr, len(leftOr.Exprs())+1)
-				copy(newExprs, leftOr.Exprs())
-
-				// Check if right is also an OrExpr
-				if rightOr, ok := right.(*fuzzy.OrExpr); ok {
-					// Flatten nested OR expressions
-					newExprs = append(newExprs[:len(leftOr.Exprs())], rightOr.Exprs()...)
-				} else {
-					newExprs[len(leftOr.Exprs())] = right
-				}
-
-				return fuzzy.Or(newExprs...), nil
-			}
-
-			// Check if right is an OrExpr
-			if rightOr, ok := right.(*fuzzy.OrExpr); ok {
-				// Create new slice with left as first element
-				newExprs := make([]fuzzy.Expr, len(rightOr.Exprs())+1)
-				newExprs[0] = left
-				copy(newExprs[1:], rightOr.Exprs())
+// combineExprs combines the operands with the given binary operator. Nested AND and OR
+// expressions are flattened, NAND and NOR being sugar for NOT (a AND b) and NOT (a OR b),
+// they are not. XOR expressions are only flattened on the left.
+func combineExprs(operator string, left, right fuzzy.Expr) fuzzy.Expr {
+	switch operator {
+	case tokenAND:
+		return fuzzy.And(flattenExprs[*fuzzy.AndExpr](left, right)...)
+	case tokenOR:
+		return fuzzy.Or(flattenExprs[*fuzzy.OrExpr](left, right)...)
+	case tokenXOR:
+		// The exclusive disjunction is only associative with some norms: XorExpr being
+		// evaluated from left to right, a right operand, i.e. a XOR (b XOR c), is kept nested
+		return fuzzy.Xor(append(flattenExprs[*fuzzy.XorExpr](left), right)...)
+	case tokenNAND:
+		return fuzzy.Nand(left, right)
+	default:
+		return fuzzy.Nor(left, right)
+	}
+}
 
-				return fuzzy.Or(newExprs...), nil
-			}
+// flattenExprs returns the operands, replacing the expressions of type E by their own operands
+func flattenExprs[E interface{ Exprs() []fuzzy.Expr }](exprs ...fuzzy.Expr) []fuzzy.Expr {
+	flattened := make([]fuzzy.Expr, 0, len(exprs))
 
-			return fuzzy.Or(left, right), nil
+	for _, e := range exprs {
+		if nested, ok := e.(E); ok {
+			flattened = append(flattened, nested.Exprs()...)
+		} else {
+			flattened = append(flattened, e)
 		}
 	}
 
-	// No AND/OR, just return the expression
-	return left, nil
+	return flattened
 }
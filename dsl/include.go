@@ -0,0 +1,75 @@
+package dsl
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// IncludeResolver returns the DSL text of the file included with INCLUDE "path"
+type IncludeResolver func(path string) (string, error)
+
+// expandIncludes replaces the INCLUDE "path" directives of the tokens, optionally followed
+// by a semicolon, by the tokens of the included files, resolved with the include resolver
+// of the options. The stack holds the paths of the files being included, to detect cycles.
+func expandIncludes(tokens []Token, opts *Options, stack []string) ([]Token, error) {
+	expanded := make([]Token, 0, len(tokens))
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if token.Type != tokenINCLUDE {
+			expanded = append(expanded, token)
+			continue
+		}
+
+		if i+1 >= len(tokens) || tokens[i+1].Type != tokenVAR || !tokens[i+1].quoted {
+			return nil, newParseError("expected a quoted path after INCLUDE", token.Position, nil)
+		}
+
+		i++
+		path := tokens[i].Value
+
+		if i+1 < len(tokens) && tokens[i+1].Type == tokenSEMI {
+			i++
+		}
+
+		if opts.IncludeResolver == nil {
+			return nil, newParseError(fmt.Sprintf("cannot include '%s' without include resolver", path), token.Position, nil)
+		}
+
+		if slices.Contains(stack, path) {
+			cycle := strings.Join(append(stack, path), " -> ")
+			return nil, newParseError(fmt.Sprintf("include cycle %s", cycle), token.Position, nil)
+		}
+
+		content, err := opts.IncludeResolver(path)
+		if err != nil {
+			return nil, newParseError(fmt.Sprintf("could not include '%s'", path), token.Position, errors.WithStack(err))
+		}
+
+		included, err := tokenize(content, opts.CommentStyles)
+		if err != nil {
+			var parseErr *ParseError
+			if errors.As(err, &parseErr) {
+				parseErr.Pos.File = path
+			}
+
+			return nil, errors.WithStack(err)
+		}
+
+		for j := range included {
+			included[j].Position.File = path
+		}
+
+		included, err = expandIncludes(included, opts, append(slices.Clone(stack), path))
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		expanded = append(expanded, included...)
+	}
+
+	return expanded, nil
+}
@@ -0,0 +1,280 @@
+package dsl
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bornholm/go-fuzzy"
+	"github.com/pkg/errors"
+)
+
+// Marshal renders the variables and the rules as DSL text, which can be parsed back
+// with ParseRulesAndVariables. Terms are emitted sorted by name.
+//
+// Only the built-in memberships and expressions can be expressed in the DSL: an error
+// wrapping fuzzy.ErrUnsupportedMembership or fuzzy.ErrUnsupportedExpr is returned otherwise.
+func Marshal(variables []*fuzzy.Variable, rules []*fuzzy.Rule) (string, error) {
+	var sb strings.Builder
+
+	for _, v := range variables {
+		if err := marshalVariable(&sb, v); err != nil {
+			return "", errors.Wrapf(err, "could not marshal variable '%s'", v.Name())
+		}
+	}
+
+	if len(variables) > 0 && len(rules) > 0 {
+		sb.WriteString("\n")
+	}
+
+	for i, r := range rules {
+		if err := marshalRule(&sb, r); err != nil {
+			return "", errors.Wrapf(err, "could not marshal rule #%d", i)
+		}
+	}
+
+	return sb.String(), nil
+}
+
+func marshalVariable(sb *strings.Builder, v *fuzzy.Variable) error {
+	if err := marshalAnnotations(sb, v.Metadata()); err != nil {
+		return errors.WithStack(err)
+	}
+
+	terms := v.Terms()
+	slices.SortFunc(terms, func(a, b *fuzzy.Term) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	name, err := formatIdentifier(v.Name())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	fmt.Fprintf(sb, "DEFINE %s (\n", name)
+
+	for i, t := range terms {
+		membership, err := marshalMembership(t.Membership())
+		if err != nil {
+			return errors.Wrapf(err, "could not marshal term '%s'", t.Name())
+		}
+
+		termName, err := formatIdentifier(t.Name())
+		if err != nil {
+			return errors.Wrapf(err, "could not marshal term '%s'", t.Name())
+		}
+
+		fmt.Fprintf(sb, "\tTERM %s", termName)
+
+		if t.Weight() != 1 {
+			fmt.Fprintf(sb, " WEIGHT %s", formatNumber(t.Weight()))
+		}
+
+		sb.WriteString(" ")
+		sb.WriteString(membership)
+
+		if i < len(terms)-1 {
+			sb.WriteString(",")
+		}
+
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(");\n")
+
+	return nil
+}
+
+func marshalRule(sb *strings.Builder, r *fuzzy.Rule) error {
+	if err := checkMarshalableExpr(r.Premise()); err != nil {
+		return errors.WithStack(err)
+	}
+
+	for _, c := range slices.Concat(r.Conclusions(), r.Alternatives()) {
+		if err := checkRuleIdentifier(c.Variable()); err != nil {
+			return errors.WithStack(err)
+		}
+
+		if _, crisp := c.CrispValue(); crisp {
+			continue
+		}
+
+		if err := checkRuleIdentifier(c.Term()); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	if err := marshalAnnotations(sb, r.Metadata()); err != nil {
+		return errors.WithStack(err)
+	}
+
+	sb.WriteString(r.String())
+	sb.WriteString(";\n")
+
+	return nil
+}
+
+// marshalAnnotations emits the metadata as @key="value" annotations, sorted by key
+func marshalAnnotations(sb *strings.Builder, metadata map[string]string) error {
+	for _, key := range slices.Sorted(maps.Keys(metadata)) {
+		value := metadata[key]
+
+		if !isAnnotationSafe(key) || strings.ContainsAny(value, "\"\r\n") {
+			return errors.Errorf("annotation @%s=%q cannot be expressed in the DSL", key, value)
+		}
+
+		fmt.Fprintf(sb, "@%s=\"%s\"\n", key, value)
+	}
+
+	return nil
+}
+
+// isAnnotationSafe returns true if the string would be read back as a single token
+func isAnnotationSafe(s string) bool {
+	return !strings.ContainsAny(s, specialChars+"\" \t\r\n")
+}
+
+// formatIdentifier returns the name as is if it is read back as a single identifier,
+// double-quoted otherwise, i.e. for names with spaces or clashing with a keyword or a number
+func formatIdentifier(name string) (string, error) {
+	if strings.ContainsAny(name, "\"\r\n") {
+		return "", errors.Errorf("identifier %q cannot be expressed in the DSL", name)
+	}
+
+	tokens, err := tokenize(name, nil)
+	if err == nil && len(tokens) == 1 && tokens[0].Type == tokenVAR && tokens[0].Value == name && !isNumericLiteral(tokens[0]) {
+		return name, nil
+	}
+
+	return `"` + name + `"`, nil
+}
+
+// checkRuleIdentifier returns an error if the name cannot be read back from the rule text,
+// where only the names with spaces or punctuation are quoted
+func checkRuleIdentifier(name string) error {
+	quoted, err := formatIdentifier(name)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if quoted != name && name != "" && !strings.ContainsAny(name, specialChars+" \t") {
+		return errors.Errorf("identifier '%s' would be read back as a keyword or a number", name)
+	}
+
+	return nil
+}
+
+// checkMarshalableExpr returns an error if the expression cannot be parsed back from its text
+func checkMarshalableExpr(e fuzzy.Expr) error {
+	switch typ := e.(type) {
+	case *fuzzy.IsExpr:
+		if err := checkRuleIdentifier(typ.Variable()); err != nil {
+			return errors.WithStack(err)
+		}
+
+		if err := checkRuleIdentifier(typ.Term()); err != nil {
+			return errors.WithStack(err)
+		}
+
+		return nil
+	case *fuzzy.CompareExpr:
+		if err := checkRuleIdentifier(typ.Variable()); err != nil {
+			return errors.WithStack(err)
+		}
+
+		return nil
+	case *fuzzy.AndExpr:
+		return checkMarshalableExprs(typ.Exprs())
+	case *fuzzy.OrExpr:
+		return checkMarshalableExprs(typ.Exprs())
+	case *fuzzy.XorExpr:
+		return checkMarshalableExprs(typ.Exprs())
+	case *fuzzy.NotExpr:
+		return checkMarshalableExpr(typ.Expr())
+	case *fuzzy.HedgeExpr:
+		// Hedges are only parsed between IS and the term
+		switch inner := typ.Expr().(type) {
+		case *fuzzy.IsExpr, *fuzzy.HedgeExpr:
+		default:
+			return errors.Wrapf(fuzzy.ErrUnsupportedExpr, "hedge on '%s'", inner)
+		}
+
+		if e := typ.Exponent(); e != fuzzy.VeryExponent && e != fuzzy.SomewhatExponent {
+			return errors.Wrapf(fuzzy.ErrUnsupportedExpr, "hedge exponent %v", e)
+		}
+
+		return checkMarshalableExpr(typ.Expr())
+	default:
+		return errors.Wrapf(fuzzy.ErrUnsupportedExpr, "%T", e)
+	}
+}
+
+func checkMarshalableExprs(exprs []fuzzy.Expr) error {
+	for _, e := range exprs {
+		if err := checkMarshalableExpr(e); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+func marshalMembership(m fuzzy.Membership) (string, error) {
+	def, err := fuzzy.DescribeMembership(m)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return formatMembership(def)
+}
+
+func formatMembership(def fuzzy.MembershipDefinition) (string, error) {
+	switch def.Type {
+	case fuzzy.KindLinear, fuzzy.KindTriangular, fuzzy.KindTrapezoid, fuzzy.KindRectangular,
+		fuzzy.KindSCurve, fuzzy.KindZCurve, fuzzy.KindSigmoid:
+		params := make([]string, 0, len(def.Params))
+		for _, p := range def.Params {
+			params = append(params, formatNumber(p))
+		}
+
+		return fmt.Sprintf("%s (%s)", def.Type, strings.Join(params, ", ")), nil
+
+	case fuzzy.KindInverted:
+		// INVERTED(LINEAR(a, b)) is what ParseLinear() produces for a descending LINEAR(b, a)
+		if len(def.Children) == 1 {
+			child := def.Children[0]
+			if child.Type == fuzzy.KindLinear && len(child.Params) == 2 && child.Params[0] < child.Params[1] {
+				return fmt.Sprintf("%s (%s, %s)", fuzzy.KindLinear, formatNumber(child.Params[1]), formatNumber(child.Params[0])), nil
+			}
+		}
+
+		return formatMemberships(def)
+
+	case fuzzy.KindMin, fuzzy.KindMax:
+		return formatMemberships(def)
+
+	default:
+		return "", errors.Wrapf(fuzzy.ErrUnsupportedMembership, "%s cannot be expressed in the DSL", def.Type)
+	}
+}
+
+func formatMemberships(def fuzzy.MembershipDefinition) (string, error) {
+	children := make([]string, 0, len(def.Children))
+
+	for _, c := range def.Children {
+		child, err := formatMembership(c)
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+
+		children = append(children, child)
+	}
+
+	return fmt.Sprintf("%s (%s)", def.Type, strings.Join(children, ", ")), nil
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
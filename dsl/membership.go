@@ -2,6 +2,9 @@ package dsl
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/bornholm/go-fuzzy"
 	"github.com/pkg/errors"
@@ -52,65 +55,44 @@ func (p *Parser) parseMembershipFunction() (fuzzy.Membership, error) {
 }
 
 const (
-	tokenLINEAR     string = "LINEAR"
-	tokenTRIANGULAR string = "TRIANGULAR"
-	tokenTRAPEZOID  string = "TRAPEZOID"
-	tokenINVERTED   string = "INVERTED"
+	tokenLINEAR      string = "LINEAR"
+	tokenTRIANGULAR  string = "TRIANGULAR"
+	tokenTRAPEZOID   string = "TRAPEZOID"
+	tokenINVERTED    string = "INVERTED"
+	tokenSIGMOID     string = "SIGMOID"
+	tokenRECTANGULAR string = "RECTANGULAR"
+	tokenBETWEEN     string = "BETWEEN"
+	tokenSCURVE      string = "SCURVE"
+	tokenZCURVE      string = "ZCURVE"
+	tokenMIN         string = "MIN"
+	tokenMAX         string = "MAX"
 )
 
 var DefaultMemberships = map[string]MembershipParser{
-	tokenLINEAR:     ParseMembershipFunc(ParseLinear),
-	tokenTRIANGULAR: ParseMembershipFunc(ParseTriangular),
-	tokenTRAPEZOID:  ParseMembershipFunc(ParseTrapezoid),
-	tokenINVERTED:   ParseMembershipFunc(ParseInverted),
+	tokenLINEAR:      ParseMembershipFunc(ParseLinear),
+	tokenTRIANGULAR:  ParseMembershipFunc(ParseTriangular),
+	tokenTRAPEZOID:   ParseMembershipFunc(ParseTrapezoid),
+	tokenINVERTED:    ParseMembershipFunc(ParseInverted),
+	tokenSIGMOID:     ParseMembershipFunc(ParseSigmoid),
+	tokenRECTANGULAR: ParseMembershipFunc(ParseRectangular),
+	tokenBETWEEN:     ParseMembershipFunc(ParseBetween),
+	tokenSCURVE:      ParseMembershipFunc(ParseSCurve),
+	tokenZCURVE:      ParseMembershipFunc(ParseZCurve),
+	tokenMIN:         ParseMembershipFunc(ParseMin),
+	tokenMAX:         ParseMembershipFunc(ParseMax),
 }
 
-// ParseLinear parses a LINEAR(x1, x2) membership function
+// ParseLinear parses a LINEAR(x1, x2) membership function.
+//
+// A descending LINEAR(x1, x2), with x1 > x2, is rewritten as INVERTED(LINEAR(x2, x1)).
+// See ParseRawLinear to reject descending linear functions instead.
 func ParseLinear(tokens []Token, current int, parse ParseMembershipFunc) (fuzzy.Membership, int, error) {
-	// Expect open parenthesis
-	if current >= len(tokens) || tokens[current].Type != tokenLPAREN {
-		return nil, current, newParseError("expected ( after LINEAR",
-			tokens[current-1].Position, nil)
-	}
-	current++
-
-	// Parse first parameter
-	if current >= len(tokens) || tokens[current].Type != tokenVAR {
-		return nil, current, newParseError("expected first parameter for LINEAR",
-			tokens[current-1].Position, nil)
-	}
-	x1Str := tokens[current].Value
-	x1, err := parseFloat(x1Str, tokens[current].Position)
+	params, current, err := parseMembershipParams(tokens, current, tokenLINEAR, "a", "b")
 	if err != nil {
 		return nil, current, err
 	}
-	current++
 
-	// Expect comma
-	if current >= len(tokens) || tokens[current].Type != tokenCOMMA {
-		return nil, current, newParseError("expected , between parameters",
-			tokens[current-1].Position, nil)
-	}
-	current++
-
-	// Parse second parameter
-	if current >= len(tokens) || tokens[current].Type != tokenVAR {
-		return nil, current, newParseError("expected second parameter for LINEAR",
-			tokens[current-1].Position, nil)
-	}
-	x2Str := tokens[current].Value
-	x2, err := parseFloat(x2Str, tokens[current].Position)
-	if err != nil {
-		return nil, current, err
-	}
-	current++
-
-	// Expect closing parenthesis
-	if current >= len(tokens) || tokens[current].Type != tokenRPAREN {
-		return nil, current, newParseError("expected ) after LINEAR parameters",
-			tokens[current-1].Position, nil)
-	}
-	current++
+	x1, x2 := params[0], params[1]
 
 	// The Linear function has issues with descending linear functions (x1 > x2)
 	// Create a function that behaves correctly for both ascending and descending cases
@@ -127,161 +109,253 @@ func ParseLinear(tokens []Token, current int, parse ParseMembershipFunc) (fuzzy.
 	}
 }
 
-// ParseTriangular parses a TRIANGULAR(x1, x2, x3) membership function
-func ParseTriangular(tokens []Token, current int, parse ParseMembershipFunc) (fuzzy.Membership, int, error) {
-	// Expect open parenthesis
-	if current >= len(tokens) || tokens[current].Type != tokenLPAREN {
-		return nil, current, newParseError("expected ( after TRIANGULAR",
-			tokens[current-1].Position, nil)
+// ParseRawLinear parses a LINEAR(x1, x2) membership function without rewriting it,
+// i.e. a descending LINEAR(x1, x2), with x1 > x2, is reported as an error
+func ParseRawLinear(tokens []Token, current int, parse ParseMembershipFunc) (fuzzy.Membership, int, error) {
+	funcTypeToken := tokens[current-1]
+
+	params, current, err := parseMembershipParams(tokens, current, tokenLINEAR, "a", "b")
+	if err != nil {
+		return nil, current, err
 	}
-	current++
 
-	// Parse first parameter
-	if current >= len(tokens) || tokens[current].Type != tokenVAR {
-		return nil, current, newParseError("expected first parameter for TRIANGULAR",
-			tokens[current-1].Position, nil)
+	x1, x2 := params[0], params[1]
+
+	if x1 > x2 {
+		return nil, current, newParseError(
+			fmt.Sprintf("descending LINEAR(%v, %v) is not allowed, use INVERTED(LINEAR(%v, %v)) instead", x1, x2, x2, x1),
+			funcTypeToken.Position, nil)
 	}
-	x1Str := tokens[current].Value
-	x1, err := parseFloat(x1Str, tokens[current].Position)
-	if err != nil {
-		return nil, current, errors.WithStack(err)
+
+	if x1 == x2 {
+		return fuzzy.Step(x1), current, nil
 	}
-	current++
 
-	// Expect comma
-	if current >= len(tokens) || tokens[current].Type != tokenCOMMA {
-		return nil, current, newParseError("expected , between parameters",
-			tokens[current-1].Position, nil)
+	return fuzzy.Linear(x1, x2), current, nil
+}
+
+// ParseTriangular parses a TRIANGULAR(x1, x2, x3) membership function
+func ParseTriangular(tokens []Token, current int, parse ParseMembershipFunc) (fuzzy.Membership, int, error) {
+	funcTypeToken := tokens[current-1]
+
+	params, current, err := parseMembershipParams(tokens, current, tokenTRIANGULAR, "a", "b", "c")
+	if err != nil {
+		return nil, current, err
 	}
-	current++
 
-	// Parse second parameter
-	if current >= len(tokens) || tokens[current].Type != tokenVAR {
-		return nil, current, newParseError("expected second parameter for TRIANGULAR",
-			tokens[current-1].Position, nil)
+	if err := checkNonDecreasing(funcTypeToken, tokenTRIANGULAR, params); err != nil {
+		return nil, current, err
 	}
-	x2Str := tokens[current].Value
-	x2, err := parseFloat(x2Str, tokens[current].Position)
+
+	return fuzzy.Triangular(params[0], params[1], params[2]), current, nil
+}
+
+// ParseTrapezoid parses a TRAPEZOID(x1, x2, x3, x4) membership function
+func ParseTrapezoid(tokens []Token, current int, parse ParseMembershipFunc) (fuzzy.Membership, int, error) {
+	funcTypeToken := tokens[current-1]
+
+	params, current, err := parseMembershipParams(tokens, current, tokenTRAPEZOID, "a", "b", "c", "d")
 	if err != nil {
-		return nil, current, errors.WithStack(err)
+		return nil, current, err
 	}
-	current++
 
-	// Expect comma
-	if current >= len(tokens) || tokens[current].Type != tokenCOMMA {
-		return nil, current, newParseError("expected , between parameters",
-			tokens[current-1].Position, nil)
+	if err := checkNonDecreasing(funcTypeToken, tokenTRAPEZOID, params); err != nil {
+		return nil, current, err
 	}
-	current++
 
-	// Parse third parameter
-	if current >= len(tokens) || tokens[current].Type != tokenVAR {
-		return nil, current, newParseError("expected third parameter for TRIANGULAR",
-			tokens[current-1].Position, nil)
+	return fuzzy.Trapezoid(params[0], params[1], params[2], params[3]), current, nil
+}
+
+// checkNonDecreasing returns a parse error if the membership function parameters
+// are not in non-decreasing order, which would produce a term never activated
+func checkNonDecreasing(funcTypeToken Token, funcType string, params []float64) error {
+	for i := 1; i < len(params); i++ {
+		if params[i] >= params[i-1] {
+			continue
+		}
+
+		values := make([]string, len(params))
+		for j, p := range params {
+			values[j] = strconv.FormatFloat(p, 'f', -1, 64)
+		}
+
+		return newParseError(
+			fmt.Sprintf("%s parameters must be in non-decreasing order, got (%s)", funcType, strings.Join(values, ", ")),
+			funcTypeToken.Position, nil)
 	}
-	x3Str := tokens[current].Value
-	x3, err := parseFloat(x3Str, tokens[current].Position)
+
+	return nil
+}
+
+// ParseRectangular parses a RECTANGULAR(a, b) membership function
+func ParseRectangular(tokens []Token, current int, parse ParseMembershipFunc) (fuzzy.Membership, int, error) {
+	funcTypeToken := tokens[current-1]
+
+	params, current, err := parseMembershipParams(tokens, current, tokenRECTANGULAR, "a", "b")
 	if err != nil {
-		return nil, current, errors.WithStack(err)
+		return nil, current, err
 	}
-	current++
 
-	// Expect closing parenthesis
-	if current >= len(tokens) || tokens[current].Type != tokenRPAREN {
-		return nil, current, newParseError("expected ) after TRIANGULAR parameters",
-			tokens[current-1].Position, nil)
+	if err := checkNonDecreasing(funcTypeToken, tokenRECTANGULAR, params); err != nil {
+		return nil, current, err
 	}
-	current++
 
-	return fuzzy.Triangular(x1, x2, x3), current, nil
+	return fuzzy.Rectangular(params[0], params[1]), current, nil
 }
 
-// ParseTrapezoid parses a TRAPEZOID(x1, x2, x3, x4) membership function
-func ParseTrapezoid(tokens []Token, current int, parse ParseMembershipFunc) (fuzzy.Membership, int, error) {
-	// Expect open parenthesis
-	if current >= len(tokens) || tokens[current].Type != tokenLPAREN {
-		return nil, current, newParseError("expected ( after TRAPEZOID",
-			tokens[current-1].Position, nil)
+// ParseBetween parses a BETWEEN(a, b) membership function, a shortcut for the crisp
+// band RECTANGULAR(a, b): 1 for a <= x <= b, both bounds included, and 0 otherwise
+func ParseBetween(tokens []Token, current int, parse ParseMembershipFunc) (fuzzy.Membership, int, error) {
+	funcTypeToken := tokens[current-1]
+
+	params, current, err := parseMembershipParams(tokens, current, tokenBETWEEN, "a", "b")
+	if err != nil {
+		return nil, current, err
 	}
-	current++
 
-	// Parse first parameter
-	if current >= len(tokens) || tokens[current].Type != tokenVAR {
-		return nil, current, newParseError("expected first parameter for TRAPEZOID",
-			tokens[current-1].Position, nil)
+	if err := checkNonDecreasing(funcTypeToken, tokenBETWEEN, params); err != nil {
+		return nil, current, err
 	}
-	x1Str := tokens[current].Value
-	x1, err := parseFloat(x1Str, tokens[current].Position)
+
+	return fuzzy.Rectangular(params[0], params[1]), current, nil
+}
+
+// ParseSCurve parses a SCURVE(a, b) membership function
+func ParseSCurve(tokens []Token, current int, parse ParseMembershipFunc) (fuzzy.Membership, int, error) {
+	funcTypeToken := tokens[current-1]
+
+	params, current, err := parseMembershipParams(tokens, current, tokenSCURVE, "a", "b")
 	if err != nil {
-		return nil, current, errors.WithStack(err)
+		return nil, current, err
 	}
-	current++
 
-	// Expect comma
-	if current >= len(tokens) || tokens[current].Type != tokenCOMMA {
-		return nil, current, newParseError("expected , between parameters",
-			tokens[current-1].Position, nil)
+	if err := checkNonDecreasing(funcTypeToken, tokenSCURVE, params); err != nil {
+		return nil, current, err
 	}
-	current++
 
-	// Parse second parameter
-	if current >= len(tokens) || tokens[current].Type != tokenVAR {
-		return nil, current, newParseError("expected second parameter for TRAPEZOID",
-			tokens[current-1].Position, nil)
-	}
-	x2Str := tokens[current].Value
-	x2, err := parseFloat(x2Str, tokens[current].Position)
+	return fuzzy.SCurve(params[0], params[1]), current, nil
+}
+
+// ParseZCurve parses a ZCURVE(a, b) membership function
+func ParseZCurve(tokens []Token, current int, parse ParseMembershipFunc) (fuzzy.Membership, int, error) {
+	funcTypeToken := tokens[current-1]
+
+	params, current, err := parseMembershipParams(tokens, current, tokenZCURVE, "a", "b")
 	if err != nil {
-		return nil, current, errors.WithStack(err)
+		return nil, current, err
 	}
-	current++
 
-	// Expect comma
-	if current >= len(tokens) || tokens[current].Type != tokenCOMMA {
-		return nil, current, newParseError("expected , between parameters",
-			tokens[current-1].Position, nil)
+	if err := checkNonDecreasing(funcTypeToken, tokenZCURVE, params); err != nil {
+		return nil, current, err
 	}
-	current++
 
-	// Parse third parameter
-	if current >= len(tokens) || tokens[current].Type != tokenVAR {
-		return nil, current, newParseError("expected third parameter for TRAPEZOID",
-			tokens[current-1].Position, nil)
-	}
-	x3Str := tokens[current].Value
-	x3, err := parseFloat(x3Str, tokens[current].Position)
+	return fuzzy.ZCurve(params[0], params[1]), current, nil
+}
+
+// ParseSigmoid parses a SIGMOID(a, c) membership function,
+// a being the slope and c the crossover point
+func ParseSigmoid(tokens []Token, current int, parse ParseMembershipFunc) (fuzzy.Membership, int, error) {
+	params, current, err := parseMembershipParams(tokens, current, tokenSIGMOID, "a", "c")
 	if err != nil {
-		return nil, current, errors.WithStack(err)
+		return nil, current, err
 	}
-	current++
 
-	// Expect comma
-	if current >= len(tokens) || tokens[current].Type != tokenCOMMA {
-		return nil, current, newParseError("expected , between parameters",
-			tokens[current-1].Position, nil)
+	return fuzzy.Sigmoid(params[0], params[1]), current, nil
+}
+
+var ordinals = []string{"first", "second", "third", "fourth", "fifth", "sixth"}
+
+func ordinal(i int) string {
+	if i < len(ordinals) {
+		return ordinals[i]
 	}
-	current++
 
-	// Parse fourth parameter
-	if current >= len(tokens) || tokens[current].Type != tokenVAR {
-		return nil, current, newParseError("expected fourth parameter for TRAPEZOID",
+	return fmt.Sprintf("#%d", i+1)
+}
+
+// parseMembershipParams parses the parenthesized numeric parameters of a membership function.
+// Parameters can either be given positionally, i.e. TRAPEZOID (15, 20, 25, 30), or by name,
+// i.e. TRAPEZOID (a=15, b=20, c=25, d=30), in which case their order does not matter.
+// Both forms cannot be mixed in a single function call.
+// The returned values are always ordered as the given parameter names.
+func parseMembershipParams(tokens []Token, current int, funcType string, names ...string) ([]float64, int, error) {
+	// Expect open parenthesis
+	if current >= len(tokens) || tokens[current].Type != tokenLPAREN {
+		return nil, current, newParseError(fmt.Sprintf("expected ( after %s", funcType),
 			tokens[current-1].Position, nil)
 	}
-	x4Str := tokens[current].Value
-	x4, err := parseFloat(x4Str, tokens[current].Position)
-	if err != nil {
-		return nil, current, errors.WithStack(err)
-	}
 	current++
 
+	values := make([]float64, len(names))
+	assigned := make([]bool, len(names))
+	keyword := false
+
+	for i := range names {
+		if i > 0 {
+			// Expect comma
+			if current >= len(tokens) || tokens[current].Type != tokenCOMMA {
+				return nil, current, newParseError("expected , between parameters",
+					tokens[current-1].Position, nil)
+			}
+			current++
+		}
+
+		if current >= len(tokens) || tokens[current].Type != tokenVAR {
+			return nil, current, newParseError(fmt.Sprintf("expected %s parameter for %s", ordinal(i), funcType),
+				tokens[current-1].Position, nil)
+		}
+
+		index := i
+		isKeyword := current+1 < len(tokens) && tokens[current+1].Type == tokenEQUAL
+
+		if i > 0 && isKeyword != keyword {
+			return nil, current, newParseError(fmt.Sprintf("cannot mix positional and named parameters for %s", funcType),
+				tokens[current].Position, nil)
+		}
+		keyword = isKeyword
+
+		if isKeyword {
+			nameToken := tokens[current]
+
+			index = slices.Index(names, nameToken.Value)
+			if index == -1 {
+				return nil, current, newParseError(
+					fmt.Sprintf("unknown parameter '%s' for %s, expected one of %s", nameToken.Value, funcType, strings.Join(names, ", ")),
+					nameToken.Position, nil)
+			}
+
+			if assigned[index] {
+				return nil, current, newParseError(
+					fmt.Sprintf("duplicate parameter '%s' for %s", nameToken.Value, funcType),
+					nameToken.Position, nil)
+			}
+
+			current += 2 // Skip name and =
+
+			if current >= len(tokens) || tokens[current].Type != tokenVAR {
+				return nil, current, newParseError(fmt.Sprintf("expected value for parameter '%s' of %s", nameToken.Value, funcType),
+					tokens[current-1].Position, nil)
+			}
+		}
+
+		value, err := parseFloat(tokens[current])
+		if err != nil {
+			return nil, current, err
+		}
+
+		values[index] = value
+		assigned[index] = true
+		current++
+	}
+
 	// Expect closing parenthesis
 	if current >= len(tokens) || tokens[current].Type != tokenRPAREN {
-		return nil, current, newParseError("expected ) after TRAPEZOID parameters",
+		return nil, current, newParseError(fmt.Sprintf("expected ) after %s parameters", funcType),
 			tokens[current-1].Position, nil)
 	}
 	current++
 
-	return fuzzy.Trapezoid(x1, x2, x3, x4), current, nil
+	return values, current, nil
 }
 
 // ParseInverted parses an INVERTED(function) membership function
@@ -308,3 +382,66 @@ func ParseInverted(tokens []Token, current int, parse ParseMembershipFunc) (fuzz
 
 	return fuzzy.Inverted(innerFunc), current, nil
 }
+
+// ParseMin parses a MIN(function, function, ...) membership function
+func ParseMin(tokens []Token, current int, parse ParseMembershipFunc) (fuzzy.Membership, int, error) {
+	memberships, current, err := parseMembershipList(tokens, current, parse, tokenMIN)
+	if err != nil {
+		return nil, current, err
+	}
+
+	return fuzzy.Min(memberships...), current, nil
+}
+
+// ParseMax parses a MAX(function, function, ...) membership function
+func ParseMax(tokens []Token, current int, parse ParseMembershipFunc) (fuzzy.Membership, int, error) {
+	memberships, current, err := parseMembershipList(tokens, current, parse, tokenMAX)
+	if err != nil {
+		return nil, current, err
+	}
+
+	return fuzzy.Max(memberships...), current, nil
+}
+
+// parseMembershipList parses a parenthesized, comma separated, list of at least one membership function
+func parseMembershipList(tokens []Token, current int, parse ParseMembershipFunc, funcType string) ([]fuzzy.Membership, int, error) {
+	// Expect open parenthesis
+	if current >= len(tokens) || tokens[current].Type != tokenLPAREN {
+		return nil, current, newParseError(fmt.Sprintf("expected ( after %s", funcType),
+			tokens[current-1].Position, nil)
+	}
+	current++
+
+	var memberships []fuzzy.Membership
+	for {
+		if current >= len(tokens) || tokens[current].Type == tokenRPAREN {
+			return nil, current, newParseError(fmt.Sprintf("expected membership function in %s", funcType),
+				tokens[current-1].Position, nil)
+		}
+
+		// Parse the inner membership function
+		membership, newCurrent, err := parse(tokens, current, parse)
+		if err != nil {
+			return nil, newCurrent, errors.WithStack(err)
+		}
+
+		memberships = append(memberships, membership)
+		current = newCurrent
+
+		if current < len(tokens) && tokens[current].Type == tokenCOMMA {
+			current++ // Skip comma
+			continue
+		}
+
+		break
+	}
+
+	// Expect closing parenthesis
+	if current >= len(tokens) || tokens[current].Type != tokenRPAREN {
+		return nil, current, newParseError(fmt.Sprintf("expected ) after %s functions", funcType),
+			tokens[current-1].Position, nil)
+	}
+	current++
+
+	return memberships, current, nil
+}
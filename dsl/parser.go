@@ -2,6 +2,7 @@ package dsl
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -13,6 +14,10 @@ import (
 type ParseResult struct {
 	Rules     []*fuzzy.Rule
 	Variables []*fuzzy.Variable
+
+	// Defuzzifiers holds the defuzzification functions declared with
+	// DEFUZZIFY, by variable name (see ApplyDefuzzifiers())
+	Defuzzifiers map[string]fuzzy.Defuzzifier
 }
 
 // Parser holds the state during parsing
@@ -20,31 +25,89 @@ type Parser struct {
 	tokens      []Token
 	current     int
 	memberships map[string]MembershipParser
+
+	// references holds the terms referenced by the parsed rules
+	references []termReference
+}
+
+// termReference is a "variable IS term" expression found in a rule
+type termReference struct {
+	Variable string
+	Term     string
+	Pos      Position
 }
 
 // parse processes the tokens and produces rules and variables
 func (p *Parser) parse() (*ParseResult, error) {
 	var rules []*fuzzy.Rule
 	var variables []*fuzzy.Variable
-	var errs []string
+	var errs ParseErrors
+	defuzzifiers := make(map[string]fuzzy.Defuzzifier)
 
 	for p.current < len(p.tokens) {
-		if p.current < len(p.tokens) && p.tokens[p.current].Type == tokenDEFINE {
+		// Parse annotations preceding the next rule or variable definition
+		annotations, err := p.parseAnnotations()
+		if err != nil {
+			errs = append(errs, p.toParseError(err))
+
+			// Skip to the next statement
+			for p.current < len(p.tokens) && p.tokens[p.current].Type != tokenSEMI {
+				p.current++
+			}
+			p.current++
+			continue
+		}
+
+		if p.current < len(p.tokens) && p.tokens[p.current].Type == tokenDEFUZZIFY {
+			// Parse defuzzification declaration
+			defuzzifyToken := p.tokens[p.current]
+			variable, defuzzify, err := p.parseDefuzzify()
+			if err != nil {
+				errs = append(errs, p.toParseError(err))
+
+				// Skip to the next statement
+				for p.current < len(p.tokens) && p.tokens[p.current].Type != tokenSEMI {
+					p.current++
+				}
+				p.current++
+			} else if _, exists := defuzzifiers[variable]; exists {
+				err := newParseError(fmt.Sprintf("defuzzification of variable '%s' is already declared", variable),
+					defuzzifyToken.Position, nil)
+				errs = append(errs, p.toParseError(err))
+			} else {
+				defuzzifiers[variable] = defuzzify
+			}
+		} else if p.current < len(p.tokens) && p.tokens[p.current].Type == tokenDEFINE {
 			// Parse variable definition
+			defineToken := p.tokens[p.current]
 			variable, err := p.parseVariableDefinition()
 			if err != nil {
-				errs = append(errs, err.Error())
+				errs = append(errs, p.toParseError(err))
 			}
-			if variable != nil {
+			if variable != nil && slices.ContainsFunc(variables, func(v *fuzzy.Variable) bool { return v.Name() == variable.Name() }) {
+				err := newParseError(fmt.Sprintf("variable '%s' is already defined", variable.Name()),
+					defineToken.Position, fuzzy.ErrVariableAlreadyExists)
+				errs = append(errs, p.toParseError(err))
+			} else if variable != nil {
+				for key, value := range annotations {
+					variable.WithMetadata(key, value)
+				}
 				variables = append(variables, variable)
 			}
 		} else {
 			// Parse rule
+			references := len(p.references)
 			rule, err := p.parseRule()
 			if err != nil {
-				errs = append(errs, err.Error())
+				errs = append(errs, p.toParseError(err))
 			}
-			if rule != nil {
+			if rule == nil {
+				// Ignore the references of rules which could not be parsed
+				p.references = p.references[:references]
+			} else {
+				for key, value := range annotations {
+					rule.WithMetadata(key, value)
+				}
 				rules = append(rules, rule)
 			}
 		}
@@ -55,22 +118,90 @@ func (p *Parser) parse() (*ParseResult, error) {
 		}
 	}
 
+	// Check the terms referenced by the rules against the variables defined in the same source
+	for _, err := range p.checkTermReferences(variables) {
+		errs = append(errs, p.toParseError(err))
+	}
+
 	// If we encountered any errors, return them all together
 	if len(errs) > 0 {
-		return nil, errors.Errorf("parsing errors: %s", strings.Join(errs, "; "))
+		return nil, errs
 	}
 
 	return &ParseResult{
-		Rules:     rules,
-		Variables: variables,
+		Rules:        rules,
+		Variables:    variables,
+		Defuzzifiers: defuzzifiers,
 	}, nil
 }
 
-// parseFloat parses a string to a float64
-func parseFloat(s string, pos Position) (float64, error) {
+// checkTermReferences returns an error for each term referenced by a rule but not
+// defined by its variable. Variables not defined in the parsed source are ignored.
+func (p *Parser) checkTermReferences(variables []*fuzzy.Variable) []error {
+	indexed := make(map[string]*fuzzy.Variable, len(variables))
+	for _, v := range variables {
+		indexed[v.Name()] = v
+	}
+
+	var errs []error
+	for _, ref := range p.references {
+		variable, exists := indexed[ref.Variable]
+		if !exists {
+			continue
+		}
+
+		if _, err := variable.Term(ref.Term); err != nil {
+			errs = append(errs, newParseError(
+				fmt.Sprintf("term '%s' is not defined by variable '%s'", ref.Term, ref.Variable),
+				ref.Pos, fuzzy.ErrUndefinedTerm))
+		}
+	}
+
+	return errs
+}
+
+// parseFloat parses the number of the token, or returns the value of the constant it names
+func parseFloat(token Token) (float64, error) {
+	if token.constant != nil {
+		return *token.constant, nil
+	}
+
+	val, err := parseNumber(token.Value)
+	if err != nil {
+		if isConstantName(token) {
+			return 0, newParseError(fmt.Sprintf("invalid number: %s (undefined constant)", token.Value), token.Position, nil)
+		}
+
+		return 0, newParseError(fmt.Sprintf("invalid number: %s", token.Value), token.Position, err)
+	}
+	return val, nil
+}
+
+// parseNumber parses a decimal number, possibly in scientific notation (1.5e-3) and
+// with underscores separating digits for readability (10_000)
+func parseNumber(s string) (float64, error) {
+	if strings.Contains(s, "_") {
+		for i := 0; i < len(s); i++ {
+			if s[i] != '_' {
+				continue
+			}
+
+			if i == 0 || i == len(s)-1 || !isDigit(s[i-1]) || !isDigit(s[i+1]) {
+				return 0, errors.Errorf("misplaced digit separator in '%s'", s)
+			}
+		}
+
+		s = strings.ReplaceAll(s, "_", "")
+	}
+
 	val, err := strconv.ParseFloat(s, 64)
 	if err != nil {
-		return 0, newParseError(fmt.Sprintf("invalid number: %s", s), pos, err)
+		return 0, errors.WithStack(err)
 	}
+
 	return val, nil
 }
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
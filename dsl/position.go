@@ -4,11 +4,16 @@ import "fmt"
 
 // Position represents a position in the source text
 type Position struct {
-	Line   int // 1-based line number
-	Column int // 1-based column number
+	Line   int    // 1-based line number
+	Column int    // 1-based column number
+	File   string // Path of the included file (see WithIncludeResolver()), empty for the parsed source
 }
 
 // String returns a string representation of the position
 func (p Position) String() string {
+	if p.File != "" {
+		return fmt.Sprintf("%s: line %d, column %d", p.File, p.Line, p.Column)
+	}
+
 	return fmt.Sprintf("line %d, column %d", p.Line, p.Column)
-}
\ No newline at end of file
+}
@@ -1,7 +1,14 @@
 package dsl
 
 import (
+	"bufio"
+	"fmt"
+	"io"
 	"strings"
+	"unicode"
+
+	"github.com/bornholm/go-fuzzy"
+	"github.com/pkg/errors"
 )
 
 // DSL tokens
@@ -9,136 +16,329 @@ const (
 	tokenIF     = "IF"
 	tokenIS     = "IS"
 	tokenTHEN   = "THEN"
+	tokenELSE   = "ELSE"
 	tokenAND    = "AND"
 	tokenOR     = "OR"
 	tokenNOT    = "NOT"
+	tokenXOR    = "XOR"
+	tokenNAND   = "NAND"
+	tokenNOR    = "NOR"
 	tokenSEMI   = ";"
 	tokenVAR    = "VARIABLE"
 	tokenTERM   = "TERM"
 	tokenLPAREN = "("
 	tokenRPAREN = ")"
 
+	// Tokens for linguistic hedges
+	tokenVERY     = "VERY"
+	tokenSOMEWHAT = "SOMEWHAT"
+
 	// Tokens for variable definitions
 	tokenDEFINE = "DEFINE"
 	tokenCOMMA  = ","
+	tokenWEIGHT = "WEIGHT"
+
+	// Token for rule priorities (PRIORITY integer)
+	tokenPRIORITY = "PRIORITY"
+
+	// Tokens for named membership function parameters
+	tokenEQUAL = "="
+
+	// Tokens for metadata annotations (@key="value")
+	tokenANNOTATION = "ANNOTATION"
+
+	// Token for file inclusions (INCLUDE "path")
+	tokenINCLUDE = "INCLUDE"
+
+	// Token for constant declarations (CONST name = value)
+	tokenCONST = "CONST"
+
+	// Tokens for defuzzification declarations (DEFUZZIFY variable USING method)
+	tokenDEFUZZIFY = "DEFUZZIFY"
+	tokenUSING     = "USING"
+
+	// Tokens for comparison conditions (variable > value)
+	tokenLESS           = "<"
+	tokenLESSOREQUAL    = "<="
+	tokenGREATER        = ">"
+	tokenGREATEROREQUAL = ">="
+	tokenEQUALEQUAL     = "=="
 )
 
+// specialChars are the characters forming a token on their own
+const specialChars = ";(),=<>"
+
+// comparisonOperators maps the comparison tokens to the operators of fuzzy.CompareExpr
+var comparisonOperators = map[string]string{
+	tokenLESS:           fuzzy.OpLess,
+	tokenLESSOREQUAL:    fuzzy.OpLessOrEqual,
+	tokenGREATER:        fuzzy.OpGreater,
+	tokenGREATEROREQUAL: fuzzy.OpGreaterOrEqual,
+	tokenEQUALEQUAL:     fuzzy.OpEqual,
+}
+
 // Token represents a lexical token in the DSL
 type Token struct {
 	Type     string
 	Value    string
 	Position Position // Position in the source text
+
+	quoted bool // Quoted identifier, i.e. "outdoor temperature"
+
+	// constant holds the value of the constant named by the token, if any (see expandConstants())
+	constant *float64
 }
 
 // tokenize breaks down the input string into tokens with position information
-func tokenize(input string) ([]Token, error) {
-	// First, remove all comments while preserving structure
-	cleanedInput := removeComments(input)
+func tokenize(input string, commentStyles []CommentStyle) ([]Token, error) {
+	return tokenizeReader(strings.NewReader(input), commentStyles)
+}
 
+// tokenizeReader breaks down the input into tokens with position information,
+// reading it one line at a time
+func tokenizeReader(r io.Reader, commentStyles []CommentStyle) ([]Token, error) {
 	var tokens []Token
-	var tokenPositions []struct {
-		word string
-		pos  Position
-	}
 
-	// Split input into lines
-	lines := strings.Split(cleanedInput, "\n")
+	reader := bufio.NewReader(r)
+	comments := &commentStripper{styles: commentStyles}
+
+	for lineNum := 1; ; lineNum++ { // 1-based line numbers
+		line, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, errors.Wrapf(err, "could not read line %d", lineNum)
+		}
 
-	// Process each line
-	for lineNum, line := range lines {
-		lineNum++ // 1-based line numbers
-		column := 1
+		// Remove the comments while preserving the columns
+		cleaned := comments.strip(strings.TrimSuffix(line, "\n"))
 
-		// Skip empty lines
-		if strings.TrimSpace(line) == "" {
-			continue
+		lineTokens, parseErr := tokenizeLine(cleaned, lineNum)
+		if parseErr != nil {
+			return nil, parseErr
 		}
 
-		// Prepare line for tokenization
-		// Replace special characters with spaces around them
-		line = strings.ReplaceAll(line, ";", " ; ")
-		line = strings.ReplaceAll(line, "(", " ( ")
-		line = strings.ReplaceAll(line, ")", " ) ")
-		line = strings.ReplaceAll(line, ",", " , ")
+		tokens = append(tokens, lineTokens...)
 
-		// Split line into words
-		words := strings.Fields(line)
+		if err != nil {
+			// End of input
+			break
+		}
+	}
 
-		for _, word := range words {
-			if word == "" {
-				continue
-			}
+	return tokens, nil
+}
 
-			// Find the actual column position in the line
-			// Using a safer approach to avoid out-of-bounds errors
-			wordPos := strings.Index(line, word)
-			if wordPos >= 0 {
-				column = wordPos + 1 // 1-based column indexing
+// tokenizeLine breaks down the given line, without comments, into tokens
+func tokenizeLine(line string, lineNum int) ([]Token, error) {
+	var tokens []Token
+
+	// Split the line into words, special characters being words on their own.
+	// Columns are computed on the original line.
+	wordStart := -1
+
+	addWord := func(start, end int) {
+		tokens = append(tokens, newToken(line[start:end], Position{Line: lineNum, Column: start + 1})) // 1-based column indexing
+	}
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+
+		switch {
+		case c == '"' && wordStart == -1:
+			// Quoted identifier, i.e. "outdoor temperature", forming a single word.
+			// Quoted identifiers are never keywords.
+			end := strings.IndexByte(line[i+1:], '"')
+			if end == -1 {
+				return nil, newParseError("unterminated quoted identifier", Position{Line: lineNum, Column: i + 1}, nil)
 			}
 
-			tokenPositions = append(tokenPositions, struct {
-				word string
-				pos  Position
-			}{
-				word: word,
-				pos:  Position{Line: lineNum, Column: column},
+			end += i + 1
+			tokens = append(tokens, Token{
+				Type:     tokenVAR,
+				Value:    line[i+1 : end],
+				Position: Position{Line: lineNum, Column: i + 1},
+				quoted:   true,
 			})
+			i = end
 
-			// Move column position past this word
-			column += len(word) + 1
-		}
-	}
+		case strings.IndexByte(specialChars, c) != -1:
+			if wordStart != -1 {
+				addWord(wordStart, i)
+				wordStart = -1
+			}
+			// Two-character comparison operators, i.e. "<=" or "=="
+			if (c == '<' || c == '>' || c == '=') && i+1 < len(line) && line[i+1] == '=' {
+				addWord(i, i+2)
+				i++
+				break
+			}
+			addWord(i, i+1)
+
+		case c == ' ' || c == '\t' || c == '\r':
+			if wordStart != -1 {
+				addWord(wordStart, i)
+				wordStart = -1
+			}
 
-	// Second pass: create tokens with positions
-	for _, tp := range tokenPositions {
-		word := tp.word
-		pos := tp.pos
-
-		var tokenType string
-		switch strings.ToUpper(word) {
-		case "IF":
-			tokenType = tokenIF
-		case "IS":
-			tokenType = tokenIS
-		case "THEN":
-			tokenType = tokenTHEN
-		case "AND":
-			tokenType = tokenAND
-		case "OR":
-			tokenType = tokenOR
-		case "NOT":
-			tokenType = tokenNOT
-		case "DEFINE":
-			tokenType = tokenDEFINE
-		case "TERM":
-			tokenType = tokenTERM
-		case "LINEAR":
-			tokenType = tokenLINEAR
-		case "TRIANGULAR":
-			tokenType = tokenTRIANGULAR
-		case "TRAPEZOID":
-			tokenType = tokenTRAPEZOID
-		case "INVERTED":
-			tokenType = tokenINVERTED
-		case "(":
-			tokenType = tokenLPAREN
-		case ")":
-			tokenType = tokenRPAREN
-		case ";":
-			tokenType = tokenSEMI
-		case ",":
-			tokenType = tokenCOMMA
 		default:
-			// If it's not a keyword, it's a variable or term name
-			tokenType = tokenVAR
+			if wordStart == -1 {
+				wordStart = i
+			}
 		}
+	}
 
-		tokens = append(tokens, Token{
-			Type:     tokenType,
-			Value:    word,
-			Position: pos,
-		})
+	if wordStart != -1 {
+		addWord(wordStart, len(line))
 	}
 
 	return tokens, nil
 }
+
+// newToken returns the token of the given unquoted word
+func newToken(word string, pos Position) Token {
+	var tokenType string
+	switch strings.ToUpper(word) {
+	case "IF":
+		tokenType = tokenIF
+	case "IS":
+		tokenType = tokenIS
+	case "THEN":
+		tokenType = tokenTHEN
+	case "ELSE":
+		tokenType = tokenELSE
+	case "AND":
+		tokenType = tokenAND
+	case "OR":
+		tokenType = tokenOR
+	case "NOT":
+		tokenType = tokenNOT
+	case "XOR":
+		tokenType = tokenXOR
+	case "NAND":
+		tokenType = tokenNAND
+	case "NOR":
+		tokenType = tokenNOR
+	case "VERY":
+		tokenType = tokenVERY
+	case "SOMEWHAT":
+		tokenType = tokenSOMEWHAT
+	case "DEFINE":
+		tokenType = tokenDEFINE
+	case "TERM":
+		tokenType = tokenTERM
+	case "WEIGHT":
+		tokenType = tokenWEIGHT
+	case "PRIORITY":
+		tokenType = tokenPRIORITY
+	case "INCLUDE":
+		tokenType = tokenINCLUDE
+	case "CONST":
+		tokenType = tokenCONST
+	case "DEFUZZIFY":
+		tokenType = tokenDEFUZZIFY
+	case "USING":
+		tokenType = tokenUSING
+	case "LINEAR":
+		tokenType = tokenLINEAR
+	case "TRIANGULAR":
+		tokenType = tokenTRIANGULAR
+	case "TRAPEZOID":
+		tokenType = tokenTRAPEZOID
+	case "INVERTED":
+		tokenType = tokenINVERTED
+	case "SIGMOID":
+		tokenType = tokenSIGMOID
+	case "RECTANGULAR":
+		tokenType = tokenRECTANGULAR
+	case "BETWEEN":
+		tokenType = tokenBETWEEN
+	case "SCURVE":
+		tokenType = tokenSCURVE
+	case "ZCURVE":
+		tokenType = tokenZCURVE
+	case "MIN":
+		tokenType = tokenMIN
+	case "MAX":
+		tokenType = tokenMAX
+	case "(":
+		tokenType = tokenLPAREN
+	case ")":
+		tokenType = tokenRPAREN
+	case ";":
+		tokenType = tokenSEMI
+	case ",":
+		tokenType = tokenCOMMA
+	case "=":
+		tokenType = tokenEQUAL
+	case "<":
+		tokenType = tokenLESS
+	case "<=":
+		tokenType = tokenLESSOREQUAL
+	case ">":
+		tokenType = tokenGREATER
+	case ">=":
+		tokenType = tokenGREATEROREQUAL
+	case "==":
+		tokenType = tokenEQUALEQUAL
+	default:
+		if strings.HasPrefix(word, "@") {
+			tokenType = tokenANNOTATION
+			break
+		}
+
+		// If it's not a keyword, it's a variable or term name
+		tokenType = tokenVAR
+	}
+
+	return Token{
+		Type:     tokenType,
+		Value:    word,
+		Position: pos,
+	}
+}
+
+// isReservedWord returns true if the token is a keyword that could have been
+// intended as an identifier (keywords are matched case-insensitively)
+func isReservedWord(token Token) bool {
+	if token.Type == tokenVAR || token.Value == "" {
+		return false
+	}
+
+	return unicode.IsLetter(rune(token.Value[0]))
+}
+
+// isNumericLiteral returns true if the unquoted token is a number written with
+// digit separators, i.e. 10_000, which cannot be used as an identifier
+func isNumericLiteral(token Token) bool {
+	if token.Type != tokenVAR || token.quoted || !strings.Contains(token.Value, "_") {
+		return false
+	}
+
+	_, err := parseNumber(token.Value)
+
+	return err == nil
+}
+
+// identifierError returns a parse error if the token at the given index is a
+// reserved word or a numeric literal used where an identifier is expected, nil otherwise
+func identifierError(tokens []Token, current int, kind string) *ParseError {
+	if current >= len(tokens) {
+		return nil
+	}
+
+	token := tokens[current]
+
+	switch {
+	case isReservedWord(token):
+		return newParseError(
+			fmt.Sprintf("'%s' is a reserved word and cannot be used as a %s name", token.Value, kind),
+			token.Position, nil,
+		)
+	case isNumericLiteral(token):
+		return newParseError(
+			fmt.Sprintf("'%s' is a number and cannot be used as a %s name", token.Value, kind),
+			token.Position, nil,
+		)
+	default:
+		return nil
+	}
+}
@@ -1,6 +1,10 @@
 package dsl
 
 import (
+	"fmt"
+	"math"
+	"slices"
+
 	"github.com/bornholm/go-fuzzy"
 )
 
@@ -11,6 +15,10 @@ func (p *Parser) parseVariableDefinition() (*fuzzy.Variable, error) {
 	p.current++
 
 	// Get variable name
+	if err := identifierError(p.tokens, p.current, "variable"); err != nil {
+		return nil, err
+	}
+
 	if p.current >= len(p.tokens) || p.tokens[p.current].Type != tokenVAR {
 		return nil, newParseError("expected variable name after DEFINE",
 			defineToken.Position, nil)
@@ -34,10 +42,20 @@ func (p *Parser) parseVariableDefinition() (*fuzzy.Variable, error) {
 				p.tokens[p.current].Position, nil)
 		}
 
+		termToken := p.tokens[p.current]
+
 		term, err := p.parseTermDefinition()
 		if err != nil {
 			return nil, err
 		}
+
+		if slices.ContainsFunc(terms, func(t *fuzzy.Term) bool { return t.Name() == term.Name() }) {
+			return nil, newParseError(
+				fmt.Sprintf("term '%s' is already defined by variable '%s'", term.Name(), variableName),
+				termToken.Position, fuzzy.ErrTermAlreadyExists,
+			)
+		}
+
 		terms = append(terms, term)
 
 		// After a term definition, expect a comma or closing parenthesis
@@ -61,22 +79,37 @@ func (p *Parser) parseVariableDefinition() (*fuzzy.Variable, error) {
 	p.current++
 
 	// Create variable with parsed terms
-	return fuzzy.NewVariable(variableName, terms...), nil
+	variable, err := fuzzy.NewVariableChecked(variableName, terms...)
+	if err != nil {
+		return nil, newParseError(err.Error(), defineToken.Position, err)
+	}
+
+	return variable, nil
 }
 
-// parseTermDefinition parses a term definition (TERM name FUNCTION_TYPE (params))
+// parseTermDefinition parses a term definition (TERM name [WEIGHT number] FUNCTION_TYPE (params))
 func (p *Parser) parseTermDefinition() (*fuzzy.Term, error) {
 	// Skip TERM token
 	termToken := p.tokens[p.current]
 	p.current++
 
 	// Get term name
+	if err := identifierError(p.tokens, p.current, "term"); err != nil {
+		return nil, err
+	}
+
 	if p.current >= len(p.tokens) || p.tokens[p.current].Type != tokenVAR {
 		return nil, newParseError("expected term name", termToken.Position, nil)
 	}
 	termName := p.tokens[p.current].Value
 	p.current++
 
+	// Parse the optional term weight (WEIGHT number)
+	weight, err := p.parseWeight()
+	if err != nil {
+		return nil, err
+	}
+
 	// Next token should be the membership function type
 	if p.current >= len(p.tokens) {
 		return nil, newParseError("expected membership function type",
@@ -89,5 +122,61 @@ func (p *Parser) parseTermDefinition() (*fuzzy.Term, error) {
 		return nil, err
 	}
 
-	return fuzzy.NewTerm(termName, membership), nil
+	return fuzzy.NewTerm(termName, membership).WithWeight(weight), nil
+}
+
+// parseWeight parses an optional WEIGHT number clause, defaulting to 1
+func (p *Parser) parseWeight() (float64, error) {
+	if p.current >= len(p.tokens) || p.tokens[p.current].Type != tokenWEIGHT {
+		return 1, nil
+	}
+
+	weightToken := p.tokens[p.current]
+	p.current++
+
+	if p.current >= len(p.tokens) {
+		return 0, newParseError("expected number after WEIGHT", weightToken.Position, nil)
+	}
+
+	value, err := parseFloat(p.tokens[p.current])
+	if err != nil {
+		return 0, err
+	}
+
+	if value < 0 {
+		return 0, newParseError(fmt.Sprintf("invalid weight %v, expected a positive number", value),
+			p.tokens[p.current].Position, nil)
+	}
+
+	p.current++
+
+	return value, nil
+}
+
+// parsePriority parses the optional PRIORITY integer of a rule, 0 if absent
+func (p *Parser) parsePriority() (int, error) {
+	if p.current >= len(p.tokens) || p.tokens[p.current].Type != tokenPRIORITY {
+		return 0, nil
+	}
+
+	priorityToken := p.tokens[p.current]
+	p.current++
+
+	if p.current >= len(p.tokens) {
+		return 0, newParseError("expected integer after PRIORITY", priorityToken.Position, nil)
+	}
+
+	value, err := parseFloat(p.tokens[p.current])
+	if err != nil {
+		return 0, err
+	}
+
+	if value != math.Trunc(value) || math.Abs(value) > math.MaxInt32 {
+		return 0, newParseError(fmt.Sprintf("invalid priority %v, expected an integer", value),
+			p.tokens[p.current].Position, nil)
+	}
+
+	p.current++
+
+	return int(value), nil
 }
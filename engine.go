@@ -1,86 +1,798 @@
 package fuzzy
 
-import "github.com/pkg/errors"
+import (
+	"context"
+	"maps"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+)
 
 type Values map[string]float64
 
-type DefuzzifyFunc func(m Membership, min, max float64) float64
+const defaultCentroidSteps = 1000
 
 type Engine struct {
-	rules     []*Rule
-	variables []*Variable
-	defuzzify DefuzzifyFunc
+	rules       []*Rule
+	variables   []*Variable
+	defuzzify   Defuzzifier
+	implication ImplicationFunc
+	aggregation AggregationFunc
+	norms       Norms
+
+	midpointDefaults bool
+	normalizedOutput bool
+	strictInputs     bool
+	clampInputs      bool
+
+	// inputDefaults holds the values of the inputs missing from Infer()
+	inputDefaults Values
+
+	// variableIndex caches the variables indexed by name, shared by the
+	// inference contexts, or the error reported if their names collide
+	variableIndex    map[string]*Variable
+	variableIndexErr error
+
+	type2Variables []*Type2Variable
+
+	latches map[string]LatchFunc
+
+	conflictResolution ConflictResolution
+
+	// membershipCache memoizes the membership degrees of the IS expressions
+	// during each inference, see WithMembershipCache()
+	membershipCache bool
+
+	// parallelism is the number of goroutines evaluating the premises, see WithParallelism()
+	parallelism int
+
+	// variableDefuzzify holds the defuzzification functions overriding the
+	// engine one for specific output variables
+	variableDefuzzify map[string]Defuzzifier
+}
+
+// LatchFunc reports whether the results of an output variable are conclusive
+// enough to stop the inference
+type LatchFunc func(results map[string]Result) bool
+
+// LatchAbove returns a latch triggering as soon as the truth degree of the given
+// term reaches the threshold
+func LatchAbove(term string, threshold float64) LatchFunc {
+	return func(results map[string]Result) bool {
+		result, exists := results[term]
+		return exists && result.TruthDegree() >= threshold
+	}
 }
 
+// Infer runs the rules of the engine on the given values.
+// The rules decay is ignored, see InferAt().
 func (e *Engine) Infer(values Values) (Results, error) {
-	ctx := NewContext(e.variables, values)
+	return e.InferContext(context.Background(), values)
+}
 
-	for _, r := range e.rules {
-		outputVariableName := r.conclusion.Variable()
-		outputTermName := r.conclusion.Term()
+// InferContext runs the rules of the engine on the given values like Infer(), checking
+// the context between the rule evaluations: the inference stops as soon as the context
+// is done, returning its error, i.e. context.Canceled.
+func (e *Engine) InferContext(ctx context.Context, values Values) (Results, error) {
+	return e.infer(ctx, values, time.Time{}, nil)
+}
+
+// InferAt runs the rules of the engine on the given values, the firing strength of
+// each rule being scaled by its decay factor at the given time (see Rule.DecayFactor()).
+// Without any rule decay configured, InferAt behaves like Infer.
+func (e *Engine) InferAt(values Values, now time.Time) (Results, error) {
+	return e.infer(context.Background(), values, now, nil)
+}
+
+// InferBatch runs the rules of the engine on each of the given input sets, the results
+// at index i being the ones of inputs[i]. Each input set gets its own results, only the
+// immutable index of the variables being shared between them. It stops at the first error.
+func (e *Engine) InferBatch(inputs []Values) ([]Results, error) {
+	batch := make([]Results, 0, len(inputs))
+
+	for i, values := range inputs {
+		results, err := e.infer(context.Background(), values, time.Time{}, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "input set #%d", i)
+		}
+
+		batch = append(batch, results)
+	}
+
+	return batch, nil
+}
+
+// infer runs the rules on the given values, recording the evaluated rules in trace if not nil.
+// It stops with the error of runCtx as soon as it is done.
+func (e *Engine) infer(runCtx context.Context, values Values, now time.Time, trace *Trace) (Results, error) {
+	if len(e.inputDefaults) > 0 {
+		values = e.withInputDefaults(values)
+	}
+
+	if e.midpointDefaults {
+		values = e.withMidpointDefaults(values)
+	}
+
+	ctx, err := e.newContext(values)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// In parallel mode, the premises are evaluated upfront and concurrently,
+	// the results being then added serially
+	var (
+		truthDegrees []float64
+		premiseErrs  []error
+	)
+	if e.parallelism > 1 {
+		truthDegrees, premiseErrs, err = e.evaluatePremises(runCtx, ctx)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	premiseValue := func(i int) (float64, error) {
+		if truthDegrees != nil {
+			return truthDegrees[i], premiseErrs[i]
+		}
+
+		return e.rules[i].premise.Value(ctx)
+	}
+
+	var priorities map[string]int
+	if e.conflictResolution == HighestPriorityWins {
+		priorities, err = e.firingPriorities(runCtx, premiseValue, now)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	for i, r := range e.rules {
+		if err := runCtx.Err(); err != nil {
+			return nil, errors.WithStack(err)
+		}
 
-		outputVariable, err := ctx.Variable(outputVariableName)
+		conclusions, alternatives := r.conclusions, r.alternatives
+		if priorities != nil {
+			conclusions = winningConclusions(r, conclusions, priorities)
+			alternatives = winningConclusions(r, alternatives, priorities)
+		}
+
+		outputTerms, err := conclusionTerms(ctx, conclusions)
 		if err != nil {
 			return nil, errors.WithStack(err)
 		}
 
-		outputTerm, err := outputVariable.Term(outputTermName)
+		alternativeTerms, err := conclusionTerms(ctx, alternatives)
 		if err != nil {
 			return nil, errors.WithStack(err)
 		}
 
-		truthDegree, err := r.premise.Value(ctx)
+		truthDegree, err := premiseValue(i)
 		if err != nil {
 			return nil, errors.WithStack(err)
 		}
 
-		ctx.AddResult(outputVariableName, outputTerm, truthDegree)
+		strength := r.weight
+
+		if !now.IsZero() {
+			strength *= r.DecayFactor(now)
+		}
+
+		var ruleTrace *RuleTrace
+		if trace != nil {
+			*trace = append(*trace, RuleTrace{
+				Index:       i,
+				Rule:        FormatRule(r),
+				TruthDegree: truthDegree,
+				Strength:    strength,
+				Conclusions: make([]ConclusionTrace, 0, len(conclusions)),
+			})
+			ruleTrace = &(*trace)[len(*trace)-1]
+		}
+
+		if e.conclude(ctx, conclusions, outputTerms, truthDegree*strength, ruleTrace, false) {
+			break
+		}
+
+		// The ELSE conclusions only contribute when the premise is not fully true
+		if complement := 1 - truthDegree; complement > 0 && e.conclude(ctx, alternatives, alternativeTerms, complement*strength, ruleTrace, true) {
+			break
+		}
 	}
 
 	return ctx.Results(), nil
 }
 
+// conclude adds the results of the conclusions for the given truth degree, recording
+// them in the rule trace if not nil, and returns true if a latch triggered
+func (e *Engine) conclude(ctx *Context, conclusions []*IsExpr, terms []*Term, truthDegree float64, trace *RuleTrace, alternative bool) bool {
+	for i, c := range conclusions {
+		if value, crisp := c.CrispValue(); crisp {
+			ctx.AddCrispResult(c.Variable(), value, truthDegree)
+		} else {
+			ctx.AddResult(c.Variable(), terms[i], truthDegree)
+		}
+
+		if trace != nil {
+			trace.Conclusions = append(trace.Conclusions, ConclusionTrace{
+				Variable:    c.Variable(),
+				Term:        c.Term(),
+				TruthDegree: truthDegree,
+				Alternative: alternative,
+			})
+		}
+
+		if latch, exists := e.latches[c.Variable()]; exists && latch(ctx.Result(c.Variable())) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// conclusionTerms returns the output terms of the conclusions, nil for crisp conclusions
+func conclusionTerms(ctx *Context, conclusions []*IsExpr) ([]*Term, error) {
+	terms := make([]*Term, len(conclusions))
+
+	for i, c := range conclusions {
+		if _, crisp := c.CrispValue(); crisp {
+			continue
+		}
+
+		outputVariable, err := ctx.Variable(c.Variable())
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		terms[i], err = outputVariable.Term(c.Term())
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	return terms, nil
+}
+
+// InferNormalized runs the inference with inputs expressed as fractions of their variable's universe,
+// each fraction in [0, 1] being mapped to min + fraction*(max-min) before inference.
+func (e *Engine) InferNormalized(fractions map[string]float64) (Results, error) {
+	values := make(Values, len(fractions))
+
+	for name, fraction := range fractions {
+		variable, err := e.variable(name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "variable '%s'", name)
+		}
+
+		if fraction < 0 || fraction > 1 {
+			return nil, errors.Errorf("invalid fraction '%v' for variable '%s', expected a value in [0, 1]", fraction, name)
+		}
+
+		min, max := variable.UniverseMin(), variable.UniverseMax()
+		values[name] = min + fraction*(max-min)
+	}
+
+	return e.Infer(values)
+}
+
+// Defuzzify computes the crisp output value of the given variable.
+//
+// When the results of the variable include crisp conclusions (see Rule.ThenValue()), the
+// output is the weighted average of the concluded values, each weighted by its truth degree.
+// Term conclusions on the same variable then count as a single value, the defuzzification
+// of their implied membership, weighted by their truth degree (and term weight).
 func (e *Engine) Defuzzify(variableName string, results Results) (float64, error) {
-	var targetVariable *Variable
-	for _, v := range e.variables {
-		if v.Name() == variableName {
-			targetVariable = v
-			break
+	variableResults := results[variableName]
+
+	// The variable is optional as long as all of its conclusions are crisp
+	targetVariable, err := e.variable(variableName)
+	if err != nil && !hasCrispResults(variableResults) {
+		return 0, errors.WithStack(err)
+	}
+
+	value, err := defuzzifyResults(targetVariable, variableResults, e.defuzzifier(variableName), e.aggregation, e.normalizedOutput)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	return value, nil
+}
+
+// BestTermValue returns the representative value of the best term of the variable (see
+// Results.Best()), i.e. the x maximizing its membership: the apex of a triangle, the
+// midpoint of the plateau of a trapezoid. The value of a crisp conclusion is returned as is.
+// It returns false if no term of the variable was activated.
+func (e *Engine) BestTermValue(variableName string, results Results) (float64, bool, error) {
+	best, ok := results.Best(variableName)
+	if !ok {
+		if _, err := e.variable(variableName); err != nil && !hasCrispResults(results[variableName]) {
+			return 0, false, errors.WithStack(err)
 		}
+
+		return 0, false, nil
+	}
+
+	if value, crisp := best.Value(); crisp {
+		return value, true, nil
 	}
 
-	if targetVariable == nil {
-		return 0, errors.WithStack(ErrUndefinedVariable)
+	variable, err := e.variable(variableName)
+	if err != nil {
+		return 0, false, errors.WithStack(err)
 	}
 
-	variableResults, ok := results[variableName]
-	if !ok || len(variableResults) == 0 {
-		return (targetVariable.UniverseMin() + targetVariable.UniverseMax()) / 2, nil
+	term, err := variable.Term(best.Term())
+	if err != nil {
+		return 0, false, errors.WithStack(err)
 	}
 
-	finalMembership := Max()
+	peak := MeanOfMaximum(defaultCentroidSteps).Defuzzify(term.Membership(), variable.UniverseMin(), variable.UniverseMax())
+
+	return peak, true, nil
+}
+
+// defuzzifyResults computes the crisp output value of the variable from its results,
+// see Engine.Defuzzify(). The variable can be nil if all of its results are crisp.
+// If normalized, the aggregated membership is scaled to a peak of 1 before being defuzzified.
+func defuzzifyResults(variable *Variable, variableResults map[string]Result, defuzzify Defuzzifier, aggregation AggregationFunc, normalized bool) (float64, error) {
+	if hasCrispResults(variableResults) {
+		return defuzzifyWeightedAverage(variable, variableResults, defuzzify)
+	}
+
+	if len(variableResults) == 0 {
+		return (variable.UniverseMin() + variable.UniverseMax()) / 2, nil
+	}
+
+	finalMembership := aggregate(variable, variableResults, aggregation)
+
+	if normalized {
+		finalMembership = normalize(finalMembership, variable.UniverseMin(), variable.UniverseMax())
+	}
+
+	return defuzzify.Defuzzify(finalMembership, variable.UniverseMin(), variable.UniverseMax()), nil
+}
+
+// defuzzifyWeightedAverage computes the Takagi-Sugeno output of the variable, the
+// variable being optional (nil) as long as all of its conclusions are crisp. When no rule
+// fired, the midpoint of the variable universe (or of the concluded values) is returned.
+func defuzzifyWeightedAverage(variable *Variable, variableResults map[string]Result, defuzzify Defuzzifier) (float64, error) {
+	var (
+		weightedSum float64
+		totalWeight float64
+		min         = math.Inf(1)
+		max         = math.Inf(-1)
+	)
+
 	for _, res := range variableResults {
-		finalMembership.memberships = append(finalMembership.memberships, res.Membership())
+		value, crisp := res.Value()
+		weight := res.TruthDegree()
+
+		if !crisp {
+			if variable == nil {
+				return 0, errors.WithStack(ErrUndefinedVariable)
+			}
+
+			term, err := variable.Term(res.Term())
+			if err != nil {
+				return 0, errors.WithStack(err)
+			}
+
+			value = defuzzify.Defuzzify(res.Membership(), variable.UniverseMin(), variable.UniverseMax())
+			weight *= term.Weight()
+		}
+
+		weightedSum += weight * value
+		totalWeight += weight
+		min, max = math.Min(min, value), math.Max(max, value)
 	}
 
-	return e.defuzzify(finalMembership, targetVariable.UniverseMin(), targetVariable.UniverseMax()), nil
+	if totalWeight > 0 {
+		return weightedSum / totalWeight, nil
+	}
+
+	if variable != nil {
+		min, max = variable.UniverseMin(), variable.UniverseMax()
+	}
+
+	return (min + max) / 2, nil
 }
 
+// normalize scales the membership so that its peak over [min, max] is 1,
+// the membership being returned as is if it is zero everywhere
+func normalize(m Membership, min, max float64) Membership {
+	if math.IsInf(min, 0) || math.IsInf(max, 0) {
+		return m
+	}
+
+	step := (max - min) / defaultCentroidSteps
+
+	peak := 0.0
+	for i := 0; i <= defaultCentroidSteps; i++ {
+		peak = math.Max(peak, m.Value(sampleAt(min, max, step, i, defaultCentroidSteps)))
+	}
+
+	if peak == 0 || peak == 1 {
+		return m
+	}
+
+	return Scale(m, 1/peak)
+}
+
+func hasCrispResults(variableResults map[string]Result) bool {
+	for _, res := range variableResults {
+		if _, crisp := res.Value(); crisp {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *Engine) variable(name string) (*Variable, error) {
+	for _, v := range e.variables {
+		if v.Name() == name {
+			return v, nil
+		}
+	}
+
+	return nil, errors.WithStack(ErrUndefinedVariable)
+}
+
+// aggregate combines the conclusions on the variable's terms, each one being
+// scaled by the weight of its term
+func (e *Engine) aggregate(variable *Variable, variableResults map[string]Result) Membership {
+	return aggregate(variable, variableResults, e.aggregation)
+}
+
+func aggregate(variable *Variable, variableResults map[string]Result, aggregation AggregationFunc) Membership {
+	memberships := make([]Membership, 0, len(variableResults))
+	for _, res := range variableResults {
+		membership := res.Membership()
+
+		if term, err := variable.Term(res.Term()); err == nil && term.Weight() != 1 {
+			membership = Scale(membership, term.Weight())
+		}
+
+		memberships = append(memberships, membership)
+	}
+
+	return aggregation(memberships...)
+}
+
+// withInputDefaults returns a copy of the values completed with the input defaults
+// of the engine, the given values taking precedence
+func (e *Engine) withInputDefaults(values Values) Values {
+	defaulted := maps.Clone(e.inputDefaults)
+	maps.Copy(defaulted, values)
+	return defaulted
+}
+
+// withMidpointDefaults returns a copy of the values where each missing variable
+// used in a rule premise defaults to the midpoint of its universe
+func (e *Engine) withMidpointDefaults(values Values) Values {
+	defaulted := maps.Clone(values)
+	if defaulted == nil {
+		defaulted = make(Values)
+	}
+
+	for _, r := range e.rules {
+		for _, name := range premiseVariables(r.premise) {
+			if _, exists := defaulted[name]; exists {
+				continue
+			}
+
+			variable, err := e.variable(name)
+			if err != nil {
+				continue
+			}
+
+			defaulted[name] = (variable.UniverseMin() + variable.UniverseMax()) / 2
+		}
+	}
+
+	return defaulted
+}
+
+func (e *Engine) newContext(values Values) (*Context, error) {
+	if e.variableIndexErr != nil {
+		return nil, errors.WithStack(e.variableIndexErr)
+	}
+
+	if e.strictInputs {
+		if err := e.checkInputs(values); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	ctx := newContext(e.variableIndex, values)
+	ctx.implication = e.implication
+	ctx.aggregation = e.aggregation
+	ctx.norms = e.norms
+	ctx.clampInputs = e.clampInputs
+
+	if e.membershipCache {
+		ctx.memberships = make(map[[2]string]float64)
+	}
+
+	return ctx, nil
+}
+
+// checkInputs returns an error wrapping ErrValueOutOfUniverse for the first input,
+// in name order, falling outside of the universe of its variable. Inputs of unknown
+// variables are ignored.
+func (e *Engine) checkInputs(values Values) error {
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		variable, exists := e.variableIndex[name]
+		if !exists {
+			continue
+		}
+
+		value, min, max := values[name], variable.UniverseMin(), variable.UniverseMax()
+		if !(value >= min && value <= max) {
+			return errors.Wrapf(ErrValueOutOfUniverse, "variable '%s': value '%v' outside of [%v, %v]", name, value, min, max)
+		}
+	}
+
+	return nil
+}
+
+// Variables replaces the variables of the engine, see AddVariables() to add variables
 func (e *Engine) Variables(variables ...*Variable) *Engine {
 	e.variables = variables
+	e.indexVariables()
 	return e
 }
 
+// indexVariables refreshes the cached index of the engine variables
+func (e *Engine) indexVariables() {
+	e.variableIndex, e.variableIndexErr = indexVariables(e.variables)
+}
+
+// AddVariables adds the given variables to the ones of the engine, i.e. to merge the
+// variables of several DSL sources. It returns an error wrapping ErrVariableAlreadyExists,
+// without adding any variable, if a variable name is already in use.
+func (e *Engine) AddVariables(variables ...*Variable) error {
+	names := make(map[string]struct{}, len(e.variables)+len(variables))
+	for _, v := range e.variables {
+		names[v.Name()] = struct{}{}
+	}
+
+	for _, v := range variables {
+		if _, exists := names[v.Name()]; exists {
+			return errors.Wrapf(ErrVariableAlreadyExists, "variable '%s'", v.Name())
+		}
+
+		names[v.Name()] = struct{}{}
+	}
+
+	e.variables = append(e.variables, variables...)
+	e.indexVariables()
+
+	return nil
+}
+
+// Rules replaces the rules of the engine, see AddRules() to add rules
 func (e *Engine) Rules(rules ...*Rule) *Engine {
 	e.rules = rules
 	return e
 }
 
+// AddRules appends the given rules to the ones of the engine
+func (e *Engine) AddRules(rules ...*Rule) *Engine {
+	e.rules = append(e.rules, rules...)
+	return e
+}
+
+// WithDefuzzify sets the defuzzification function of the engine, reported as
+// "custom" by DefuzzifierName(). See WithDefuzzifier().
+func (e *Engine) WithDefuzzify(defuzzify DefuzzifyFunc) *Engine {
+	return e.WithDefuzzifier(defuzzify)
+}
+
+// WithDefuzzifier sets the defuzzification function of the engine, i.e. a
+// named one created with NamedCentroid() or NamedDefuzzify(). A nil defuzzifier
+// defaults to the centroid.
+func (e *Engine) WithDefuzzifier(defuzzify Defuzzifier) *Engine {
+	if fn, ok := defuzzify.(DefuzzifyFunc); defuzzify == nil || (ok && fn == nil) {
+		defuzzify = defaultDefuzzifier()
+	}
+
+	e.defuzzify = defuzzify
+
+	return e
+}
+
+// SetDefuzzify overrides the defuzzification function of the engine for the given
+// output variable, i.e. to defuzzify an output with MeanOfMaximum() and the others with
+// the centroid. A nil function removes the override.
+func (e *Engine) SetDefuzzify(variableName string, defuzzify Defuzzifier) *Engine {
+	if fn, ok := defuzzify.(DefuzzifyFunc); defuzzify == nil || (ok && fn == nil) {
+		delete(e.variableDefuzzify, variableName)
+		return e
+	}
+
+	if e.variableDefuzzify == nil {
+		e.variableDefuzzify = make(map[string]Defuzzifier)
+	}
+
+	e.variableDefuzzify[variableName] = defuzzify
+
+	return e
+}
+
+// defuzzifier returns the defuzzification function of the given output variable,
+// the engine one if it is not overridden
+func (e *Engine) defuzzifier(variableName string) Defuzzifier {
+	if defuzzify, exists := e.variableDefuzzify[variableName]; exists {
+		return defuzzify
+	}
+
+	return e.defuzzify
+}
+
+// DefuzzifierName returns the name of the engine defuzzification function,
+// "centroid" for the default one and "custom" for bare functions, i.e. the ones
+// given to NewEngine(). The overrides of SetDefuzzify() are not reported.
+func (e *Engine) DefuzzifierName() string {
+	return e.defuzzify.Name()
+}
+
+// WithImplication sets the implication function applied to the rules conclusions.
+// Defaults to MinImplication.
+func (e *Engine) WithImplication(implication ImplicationFunc) *Engine {
+	e.implication = implication
+	return e
+}
+
+// WithAggregation sets the aggregation function combining the rules conclusions.
+// Defaults to MaxAggregation.
+func (e *Engine) WithAggregation(aggregation AggregationFunc) *Engine {
+	e.aggregation = aggregation
+	return e
+}
+
+// WithLatch stops the inference as soon as the latch reports the results of the
+// output variable as conclusive, i.e. once a critical rule fired. The remaining
+// rules are not evaluated, so the results only differ when the latch triggers.
+func (e *Engine) WithLatch(output string, latch LatchFunc) *Engine {
+	if e.latches == nil {
+		e.latches = make(map[string]LatchFunc)
+	}
+
+	e.latches[output] = latch
+
+	return e
+}
+
+// WithInputDefaults sets the values of the input variables missing from the values
+// given to Infer(), i.e. for optional sensors. Values explicitly given to Infer() always
+// take precedence, and the defaults take precedence over WithMidpointDefaults().
+// A variable with neither an input nor a default still fails the inference with an
+// error wrapping ErrValueNotFound. The defaults are copied.
+func (e *Engine) WithInputDefaults(defaults Values) *Engine {
+	e.inputDefaults = maps.Clone(defaults)
+	return e
+}
+
+// WithMidpointDefaults makes the inference default any missing input variable,
+// i.e. used in a rule premise, to the midpoint of its universe.
+// Values explicitly given to Infer() always take precedence.
+func (e *Engine) WithMidpointDefaults(enabled bool) *Engine {
+	e.midpointDefaults = enabled
+	return e
+}
+
+// WithStrictInputs makes the inference fail with an error wrapping ErrValueOutOfUniverse
+// when an input value falls outside of the universe of its variable, instead of evaluating
+// the memberships at that point. Defaults to false.
+func (e *Engine) WithStrictInputs(enabled bool) *Engine {
+	e.strictInputs = enabled
+	return e
+}
+
+// WithMembershipCache memoizes the membership degrees of the IS expressions during each
+// inference, so that a term referenced by many rules is only evaluated once per inference,
+// the inputs being fixed for its duration. Disable it for custom memberships whose value
+// is not determined by the input alone. Defaults to true.
+func (e *Engine) WithMembershipCache(enabled bool) *Engine {
+	e.membershipCache = enabled
+	return e
+}
+
+// WithClampInputs clamps the input values to the universe of their variable, i.e. for
+// sensors slightly exceeding their calibrated range: an input above the universe max
+// evaluates like the max itself. Memberships already saturating at the bounds of the
+// universe, like Linear(), are unaffected. WithStrictInputs() takes precedence, out of
+// universe inputs being rejected before being clamped. Defaults to false.
+func (e *Engine) WithClampInputs(enabled bool) *Engine {
+	e.clampInputs = enabled
+	return e
+}
+
+// WithNormalizedOutput scales the aggregated membership of the output variables to a
+// peak of 1 before defuzzifying it, so that weakly fired rules do not leave a membership
+// hugging the zero floor, where MeanOfMaximum() cannot tell the maximum apart from the
+// unactivated parts of the universe. Scaling does not move the centroid of the aggregated
+// shape, but defuzzifiers comparing membership degrees to absolute levels, like the
+// tolerance of MeanOfMaximum(), may give different results. Crisp conclusions (see Rule.ThenValue()) are unaffected.
+func (e *Engine) WithNormalizedOutput(enabled bool) *Engine {
+	e.normalizedOutput = enabled
+	return e
+}
+
+// WithNorms sets the operators used by the AND and OR expressions of the rules premises.
+// Defaults to MinMax, which also replaces a nil TNorm (minimum) or SNorm (maximum).
+func (e *Engine) WithNorms(norms Norms) *Engine {
+	if norms.TNorm == nil && norms.SNorm == nil {
+		norms = MinMax
+	}
+
+	if norms.TNorm == nil {
+		norms.TNorm = MinMax.TNorm
+	}
+
+	if norms.SNorm == nil {
+		norms.SNorm = MinMax.SNorm
+	}
+
+	e.norms = norms
+	return e
+}
+
+// With applies the given options to the engine
+func (e *Engine) With(opts ...Option) *Engine {
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// NewEngine creates an engine defuzzifying its outputs with the given function,
+// the centroid if nil. Bare functions are reported as "custom": see NewEngineWith()
+// for named defuzzifiers, i.e. NewEngineWith(NamedCentroid(100)).
 func NewEngine(defuzzify DefuzzifyFunc) *Engine {
-	if defuzzify == nil {
-		defuzzify = Centroid(1000)
+	return NewEngineWith(defuzzify)
+}
+
+// NewEngineWith creates an engine defuzzifying its outputs with the given
+// Defuzzifier, the centroid if nil
+func NewEngineWith(defuzzify Defuzzifier) *Engine {
+	engine := &Engine{
+		implication: MinImplication,
+		aggregation: MaxAggregation,
+		norms:       MinMax,
+
+		membershipCache: true,
 	}
-	return &Engine{
-		defuzzify: defuzzify,
+
+	return engine.WithDefuzzifier(defuzzify)
+}
+
+// RequiredInputs returns the sorted names of the variables referenced by the premises of
+// the rules, i.e. the inputs to give to Infer(). Variables only used in conclusions are
+// not included.
+func (e *Engine) RequiredInputs() []string {
+	inputs := make([]string, 0)
+	for _, r := range e.rules {
+		inputs = append(inputs, premiseVariables(r.premise)...)
 	}
+
+	slices.Sort(inputs)
+
+	return slices.Compact(inputs)
+}
+
+// RulesForOutput returns the rules concluding on the given variable, in declaration order
+func (e *Engine) RulesForOutput(variable string) []*Rule {
+	rules := make([]*Rule, 0)
+	for _, r := range e.rules {
+		if r.concludes(variable) {
+			rules = append(rules, r)
+		}
+	}
+
+	return rules
 }
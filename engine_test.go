@@ -1,9 +1,12 @@
 package fuzzy
 
 import (
+	"context"
+	"math"
 	"slices"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -188,3 +191,919 @@ func dumpResult(t *testing.T, engine *Engine, results Results, variable string)
 		t.Log("|")
 	}
 }
+
+func TestMidpointDefaults(t *testing.T) {
+	engine := NewEngine(Centroid(100)).
+		Variables(
+			NewVariable("temperature", NewTerm("hot", Linear(0, 40))),
+			NewVariable("humidity", NewTerm("high", Linear(0, 100))),
+			NewVariable("ventilation", NewTerm("high", Linear(0, 100))),
+		).
+		Rules(
+			If(And(Is("temperature", "hot"), Is("humidity", "high"))).Then("ventilation", "high"),
+		)
+
+	if _, err := engine.Infer(Values{"temperature": 30}); !errors.Is(err, ErrValueNotFound) {
+		t.Errorf("engine.Infer(): got error '%v', expected '%v'", err, ErrValueNotFound)
+	}
+
+	engine.WithMidpointDefaults(true)
+
+	values := Values{"temperature": 40}
+
+	results, err := engine.Infer(values)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := results["ventilation"]["high"].TruthDegree(), 0.5; g != e {
+		t.Errorf("ventilation IS high: got '%v', expected '%v'", g, e)
+	}
+
+	if _, exists := values["humidity"]; exists {
+		t.Errorf("values: expected the given values to be left untouched")
+	}
+
+	results, err = engine.Infer(Values{"temperature": 40, "humidity": 80})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := results["ventilation"]["high"].TruthDegree(), 0.8; g != e {
+		t.Errorf("ventilation IS high: got '%v', expected '%v'", g, e)
+	}
+
+	// All the variables default to their midpoint
+	results, err = engine.Infer(nil)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := results["ventilation"]["high"].TruthDegree(), 0.5; g != e {
+		t.Errorf("ventilation IS high: got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestLatch(t *testing.T) {
+	evaluated := 0
+	counted := Func(func(ctx *Context) (float64, error) {
+		evaluated++
+		return 1, nil
+	})
+
+	engine := NewEngine(Centroid(100)).
+		Variables(
+			NewVariable("temperature", NewTerm("critical", Linear(80, 100))),
+			NewVariable("shutdown", NewTerm("true", Linear(0, 1))),
+			NewVariable("fan_speed", NewTerm("high", Linear(0, 100))),
+		).
+		Rules(
+			If(Is("temperature", "critical")).Then("shutdown", "true"),
+			If(counted).Then("fan_speed", "high"),
+		).
+		WithLatch("shutdown", LatchAbove("true", 0.9))
+
+	results, err := engine.Infer(Values{"temperature": 50})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := evaluated, 1; g != e {
+		t.Errorf("evaluated rules: got '%v', expected '%v'", g, e)
+	}
+
+	if _, exists := results["fan_speed"]["high"]; !exists {
+		t.Errorf("results: expected a result for 'fan_speed'")
+	}
+
+	results, err = engine.Infer(Values{"temperature": 100})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := evaluated, 1; g != e {
+		t.Errorf("evaluated rules: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := results["shutdown"]["true"].TruthDegree(), 1.0; g != e {
+		t.Errorf("shutdown IS true: got '%v', expected '%v'", g, e)
+	}
+
+	if _, exists := results["fan_speed"]; exists {
+		t.Errorf("results: expected no result for 'fan_speed' once latched")
+	}
+}
+
+func TestInferAt(t *testing.T) {
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	engine := NewEngine(Centroid(100)).
+		Variables(
+			NewVariable("errors", NewTerm("many", Linear(0, 10))),
+			NewVariable("alert", NewTerm("raised", Linear(0, 1)), NewTerm("critical", Linear(0, 1))),
+		).
+		Rules(
+			If(Is("errors", "many")).Then("alert", "raised").WithDecay(timestamp, time.Hour),
+			If(Is("errors", "many")).Then("alert", "critical"),
+		)
+
+	testCases := []struct {
+		now      time.Time
+		raised   float64
+		critical float64
+	}{
+		{now: timestamp.Add(-time.Hour), raised: 1, critical: 1},
+		{now: timestamp, raised: 1, critical: 1},
+		{now: timestamp.Add(time.Hour), raised: 0.5, critical: 1},
+		{now: timestamp.Add(3 * time.Hour), raised: 0.125, critical: 1},
+	}
+
+	for _, tc := range testCases {
+		results, err := engine.InferAt(Values{"errors": 10}, tc.now)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if g, e := results["alert"]["raised"].TruthDegree(), tc.raised; g != e {
+			t.Errorf("alert IS raised at %v: got '%v', expected '%v'", tc.now, g, e)
+		}
+
+		if g, e := results["alert"]["critical"].TruthDegree(), tc.critical; g != e {
+			t.Errorf("alert IS critical at %v: got '%v', expected '%v'", tc.now, g, e)
+		}
+	}
+
+	results, err := engine.Infer(Values{"errors": 10})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := results["alert"]["raised"].TruthDegree(), 1.0; g != e {
+		t.Errorf("alert IS raised: got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestRuleWeight(t *testing.T) {
+	newEngine := func(weight float64) *Engine {
+		return NewEngine(Centroid(1000)).
+			Variables(
+				NewVariable("load", NewTerm("low", Inverted(Linear(0, 10))), NewTerm("high", Linear(0, 10))),
+				NewVariable("fan", NewTerm("slow", Triangular(0, 25, 50)), NewTerm("fast", Triangular(50, 75, 100))),
+			).
+			Rules(
+				If(Is("load", "low")).Then("fan", "slow"),
+				If(Is("load", "high")).Then("fan", "fast").WithWeight(weight),
+			)
+	}
+
+	defuzzify := func(engine *Engine) float64 {
+		results, err := engine.Infer(Values{"load": 5})
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		value, err := engine.Defuzzify("fan", results)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		return value
+	}
+
+	engine := newEngine(0.4)
+
+	results, err := engine.Infer(Values{"load": 5})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := results["fan"]["fast"].TruthDegree(), 0.2; math.Abs(g-e) > 1e-9 {
+		t.Errorf("fan IS fast: got '%v', expected '%v'", g, e)
+	}
+
+	unweighted, weighted := defuzzify(newEngine(1)), defuzzify(engine)
+
+	if math.Abs(unweighted-50) > 1e-6 {
+		t.Errorf("unweighted fan: got '%v', expected '%v'", unweighted, 50)
+	}
+
+	if weighted >= unweighted {
+		t.Errorf("weighted fan: got '%v', expected less than '%v'", weighted, unweighted)
+	}
+}
+
+func TestTakagiSugeno(t *testing.T) {
+	engine := NewEngine(nil).
+		Variables(
+			NewVariable("temperature", NewTerm("cold", Inverted(Linear(0, 40))), NewTerm("hot", Linear(0, 40))),
+		).
+		Rules(
+			If(Is("temperature", "cold")).ThenValue("fan_speed", 20),
+			If(Is("temperature", "hot")).ThenValue("fan_speed", 80),
+		)
+
+	testCases := []struct {
+		temperature float64
+		expected    float64
+	}{
+		{temperature: 0, expected: 20},
+		{temperature: 10, expected: 35},
+		{temperature: 20, expected: 50},
+		{temperature: 40, expected: 80},
+	}
+
+	for _, tc := range testCases {
+		results, err := engine.Infer(Values{"temperature": tc.temperature})
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if value, crisp := results["fan_speed"]["80"].Value(); !crisp || value != 80 {
+			t.Errorf("fan_speed = 80: got '%v' (crisp: %v), expected '%v'", value, crisp, 80)
+		}
+
+		value, err := engine.Defuzzify("fan_speed", results)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if g, e := value, tc.expected; math.Abs(g-e) > 1e-9 {
+			t.Errorf("fan_speed at %v: got '%v', expected '%v'", tc.temperature, g, e)
+		}
+	}
+}
+
+func TestTakagiSugenoMixed(t *testing.T) {
+	engine := NewEngine(Centroid(1000)).
+		Variables(
+			NewVariable("temperature", NewTerm("cold", Inverted(Linear(0, 40))), NewTerm("hot", Linear(0, 40))),
+			NewVariable("fan_speed", NewTerm("slow", Triangular(0, 20, 40))),
+		).
+		Rules(
+			If(Is("temperature", "cold")).Then("fan_speed", "slow"),
+			If(Is("temperature", "hot")).ThenValue("fan_speed", 80),
+		)
+
+	results, err := engine.Infer(Values{"temperature": 20})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	value, err := engine.Defuzzify("fan_speed", results)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	// The clipped "slow" triangle counts as a single value, its centroid (20)
+	if g, e := value, 50.0; math.Abs(g-e) > 1e-6 {
+		t.Errorf("fan_speed: got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestMultipleConclusions(t *testing.T) {
+	engine := NewEngine(nil).
+		Variables(
+			NewVariable("temperature", NewTerm("hot", Linear(0, 40))),
+			NewVariable("ac_mode", NewTerm("cooling", Linear(0, 1))),
+			NewVariable("fan", NewTerm("high", Linear(0, 1))),
+		).
+		Rules(
+			If(Is("temperature", "hot")).Then("ac_mode", "cooling").Then("fan", "high"),
+		)
+
+	results, err := engine.Infer(Values{"temperature": 30})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := results["ac_mode"]["cooling"].TruthDegree(), 0.75; g != e {
+		t.Errorf("ac_mode IS cooling: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := results["fan"]["high"].TruthDegree(), 0.75; g != e {
+		t.Errorf("fan IS high: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := len(engine.RulesForOutput("fan")), 1; g != e {
+		t.Errorf("len(RulesForOutput(\"fan\")): got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestElse(t *testing.T) {
+	engine := NewEngine(Centroid(1000)).
+		Variables(
+			NewVariable("temperature", NewTerm("hot", Linear(20, 30))),
+			NewVariable("ac_mode", NewTerm("off", Triangular(0, 10, 20)), NewTerm("cooling", Triangular(80, 90, 100))),
+		).
+		Rules(
+			If(Is("temperature", "hot")).Then("ac_mode", "cooling").Else("ac_mode", "off"),
+		)
+
+	testCases := []struct {
+		temperature float64
+		cooling     float64
+		off         float64
+		offExists   bool
+	}{
+		// No rule fires, the ELSE conclusion takes over
+		{temperature: 10, cooling: 0, off: 1, offExists: true},
+		{temperature: 25, cooling: 0.5, off: 0.5, offExists: true},
+		// The premise is fully true, the ELSE conclusion does not contribute
+		{temperature: 30, cooling: 1, offExists: false},
+	}
+
+	for _, tc := range testCases {
+		results, err := engine.Infer(Values{"temperature": tc.temperature})
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if g, e := results["ac_mode"]["cooling"].TruthDegree(), tc.cooling; g != e {
+			t.Errorf("ac_mode IS cooling at %v: got '%v', expected '%v'", tc.temperature, g, e)
+		}
+
+		off, exists := results["ac_mode"]["off"]
+		if exists != tc.offExists {
+			t.Errorf("ac_mode IS off at %v: got exists '%v', expected '%v'", tc.temperature, exists, tc.offExists)
+		}
+
+		if g, e := off.TruthDegree(), tc.off; g != e {
+			t.Errorf("ac_mode IS off at %v: got '%v', expected '%v'", tc.temperature, g, e)
+		}
+	}
+
+	// Without the ELSE conclusion, the silent rules would defuzzify to the universe midpoint (50)
+	results, err := engine.Infer(Values{"temperature": 0})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	value, err := engine.Defuzzify("ac_mode", results)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := value, 10.0; math.Abs(g-e) > 1e-6 {
+		t.Errorf("ac_mode: got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestAddVariablesAndRules(t *testing.T) {
+	engine := NewEngine(nil)
+
+	if err := engine.AddVariables(NewVariable("temperature", NewTerm("hot", Linear(20, 30)))); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if err := engine.AddVariables(NewVariable("ac_mode", NewTerm("cooling", Linear(0, 100)))); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	engine.AddRules(If(Is("temperature", "hot")).Then("ac_mode", "cooling"))
+	engine.AddRules(If(Not(Is("temperature", "hot"))).Then("ac_mode", "cooling"))
+
+	if g, e := len(engine.RulesForOutput("ac_mode")), 2; g != e {
+		t.Errorf("len(RulesForOutput(\"ac_mode\")): got '%v', expected '%v'", g, e)
+	}
+
+	if _, err := engine.Infer(Values{"temperature": 25}); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	err := engine.AddVariables(
+		NewVariable("humidity", NewTerm("high", Linear(50, 100))),
+		NewVariable("temperature", NewTerm("cold", Inverted(Linear(0, 10)))),
+	)
+	if !errors.Is(err, ErrVariableAlreadyExists) {
+		t.Errorf("engine.AddVariables(): got error '%v', expected '%v'", err, ErrVariableAlreadyExists)
+	}
+
+	if _, err := engine.variable("humidity"); err == nil {
+		t.Errorf("engine.AddVariables(): expected no variable to be added on error")
+	}
+}
+
+func newBatchEngine() *Engine {
+	return NewEngine(Centroid(100)).
+		Variables(
+			NewVariable(
+				"temperature",
+				NewTerm("cold", Inverted(Linear(0, 15))),
+				NewTerm("mild", Triangular(10, 20, 30)),
+				NewTerm("hot", Linear(25, 40)),
+			),
+			NewVariable(
+				"heating",
+				NewTerm("low", Triangular(0, 25, 50)),
+				NewTerm("high", Triangular(50, 75, 100)),
+			),
+		).
+		Rules(
+			If(Is("temperature", "cold")).Then("heating", "high"),
+			If(Or(Is("temperature", "mild"), Is("temperature", "hot"))).Then("heating", "low"),
+		)
+}
+
+func newBatchInputs(total int) []Values {
+	inputs := make([]Values, 0, total)
+	for i := 0; i < total; i++ {
+		inputs = append(inputs, Values{"temperature": float64(i % 40)})
+	}
+
+	return inputs
+}
+
+func TestInferBatch(t *testing.T) {
+	engine := newBatchEngine()
+	inputs := newBatchInputs(40)
+
+	batch, err := engine.InferBatch(inputs)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := len(batch), len(inputs); g != e {
+		t.Fatalf("len(batch): got '%v', expected '%v'", g, e)
+	}
+
+	for i, values := range inputs {
+		expected, err := engine.Infer(values)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		for term, result := range expected["heating"] {
+			if g, e := batch[i]["heating"][term].TruthDegree(), result.TruthDegree(); g != e {
+				t.Errorf("input set #%d: heating IS %s: got '%v', expected '%v'", i, term, g, e)
+			}
+		}
+	}
+
+	// The results of each input set are independent
+	delete(batch[0]["heating"], "high")
+	if _, exists := batch[1]["heating"]["high"]; !exists {
+		t.Errorf("batch[1]: expected 'heating IS high' to be unaffected by batch[0]")
+	}
+
+	if _, err := engine.InferBatch([]Values{{"temperature": 10}, {"humidity": 10}}); !errors.Is(err, ErrValueNotFound) {
+		t.Errorf("engine.InferBatch(): got error '%v', expected '%v'", err, ErrValueNotFound)
+	}
+}
+
+func BenchmarkInferLoop(b *testing.B) {
+	engine := newBatchEngine()
+	inputs := newBatchInputs(1000)
+
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		for _, values := range inputs {
+			if _, err := engine.Infer(values); err != nil {
+				b.Fatalf("%+v", errors.WithStack(err))
+			}
+		}
+	}
+}
+
+func BenchmarkInferBatch(b *testing.B) {
+	engine := newBatchEngine()
+	inputs := newBatchInputs(1000)
+
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		if _, err := engine.InferBatch(inputs); err != nil {
+			b.Fatalf("%+v", errors.WithStack(err))
+		}
+	}
+}
+
+func TestBestTermValue(t *testing.T) {
+	input := NewVariable(
+		"input",
+		NewTerm("a", Linear(0, 100)),
+	)
+
+	testCases := []struct {
+		term     *Term
+		expected float64
+	}{
+		{term: NewTerm("triangle", Triangular(10, 30, 90)), expected: 30},
+		{term: NewTerm("trapezoid", Trapezoid(0, 20, 40, 100)), expected: 30},
+		{term: NewTerm("rectangle", Rectangular(40, 60)), expected: 50},
+		{term: NewTerm("increasing", Linear(0, 50)), expected: 75},
+		{term: NewTerm("decreasing", Inverted(Linear(20, 100))), expected: 10},
+	}
+
+	for _, tc := range testCases {
+		output := NewVariable("output", tc.term).WithUniverse(0, 100)
+
+		engine := NewEngine(nil).
+			Variables(input, output).
+			Rules(If(Is("input", "a")).Then("output", tc.term.Name()))
+
+		results, err := engine.Infer(Values{"input": 50})
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		value, ok, err := engine.BestTermValue("output", results)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if !ok {
+			t.Fatalf("term '%s': expected a best term", tc.term.Name())
+		}
+
+		if g, e := value, tc.expected; math.Abs(g-e) > 0.1 {
+			t.Errorf("term '%s': got '%v', expected '%v'", tc.term.Name(), g, e)
+		}
+	}
+
+	engine := NewEngine(nil).
+		Variables(input, NewVariable("output", NewTerm("low", Triangular(0, 25, 50)))).
+		Rules(
+			If(Is("input", "a")).Then("output", "low"),
+			If(Is("input", "a")).ThenValue("speed", 80),
+		)
+
+	results, err := engine.Infer(Values{"input": 0})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if _, ok, err := engine.BestTermValue("output", results); err != nil || ok {
+		t.Errorf("engine.BestTermValue(): got '%v' (err: %v), expected no best term", ok, err)
+	}
+
+	if _, _, err := engine.BestTermValue("undefined", results); !errors.Is(err, ErrUndefinedVariable) {
+		t.Errorf("engine.BestTermValue(): got '%v', expected '%v'", err, ErrUndefinedVariable)
+	}
+
+	results, err = engine.Infer(Values{"input": 100})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	value, ok, err := engine.BestTermValue("speed", results)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := value, 80.0; !ok || g != e {
+		t.Errorf("engine.BestTermValue(): got '%v' (%v), expected '%v'", g, ok, e)
+	}
+}
+
+func TestNormalizedOutput(t *testing.T) {
+	newEngine := func(defuzzify DefuzzifyFunc) *Engine {
+		return NewEngine(defuzzify).
+			Variables(
+				NewVariable("signal", NewTerm("strong", Linear(0, 1e12))),
+				NewVariable(
+					"output",
+					NewTerm("low", Triangular(0, 25, 50)),
+					NewTerm("high", Triangular(40, 70, 100)),
+				).WithUniverse(0, 100),
+			).
+			Rules(
+				If(Is("signal", "strong")).Then("output", "low"),
+			)
+	}
+
+	// The rule barely fires, its truth degree being 1e-12
+	values := Values{"signal": 1}
+
+	testCases := []struct {
+		defuzzify  DefuzzifyFunc
+		raw        float64
+		normalized float64
+	}{
+		// All of the universe is within the tolerance of the raw maximum
+		{defuzzify: MeanOfMaximum(100), raw: 50, normalized: 25},
+		// The centroid is unaffected by scaling
+		{defuzzify: Centroid(100), raw: 25, normalized: 25},
+	}
+
+	for i, tc := range testCases {
+		for _, normalized := range []bool{false, true} {
+			engine := newEngine(tc.defuzzify).WithNormalizedOutput(normalized)
+
+			results, err := engine.Infer(values)
+			if err != nil {
+				t.Fatalf("%+v", errors.WithStack(err))
+			}
+
+			value, err := engine.Defuzzify("output", results)
+			if err != nil {
+				t.Fatalf("%+v", errors.WithStack(err))
+			}
+
+			expected := tc.raw
+			if normalized {
+				expected = tc.normalized
+			}
+
+			if g, e := value, expected; math.Abs(g-e) > 0.5 {
+				t.Errorf("test case #%d (normalized: %v): got '%v', expected '%v'", i, normalized, g, e)
+			}
+		}
+	}
+}
+
+func TestSetDefuzzify(t *testing.T) {
+	newEngine := func() *Engine {
+		return NewEngine(Centroid(100)).
+			Variables(
+				NewVariable("temperature", NewTerm("hot", Linear(0, 100))),
+				NewVariable("heating", NewTerm("low", Trapezoid(0, 10, 20, 100))),
+				NewVariable("fan", NewTerm("slow", Trapezoid(0, 10, 20, 100))),
+			).
+			Rules(
+				If(Is("temperature", "hot")).Then("heating", "low").Then("fan", "slow"),
+			)
+	}
+
+	values := Values{"temperature": 100}
+
+	engine := newEngine().SetDefuzzify("fan", MeanOfMaximum(100))
+
+	results, err := engine.Infer(values)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	centroid, err := newEngine().Defuzzify("heating", results)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	testCases := []struct {
+		variable string
+		expected float64
+	}{
+		{variable: "heating", expected: centroid},
+		{variable: "fan", expected: 15},
+	}
+
+	for _, tc := range testCases {
+		value, err := engine.Defuzzify(tc.variable, results)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if g, e := value, tc.expected; math.Abs(g-e) > 1e-9 {
+			t.Errorf("engine.Defuzzify(%s): got '%v', expected '%v'", tc.variable, g, e)
+		}
+	}
+
+	if g, e := centroid, 15.0; math.Abs(g-e) < 1 {
+		t.Errorf("centroid: got '%v', expected a value apart from '%v'", g, e)
+	}
+
+	// Removing the override falls back to the engine defuzzification
+	engine.SetDefuzzify("fan", nil)
+
+	value, err := engine.Defuzzify("fan", results)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := value, centroid; math.Abs(g-e) > 1e-9 {
+		t.Errorf("engine.Defuzzify(fan): got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestStrictInputs(t *testing.T) {
+	newEngine := func() *Engine {
+		return NewEngine(nil).
+			Variables(
+				NewVariable("temperature", NewTerm("cold", Inverted(Linear(0, 15))), NewTerm("hot", Linear(25, 40))),
+				NewVariable("heating", NewTerm("high", Triangular(50, 75, 100))),
+			).
+			Rules(
+				If(Is("temperature", "cold")).Then("heating", "high"),
+			)
+	}
+
+	testCases := []struct {
+		strict   bool
+		value    float64
+		expected error
+	}{
+		{strict: true, value: 20, expected: nil},
+		{strict: true, value: 0, expected: nil},
+		{strict: true, value: 40, expected: nil},
+		{strict: true, value: 1e9, expected: ErrValueOutOfUniverse},
+		{strict: true, value: -1, expected: ErrValueOutOfUniverse},
+		{strict: true, value: math.NaN(), expected: ErrValueOutOfUniverse},
+		{strict: false, value: 1e9, expected: nil},
+		{strict: false, value: -1, expected: nil},
+	}
+
+	for i, tc := range testCases {
+		engine := newEngine().WithStrictInputs(tc.strict)
+
+		// Inputs of unknown variables are ignored
+		_, err := engine.Infer(Values{"temperature": tc.value, "humidity": 1e9})
+		if !errors.Is(err, tc.expected) {
+			t.Errorf("test case #%d: got '%v', expected '%v'", i, err, tc.expected)
+		}
+	}
+
+	_, err := newEngine().WithStrictInputs(true).Infer(Values{"temperature": 50})
+	if g, e := errors.Cause(err).Error(), ErrValueOutOfUniverse.Error(); g != e {
+		t.Fatalf("err: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := err.Error(), "variable 'temperature': value '50' outside of [0, 40]: value out of universe"; g != e {
+		t.Errorf("err.Error(): got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestClampInputs(t *testing.T) {
+	newEngine := func() *Engine {
+		return NewEngine(nil).
+			Variables(
+				NewVariable(
+					"temperature",
+					NewTerm("mild", Trapezoid(0, 10, 20, 30)),
+					NewTerm("hot", Trapezoid(20, 40, 60, 80)),
+					NewTerm("warm", Linear(0, 60)),
+				).WithUniverse(0, 60),
+				NewVariable(
+					"heating",
+					NewTerm("low", Triangular(0, 25, 50)),
+					NewTerm("high", Triangular(50, 75, 100)),
+				),
+			).
+			Rules(
+				If(Is("temperature", "hot")).Then("heating", "low"),
+				If(Is("temperature", "mild")).Then("heating", "high"),
+				If(Is("temperature", "warm")).Then("heating", "high"),
+			)
+	}
+
+	clamped := newEngine().WithClampInputs(true)
+
+	expected, err := clamped.Infer(Values{"temperature": 60})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	results, err := clamped.Infer(Values{"temperature": 70})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	for _, term := range []string{"low", "high"} {
+		if g, e := results["heating"][term].TruthDegree(), expected["heating"][term].TruthDegree(); g != e {
+			t.Errorf("heating IS %s: got '%v', expected '%v'", term, g, e)
+		}
+	}
+
+	// Without clamping, the input is evaluated past the universe max
+	results, err = newEngine().Infer(Values{"temperature": 70})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := results["heating"]["low"].TruthDegree(), 0.5; g != e {
+		t.Errorf("heating IS low: got '%v', expected '%v'", g, e)
+	}
+
+	// Saturating memberships are unaffected
+	if g, e := results["heating"]["high"].TruthDegree(), expected["heating"]["high"].TruthDegree(); g != e {
+		t.Errorf("heating IS high: got '%v', expected '%v'", g, e)
+	}
+
+	// Out of universe inputs are rejected in strict mode
+	if _, err := newEngine().WithClampInputs(true).WithStrictInputs(true).Infer(Values{"temperature": 70}); !errors.Is(err, ErrValueOutOfUniverse) {
+		t.Errorf("err: got '%v', expected '%v'", err, ErrValueOutOfUniverse)
+	}
+}
+
+func TestInputDefaults(t *testing.T) {
+	defaults := Values{"humidity": 80}
+
+	engine := NewEngine(nil).
+		Variables(
+			NewVariable("temperature", NewTerm("hot", Linear(20, 30))),
+			NewVariable("humidity", NewTerm("high", Linear(50, 100))),
+			NewVariable("wind", NewTerm("strong", Linear(0, 50))),
+			NewVariable("ac_mode", NewTerm("cooling", Triangular(0, 50, 100))),
+		).
+		Rules(
+			If(And(Is("temperature", "hot"), Is("humidity", "high"))).Then("ac_mode", "cooling"),
+		).
+		WithInputDefaults(defaults)
+
+	// The defaults are copied
+	defaults["humidity"] = 0
+
+	values := Values{"temperature": 30}
+
+	results, err := engine.Infer(values)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := results["ac_mode"]["cooling"].TruthDegree(), 0.6; math.Abs(g-e) > 1e-9 {
+		t.Errorf("ac_mode IS cooling: got '%v', expected '%v'", g, e)
+	}
+
+	// The given values are left untouched
+	if _, exists := values["humidity"]; exists {
+		t.Errorf("values: unexpected 'humidity' value")
+	}
+
+	// Given values take precedence over the defaults
+	results, err = engine.Infer(Values{"temperature": 30, "humidity": 100})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := results["ac_mode"]["cooling"].TruthDegree(), 1.0; g != e {
+		t.Errorf("ac_mode IS cooling: got '%v', expected '%v'", g, e)
+	}
+
+	// Variables without input nor default still fail the inference
+	engine.AddRules(If(Is("wind", "strong")).Then("ac_mode", "cooling"))
+
+	if _, err := engine.Infer(Values{"temperature": 30}); !errors.Is(err, ErrValueNotFound) {
+		t.Errorf("err: got '%v', expected '%v'", err, ErrValueNotFound)
+	}
+}
+
+func TestRequiredInputs(t *testing.T) {
+	engine := NewEngine(nil).
+		Rules(
+			If(And(Is("temperature", "hot"), Or(Is("humidity", "high"), Not(Is("sunshine", "strong"))))).Then("ac_mode", "cooling"),
+			If(Or(Very(Is("temperature", "cold")), And(Is("wind", "strong"), Not(Is("humidity", "low"))))).Then("heating", "high").Else("heating", "low"),
+			If(Is("ac_mode", "cooling")).ThenValue("fan_speed", 80),
+			If(Func(func(ctx *Context) (float64, error) { return 1, nil }, "occupancy")).Then("lights", "on"),
+		)
+
+	if g, e := engine.RequiredInputs(), []string{"ac_mode", "humidity", "occupancy", "sunshine", "temperature", "wind"}; !slices.Equal(g, e) {
+		t.Errorf("engine.RequiredInputs(): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := NewEngine(nil).RequiredInputs(), []string{}; !slices.Equal(g, e) {
+		t.Errorf("engine.RequiredInputs(): got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestInferContext(t *testing.T) {
+	const totalRules = 10000
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	evaluated := 0
+
+	// Cancels the inference halfway through the rules
+	premise := Func(func(ctx *Context) (float64, error) {
+		evaluated++
+		if evaluated == totalRules/2 {
+			cancel()
+		}
+		return 1, nil
+	}, "temperature")
+
+	rules := make([]*Rule, 0, totalRules)
+	for i := 0; i < totalRules; i++ {
+		rules = append(rules, If(premise).Then("heating", "high"))
+	}
+
+	engine := NewEngine(nil).
+		Variables(
+			NewVariable("temperature", NewTerm("cold", Inverted(Linear(0, 10)))),
+			NewVariable("heating", NewTerm("high", Triangular(50, 75, 100))),
+		).
+		Rules(rules...)
+
+	results, err := engine.InferContext(runCtx, Values{"temperature": 5})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err: got '%v', expected '%v'", err, context.Canceled)
+	}
+
+	if results != nil {
+		t.Errorf("results: got '%v', expected nil", results)
+	}
+
+	if g, e := evaluated, totalRules/2; g != e {
+		t.Errorf("evaluated rules: got '%v', expected '%v'", g, e)
+	}
+
+	// A context done before the inference stops it before the first rule
+	evaluated = 0
+
+	if _, err := engine.InferContext(runCtx, Values{"temperature": 5}); !errors.Is(err, context.Canceled) {
+		t.Errorf("err: got '%v', expected '%v'", err, context.Canceled)
+	}
+
+	if g, e := evaluated, 0; g != e {
+		t.Errorf("evaluated rules: got '%v', expected '%v'", g, e)
+	}
+}
@@ -9,4 +9,9 @@ var (
 	ErrUndefinedTerm         = errors.New("undefined term")
 	ErrVariableAlreadyExists = errors.New("variable already exists")
 	ErrTermAlreadyExists     = errors.New("term already exists")
+	ErrUnsupportedMembership = errors.New("unsupported membership")
+	ErrUnsupportedExpr       = errors.New("unsupported expression")
+	ErrInvalidWeights        = errors.New("invalid weights")
+	ErrUnsupportedConclusion = errors.New("unsupported conclusion")
+	ErrValueOutOfUniverse    = errors.New("value out of universe")
 )
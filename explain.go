@@ -0,0 +1,179 @@
+package fuzzy
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// WhyNot explains the truth degree of the given output term for the given values.
+//
+// For each rule concluding the term, it reports the truth degree of the premise
+// and, for each AND, the operand limiting it (the minimum) with its value. ORs
+// report the operand determining them (the maximum) the same way, WEIGHTED_ANDs the
+// operand lowering their average the most and XORs the value of each operand.
+// Expressions under a NOT are flagged, as their contribution is inverted. Rules concluding
+// the term in their ELSE branch report the complement of the premise truth degree.
+func (e *Engine) WhyNot(values Values, variable, term string) (string, error) {
+	outputVariable, err := e.variable(variable)
+	if err != nil {
+		return "", errors.Wrapf(err, "variable '%s'", variable)
+	}
+
+	if _, err := outputVariable.Term(term); err != nil {
+		return "", errors.Wrapf(err, "term '%s' of variable '%s'", term, variable)
+	}
+
+	ctx, err := e.newContext(values)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	var sb strings.Builder
+
+	concludesTerm := func(c *IsExpr) bool {
+		return c.Variable() == variable && c.Term() == term
+	}
+
+	explained := 0
+	for i, r := range e.rules {
+		then := slices.ContainsFunc(r.conclusions, concludesTerm)
+		otherwise := slices.ContainsFunc(r.alternatives, concludesTerm)
+
+		if !then && !otherwise {
+			continue
+		}
+
+		truthDegree, err := r.premise.Value(ctx)
+		if err != nil {
+			return "", errors.Wrapf(err, "rule #%d", i)
+		}
+
+		if then {
+			fmt.Fprintf(&sb, "rule #%d '%s': truth degree %v\n", i, FormatRule(r), truthDegree)
+		}
+
+		if otherwise {
+			fmt.Fprintf(&sb, "rule #%d '%s': ELSE truth degree %v (premise %v)\n", i, FormatRule(r), 1-truthDegree, truthDegree)
+		}
+
+		if err := explainExpr(&sb, r.premise, ctx, 1); err != nil {
+			return "", errors.Wrapf(err, "rule #%d", i)
+		}
+
+		explained++
+	}
+
+	if explained == 0 {
+		return fmt.Sprintf("no rule concludes '%s IS %s'\n", variable, term), nil
+	}
+
+	return sb.String(), nil
+}
+
+// explainExpr writes, for each composite expression of the premise, the operand
+// limiting (AND, WEIGHTED_AND) or determining (OR) its value. XOR expressions, which
+// depend on all their operands, report the value of each of them. The expressions
+// under an odd number of NOT are flagged as such, their contribution being inverted.
+func explainExpr(sb *strings.Builder, premise Expr, ctx *Context, depth int) error {
+	var err error
+
+	depths := map[Expr]int{premise: depth}
+	negated := make(map[Expr]bool)
+
+	Walk(premise, func(expr Expr) {
+		if err != nil {
+			return
+		}
+
+		var (
+			line     string
+			operands []Expr
+		)
+
+		switch typ := expr.(type) {
+		case *AndExpr:
+			operands = typ.exprs
+			line, err = explainOperand(ctx, "AND limited by", operands, nil, func(v, current float64) bool { return v < current })
+		case *OrExpr:
+			operands = typ.exprs
+			line, err = explainOperand(ctx, "OR determined by", operands, nil, func(v, current float64) bool { return v > current })
+		case *WeightedAndExpr:
+			// The operand lowering the weighted average the most
+			operands = typ.exprs
+			line, err = explainOperand(ctx, "WEIGHTED_AND limited by", operands, typ.weights, func(v, current float64) bool { return v > current })
+		case *XorExpr:
+			operands = typ.exprs
+			line, err = explainOperands(ctx, "XOR of", operands)
+		case *NotExpr:
+			depths[typ.expr] = depths[expr]
+			negated[typ.expr] = !negated[expr]
+		case *HedgeExpr:
+			depths[typ.expr] = depths[expr]
+			negated[typ.expr] = negated[expr]
+		}
+
+		if line == "" {
+			return
+		}
+
+		if negated[expr] {
+			line += " (under NOT)"
+		}
+
+		fmt.Fprintf(sb, "%s%s\n", strings.Repeat("  ", depths[expr]), line)
+
+		for _, operand := range operands {
+			depths[operand] = depths[expr] + 1
+			negated[operand] = negated[expr]
+		}
+	})
+
+	return err
+}
+
+// explainOperand describes the operand selected among the given ones, comparing their
+// truth degree or, if weights are given, their weighted shortfall from full truth
+func explainOperand(ctx *Context, prefix string, exprs []Expr, weights []float64, selected func(v, current float64) bool) (string, error) {
+	var (
+		factor      Expr
+		factorValue float64
+		factorScore float64
+	)
+
+	for i, e := range exprs {
+		v, err := e.Value(ctx)
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+
+		score := v
+		if weights != nil {
+			score = weights[i] * (1 - v)
+		}
+
+		if factor == nil || selected(score, factorScore) {
+			factor, factorValue, factorScore = e, v, score
+		}
+	}
+
+	return fmt.Sprintf("%s '%s': %v", prefix, formatExpr(factor), factorValue), nil
+}
+
+// explainOperands describes the truth degree of each of the given operands
+func explainOperands(ctx *Context, prefix string, exprs []Expr) (string, error) {
+	operands := make([]string, 0, len(exprs))
+
+	for _, e := range exprs {
+		v, err := e.Value(ctx)
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+
+		operands = append(operands, fmt.Sprintf("'%s': %v", formatExpr(e), v))
+	}
+
+	return prefix + " " + strings.Join(operands, ", "), nil
+}
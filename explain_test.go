@@ -0,0 +1,98 @@
+package fuzzy
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestWhyNot(t *testing.T) {
+	engine := NewEngine(Centroid(100)).
+		Variables(
+			NewVariable("temperature", NewTerm("hot", Linear(20, 30))),
+			NewVariable("humidity", NewTerm("high", Linear(50, 100))),
+			NewVariable("sunshine", NewTerm("strong", Linear(0, 10))),
+			NewVariable("ac_mode", NewTerm("cooling", Linear(0, 100)), NewTerm("off", Linear(0, 100))),
+		).
+		Rules(
+			If(And(
+				Is("temperature", "hot"),
+				Or(Is("humidity", "high"), Is("sunshine", "strong")),
+			)).Then("ac_mode", "cooling"),
+		)
+
+	explanation, err := engine.WhyNot(Values{"temperature": 28, "humidity": 60, "sunshine": 3}, "ac_mode", "cooling")
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	expected := "rule #0 'IF temperature IS hot AND (humidity IS high OR sunshine IS strong) THEN ac_mode IS cooling': truth degree 0.3\n" +
+		"  AND limited by 'humidity IS high OR sunshine IS strong': 0.3\n" +
+		"    OR determined by 'sunshine IS strong': 0.3\n"
+
+	if g, e := explanation, expected; g != e {
+		t.Errorf("engine.WhyNot(): got '%v', expected '%v'", g, e)
+	}
+
+	explanation, err = engine.WhyNot(Values{}, "ac_mode", "off")
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := explanation, "no rule concludes 'ac_mode IS off'\n"; g != e {
+		t.Errorf("engine.WhyNot(): got '%v', expected '%v'", g, e)
+	}
+
+	engine.Rules(
+		If(Is("temperature", "hot")).Then("ac_mode", "cooling").Else("ac_mode", "off"),
+	)
+
+	explanation, err = engine.WhyNot(Values{"temperature": 25}, "ac_mode", "off")
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := explanation, "rule #0 'IF temperature IS hot THEN ac_mode IS cooling ELSE ac_mode IS off': ELSE truth degree 0.5 (premise 0.5)\n"; g != e {
+		t.Errorf("engine.WhyNot(): got '%v', expected '%v'", g, e)
+	}
+
+	if _, err := engine.WhyNot(Values{}, "ac_mode", "heating"); !errors.Is(err, ErrUndefinedTerm) {
+		t.Errorf("engine.WhyNot(): got error '%v', expected '%v'", err, ErrUndefinedTerm)
+	}
+}
+
+func TestWhyNotOperators(t *testing.T) {
+	engine := NewEngine(Centroid(100)).
+		Variables(
+			NewVariable("temperature", NewTerm("hot", Linear(20, 30))),
+			NewVariable("humidity", NewTerm("high", Linear(50, 100))),
+			NewVariable("sunshine", NewTerm("strong", Linear(0, 10))),
+			NewVariable("ac_mode", NewTerm("cooling", Linear(0, 100))),
+		).
+		Rules(
+			If(Not(And(
+				Is("temperature", "hot"),
+				Xor(Is("humidity", "high"), Is("sunshine", "strong")),
+			))).Then("ac_mode", "cooling"),
+			If(WeightedAnd(
+				[]float64{1, 3},
+				Is("temperature", "hot"),
+				Is("sunshine", "strong"),
+			)).Then("ac_mode", "cooling"),
+		)
+
+	explanation, err := engine.WhyNot(Values{"temperature": 25, "humidity": 60, "sunshine": 10}, "ac_mode", "cooling")
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	expected := "rule #0 'IF NOT (temperature IS hot AND (humidity IS high XOR sunshine IS strong)) THEN ac_mode IS cooling': truth degree 0.5\n" +
+		"  AND limited by 'temperature IS hot': 0.5 (under NOT)\n" +
+		"    XOR of 'humidity IS high': 0.2, 'sunshine IS strong': 1 (under NOT)\n" +
+		"rule #1 'IF WEIGHTED_AND(1: temperature IS hot, 3: sunshine IS strong) THEN ac_mode IS cooling': truth degree 0.875\n" +
+		"  WEIGHTED_AND limited by 'temperature IS hot': 0.5\n"
+
+	if g, e := explanation, expected; g != e {
+		t.Errorf("engine.WhyNot(): got '%v', expected '%v'", g, e)
+	}
+}
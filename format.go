@@ -0,0 +1,224 @@
+package fuzzy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FormatRule renders the rule as DSL-like text, i.e. "IF temperature IS hot THEN ac_mode IS cooling"
+func FormatRule(r *Rule) string {
+	var sb strings.Builder
+
+	sb.WriteString("IF ")
+	sb.WriteString(formatExpr(r.premise))
+
+	for i, c := range r.conclusions {
+		if i == 0 {
+			sb.WriteString(" THEN ")
+		} else {
+			sb.WriteString(" AND ")
+		}
+
+		sb.WriteString(formatConclusion(c))
+	}
+
+	for i, c := range r.alternatives {
+		if i == 0 {
+			sb.WriteString(" ELSE ")
+		} else {
+			sb.WriteString(" AND ")
+		}
+
+		sb.WriteString(formatConclusion(c))
+	}
+
+	if r.weight != 1 {
+		fmt.Fprintf(&sb, " WEIGHT %v", r.weight)
+	}
+
+	if r.priority != 0 {
+		fmt.Fprintf(&sb, " PRIORITY %d", r.priority)
+	}
+
+	return sb.String()
+}
+
+// FormatRules renders the rules as DSL-like text, one rule per line
+func FormatRules(rules []*Rule) string {
+	var sb strings.Builder
+
+	for _, r := range rules {
+		sb.WriteString(FormatRule(r))
+		sb.WriteString(";\n")
+	}
+
+	return sb.String()
+}
+
+// String returns the DSL-like text of the rule, see FormatRule()
+func (r *Rule) String() string {
+	return FormatRule(r)
+}
+
+// String returns the DSL-like text of the expression, i.e. "temperature IS hot",
+// or "fan_speed = 80" for crisp conclusions
+func (e *IsExpr) String() string {
+	return formatConclusion(e)
+}
+
+// String returns the DSL-like text of the expression
+func (e *AndExpr) String() string {
+	return formatExpr(e)
+}
+
+// String returns the DSL-like text of the expression
+func (e *OrExpr) String() string {
+	return formatExpr(e)
+}
+
+// String returns the DSL-like text of the expression
+func (e *XorExpr) String() string {
+	return formatExpr(e)
+}
+
+// String returns the DSL-like text of the expression
+func (e *NotExpr) String() string {
+	return formatExpr(e)
+}
+
+// String returns the DSL-like text of the expression
+func (e *HedgeExpr) String() string {
+	return formatExpr(e)
+}
+
+// String returns the DSL-like text of the expression, i.e. "temperature > 30"
+func (e *CompareExpr) String() string {
+	return formatExpr(e)
+}
+
+func formatExpr(e Expr) string {
+	switch typ := e.(type) {
+	case *IsExpr:
+		return fmt.Sprintf("%s IS %s", formatIdentifier(typ.variable), formatIdentifier(typ.term))
+	case *AndExpr:
+		return formatOperands(typ.exprs, " AND ")
+	case *OrExpr:
+		return formatOperands(typ.exprs, " OR ")
+	case *XorExpr:
+		return formatOperands(typ.exprs, " XOR ")
+	case *NotExpr:
+		if isLeafExpr(typ.expr) {
+			return "NOT " + formatExpr(typ.expr)
+		}
+		return "NOT (" + formatExpr(typ.expr) + ")"
+	case *HedgeExpr:
+		if hedges, is := hedgedIsExpr(typ); is != nil {
+			return fmt.Sprintf("%s IS %s %s", formatIdentifier(is.variable), strings.Join(hedges, " "), formatIdentifier(is.term))
+		}
+		return formatHedge(typ) + " (" + formatExpr(typ.expr) + ")"
+	case *CompareExpr:
+		return fmt.Sprintf("%s %s %v", formatIdentifier(typ.variable), typ.operator, typ.threshold)
+	case *FuncExpr:
+		return "FUNC(" + strings.Join(typ.dependencies, ", ") + ")"
+	case *WeightedAndExpr:
+		operands := make([]string, 0, len(typ.exprs))
+		for i, e := range typ.exprs {
+			operands = append(operands, fmt.Sprintf("%v: %s", typ.weights[i], formatExpr(e)))
+		}
+		return "WEIGHTED_AND(" + strings.Join(operands, ", ") + ")"
+	default:
+		return fmt.Sprintf("%v", e)
+	}
+}
+
+func formatConclusion(c *IsExpr) string {
+	if _, crisp := c.CrispValue(); crisp {
+		return fmt.Sprintf("%s = %s", formatIdentifier(c.Variable()), c.Term())
+	}
+
+	return formatExpr(c)
+}
+
+// formatIdentifier returns the name as is, or double-quoted if it is empty or
+// contains whitespaces or DSL punctuation, i.e. "outdoor temperature"
+func formatIdentifier(name string) string {
+	if name == "" || strings.ContainsAny(name, ";(),=<>\" \t\r\n") {
+		return `"` + name + `"`
+	}
+
+	return name
+}
+
+// isLeafExpr returns true if the expression is formatted as a single IS expression
+// or comparison, i.e. "x IS a", "x IS VERY a" or "x > 5"
+func isLeafExpr(e Expr) bool {
+	switch typ := e.(type) {
+	case *IsExpr, *CompareExpr:
+		return true
+	case *HedgeExpr:
+		_, is := hedgedIsExpr(typ)
+		return is != nil
+	default:
+		return false
+	}
+}
+
+// hedgedIsExpr returns the keywords of the stacked hedges, outermost first, and the
+// hedged IS expression if the hedge only wraps hedges and an IS expression, nil otherwise
+func hedgedIsExpr(h *HedgeExpr) ([]string, *IsExpr) {
+	var hedges []string
+
+	var e Expr = h
+	for {
+		switch typ := e.(type) {
+		case *HedgeExpr:
+			hedges = append(hedges, formatHedge(typ))
+			e = typ.expr
+		case *IsExpr:
+			return hedges, typ
+		default:
+			return nil, nil
+		}
+	}
+}
+
+// formatHedge returns the keyword of the hedge, HEDGE(exponent) for custom exponents
+func formatHedge(h *HedgeExpr) string {
+	switch h.exponent {
+	case VeryExponent:
+		return "VERY"
+	case SomewhatExponent:
+		return "SOMEWHAT"
+	default:
+		return fmt.Sprintf("HEDGE(%v)", h.exponent)
+	}
+}
+
+func formatOperands(exprs []Expr, operator string) string {
+	operands := make([]string, 0, len(exprs))
+
+	for i, e := range exprs {
+		operand := formatExpr(e)
+
+		// Nested operands of the same operator are equivalent to flattened ones, except
+		// for XOR, which is evaluated from left to right and not associative with all the norms
+		switch e.(type) {
+		case *AndExpr:
+			if operator != " AND " {
+				operand = "(" + operand + ")"
+			}
+		case *OrExpr:
+			if operator != " OR " {
+				operand = "(" + operand + ")"
+			}
+		case *XorExpr:
+			if operator != " XOR " || i > 0 {
+				operand = "(" + operand + ")"
+			}
+		}
+
+		operands = append(operands, operand)
+	}
+
+	return strings.Join(operands, operator)
+}
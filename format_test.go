@@ -0,0 +1,129 @@
+package fuzzy
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFormatRule(t *testing.T) {
+	testCases := []struct {
+		rule     *Rule
+		expected string
+	}{
+		{
+			rule:     If(Is("temperature", "hot")).Then("ac_mode", "cooling"),
+			expected: "IF temperature IS hot THEN ac_mode IS cooling",
+		},
+		{
+			rule: If(
+				And(
+					Or(Is("temperature", "cold"), Is("humidity", "high")),
+					Not(Is("pressure", "low")),
+				),
+			).Then("ac_mode", "heating"),
+			expected: "IF (temperature IS cold OR humidity IS high) AND NOT pressure IS low THEN ac_mode IS heating",
+		},
+		{
+			rule:     If(Not(Or(Is("temperature", "cold"), Is("temperature", "hot")))).Then("ac_mode", "off"),
+			expected: "IF NOT (temperature IS cold OR temperature IS hot) THEN ac_mode IS off",
+		},
+		{
+			rule:     If(Is("temperature", "hot")).Then("ac_mode", "cooling").WithWeight(0.7),
+			expected: "IF temperature IS hot THEN ac_mode IS cooling WEIGHT 0.7",
+		},
+		{
+			rule:     If(Is("temperature", "hot")).ThenValue("fan_speed", 80.5),
+			expected: "IF temperature IS hot THEN fan_speed = 80.5",
+		},
+		{
+			rule:     If(Is("temperature", "hot")).Then("ac_mode", "cooling").ThenValue("fan_speed", 80),
+			expected: "IF temperature IS hot THEN ac_mode IS cooling AND fan_speed = 80",
+		},
+		{
+			rule:     If(Is("temperature", "hot")).Then("ac_mode", "cooling").Else("ac_mode", "off").ElseValue("fan_speed", 0),
+			expected: "IF temperature IS hot THEN ac_mode IS cooling ELSE ac_mode IS off AND fan_speed = 0",
+		},
+	}
+
+	for _, tc := range testCases {
+		if g, e := FormatRule(tc.rule), tc.expected; g != e {
+			t.Errorf("FormatRule(): got '%v', expected '%v'", g, e)
+		}
+	}
+}
+
+func TestRulesForOutput(t *testing.T) {
+	engine := NewEngine(nil)
+
+	engine.Rules(
+		If(Is("temperature", "cold")).Then("ac_mode", "heating"),
+		If(Is("temperature", "hot")).Then("fan", "high"),
+		If(Is("temperature", "hot")).Then("ac_mode", "cooling"),
+	)
+
+	rules := engine.RulesForOutput("ac_mode")
+
+	if g, e := len(rules), 2; g != e {
+		t.Fatalf("len(engine.RulesForOutput(\"ac_mode\")): got '%v', expected '%v'", g, e)
+	}
+
+	expected := "IF temperature IS cold THEN ac_mode IS heating;\nIF temperature IS hot THEN ac_mode IS cooling;\n"
+	if g, e := FormatRules(rules), expected; g != e {
+		t.Errorf("FormatRules(): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := len(engine.RulesForOutput("unknown")), 0; g != e {
+		t.Errorf("len(engine.RulesForOutput(\"unknown\")): got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestString(t *testing.T) {
+	testCases := []struct {
+		value    fmt.Stringer
+		expected string
+	}{
+		{
+			value:    Is("temperature", "hot"),
+			expected: "temperature IS hot",
+		},
+		{
+			value:    SetValue("fan_speed", 80),
+			expected: "fan_speed = 80",
+		},
+		{
+			value:    And(And(Is("a", "x"), Is("b", "y")), Is("c", "z")),
+			expected: "a IS x AND b IS y AND c IS z",
+		},
+		{
+			value:    Or(Is("a", "x"), Or(Is("b", "y"), Is("c", "z"))),
+			expected: "a IS x OR b IS y OR c IS z",
+		},
+		{
+			value:    Or(And(Is("a", "x"), Is("b", "y")), Is("c", "z")),
+			expected: "(a IS x AND b IS y) OR c IS z",
+		},
+		{
+			value:    Not(And(Is("a", "x"), Is("b", "y"))),
+			expected: "NOT (a IS x AND b IS y)",
+		},
+		{
+			value:    Very(Is("a", "x")),
+			expected: "a IS VERY x",
+		},
+		{
+			value: If(
+				And(
+					Or(Is("temperature", "cold"), Is("humidity", "high")),
+					Not(Is("pressure", "low")),
+				),
+			).Then("ac_mode", "heating"),
+			expected: "IF (temperature IS cold OR humidity IS high) AND NOT pressure IS low THEN ac_mode IS heating",
+		},
+	}
+
+	for _, tc := range testCases {
+		if g, e := fmt.Sprintf("%v", tc.value), tc.expected; g != e {
+			t.Errorf("String(): got '%v', expected '%v'", g, e)
+		}
+	}
+}
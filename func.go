@@ -0,0 +1,40 @@
+package fuzzy
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// FuncExpr is an expression computed by a user-supplied function, which can read
+// any number of raw inputs from the context, i.e. a "discomfort index" combining
+// the temperature and the humidity.
+//
+// Its value is bounded to [0, 1] and composes under AND/OR/NOT like any other
+// expression. As the function is opaque, the variables it reads must be declared
+// as its dependencies for the linter to take them into account. A FuncExpr cannot
+// be serialized (see Engine.Snapshot()).
+type FuncExpr struct {
+	fn           func(ctx *Context) (float64, error)
+	dependencies []string
+}
+
+func (e *FuncExpr) Value(ctx *Context) (float64, error) {
+	v, err := e.fn(ctx)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	return math.Max(0, math.Min(1, v)), nil
+}
+
+// Dependencies returns the names of the variables read by the function
+func (e *FuncExpr) Dependencies() []string {
+	return e.dependencies
+}
+
+// Func returns an expression computed by the given function,
+// which reads the given dependencies from the context
+func Func(fn func(ctx *Context) (float64, error), dependencies ...string) *FuncExpr {
+	return &FuncExpr{fn, dependencies}
+}
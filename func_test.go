@@ -0,0 +1,70 @@
+package fuzzy
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestFunc(t *testing.T) {
+	// Discomfort index, growing with both the temperature and the humidity
+	discomfort := Func(func(ctx *Context) (float64, error) {
+		temperature, err := ctx.Value("temperature")
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+
+		humidity, err := ctx.Value("humidity")
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+
+		return (temperature - 20) / 10 * humidity / 100, nil
+	}, "temperature", "humidity")
+
+	engine := NewEngine(Centroid(100))
+
+	engine.Variables(
+		NewVariable("temperature", NewTerm("hot", Linear(25, 35))),
+		NewVariable("humidity", NewTerm("high", Linear(50, 100))),
+		NewVariable("ventilation", NewTerm("high", Linear(0, 100))),
+	)
+
+	engine.Rules(
+		If(Or(discomfort, Is("temperature", "hot"))).Then("ventilation", "high"),
+	)
+
+	testCases := []struct {
+		temperature float64
+		humidity    float64
+		expected    float64
+	}{
+		{temperature: 25, humidity: 80, expected: 0.4},
+		{temperature: 30, humidity: 50, expected: 0.5},
+		{temperature: 40, humidity: 100, expected: 1},
+		{temperature: 10, humidity: 100, expected: 0},
+	}
+
+	for _, tc := range testCases {
+		results, err := engine.Infer(Values{"temperature": tc.temperature, "humidity": tc.humidity})
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if g, e := results["ventilation"]["high"].TruthDegree(), tc.expected; g != e {
+			t.Errorf("ventilation IS high with temperature=%v and humidity=%v: got '%v', expected '%v'", tc.temperature, tc.humidity, g, e)
+		}
+	}
+
+	if _, err := engine.Infer(Values{"temperature": 25}); !errors.Is(err, ErrValueNotFound) {
+		t.Errorf("engine.Infer(): got error '%v', expected '%v'", err, ErrValueNotFound)
+	}
+
+	if warnings := engine.Lint(); len(warnings) != 0 {
+		t.Errorf("engine.Lint(): got '%v', expected no warnings", warnings)
+	}
+
+	if g, e := FormatRule(engine.rules[0]), "IF FUNC(temperature, humidity) OR temperature IS hot THEN ventilation IS high"; g != e {
+		t.Errorf("FormatRule(): got '%v', expected '%v'", g, e)
+	}
+}
@@ -0,0 +1,54 @@
+package fuzzy
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// Exponents of the built-in hedges
+const (
+	VeryExponent     = 2
+	SomewhatExponent = 0.5
+)
+
+// HedgeExpr is a linguistic hedge, raising the truth degree of its
+// expression to the given exponent, i.e. "very cold" squares the
+// membership of "cold" (concentration) and "somewhat cold" takes its
+// square root (dilation)
+type HedgeExpr struct {
+	exponent float64
+	expr     Expr
+}
+
+func (e *HedgeExpr) Value(ctx *Context) (float64, error) {
+	v, err := e.expr.Value(ctx)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	return math.Pow(v, e.exponent), nil
+}
+
+func (e *HedgeExpr) Expr() Expr {
+	return e.expr
+}
+
+func (e *HedgeExpr) Exponent() float64 {
+	return e.exponent
+}
+
+// Hedge returns an expression raising the truth degree of the given expression to the exponent
+func Hedge(expr Expr, exponent float64) *HedgeExpr {
+	return &HedgeExpr{exponent, expr}
+}
+
+// Very concentrates the given expression, squaring its truth degree
+func Very(expr Expr) *HedgeExpr {
+	return Hedge(expr, VeryExponent)
+}
+
+// Somewhat dilates the given expression, taking the square root of its truth degree
+func Somewhat(expr Expr) *HedgeExpr {
+	return Hedge(expr, SomewhatExponent)
+}
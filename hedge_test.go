@@ -0,0 +1,61 @@
+package fuzzy
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestHedges(t *testing.T) {
+	engine := NewEngine(nil).
+		Variables(
+			NewVariable("temperature", NewTerm("cold", Inverted(Linear(0, 20)))),
+			NewVariable("heating", NewTerm("plain", Linear(0, 1)), NewTerm("very", Linear(0, 1)), NewTerm("somewhat", Linear(0, 1))),
+		).
+		Rules(
+			If(Is("temperature", "cold")).Then("heating", "plain"),
+			If(Very(Is("temperature", "cold"))).Then("heating", "very"),
+			If(Somewhat(Is("temperature", "cold"))).Then("heating", "somewhat"),
+		)
+
+	results, err := engine.Infer(Values{"temperature": 10})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	expected := map[string]float64{
+		"plain":    0.5,
+		"very":     0.25,
+		"somewhat": math.Sqrt(0.5),
+	}
+
+	for term, e := range expected {
+		if g := results["heating"][term].TruthDegree(); math.Abs(g-e) > 1e-9 {
+			t.Errorf("heating IS %s: got '%v', expected '%v'", term, g, e)
+		}
+	}
+
+	if plain, very := results["heating"]["plain"].TruthDegree(), results["heating"]["very"].TruthDegree(); very >= plain {
+		t.Errorf("VERY cold: got '%v', expected less than '%v'", very, plain)
+	}
+}
+
+func TestFormatHedges(t *testing.T) {
+	testCases := []struct {
+		expr     Expr
+		expected string
+	}{
+		{expr: Very(Is("temperature", "cold")), expected: "temperature IS VERY cold"},
+		{expr: Somewhat(Is("temperature", "cold")), expected: "temperature IS SOMEWHAT cold"},
+		{expr: Very(Somewhat(Is("temperature", "cold"))), expected: "temperature IS VERY SOMEWHAT cold"},
+		{expr: Very(Or(Is("temperature", "cold"), Is("humidity", "high"))), expected: "VERY (temperature IS cold OR humidity IS high)"},
+		{expr: Hedge(Is("temperature", "cold"), 3), expected: "temperature IS HEDGE(3) cold"},
+	}
+
+	for _, tc := range testCases {
+		if g, e := formatExpr(tc.expr), tc.expected; g != e {
+			t.Errorf("formatExpr(): got '%v', expected '%v'", g, e)
+		}
+	}
+}
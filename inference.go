@@ -0,0 +1,55 @@
+package fuzzy
+
+// ImplicationFunc shapes the membership of a rule conclusion
+// according to the truth degree of the rule premise
+type ImplicationFunc func(m Membership, truthDegree float64) Membership
+
+// AggregationFunc combines the memberships of the conclusions
+// targeting a same output variable
+type AggregationFunc func(memberships ...Membership) Membership
+
+// MinImplication clips the membership at the truth degree (Mamdani implication)
+func MinImplication(m Membership, truthDegree float64) Membership {
+	return Min(Constant(truthDegree), m)
+}
+
+// ProductImplication scales the membership by the truth degree (Larsen implication)
+func ProductImplication(m Membership, truthDegree float64) Membership {
+	return Scale(m, truthDegree)
+}
+
+// MaxAggregation combines the memberships with their maximum
+func MaxAggregation(memberships ...Membership) Membership {
+	return Max(memberships...)
+}
+
+// SumAggregation combines the memberships with their sum.
+// The aggregated membership may exceed 1.0.
+func SumAggregation(memberships ...Membership) Membership {
+	return Sum(memberships...)
+}
+
+// ClampedSumAggregation combines the memberships with their sum, bounded to 1.0
+// so that the aggregated membership remains a degree of truth
+func ClampedSumAggregation(memberships ...Membership) Membership {
+	return Clamped(Sum(memberships...))
+}
+
+// Option configures an engine
+type Option func(e *Engine)
+
+// MamdaniProductSum configures the engine with the product implication,
+// the sum aggregation and the centroid defuzzification.
+//
+// Scaling (instead of clipping) the conclusions preserves the shape of the output terms and
+// summing (instead of taking the maximum of) them makes every firing rule contribute to the
+// output. With complementary input terms and output terms of equal area, the resulting control
+// surface linearly interpolates between the output terms centroids, whereas the default
+// min implication and max aggregation produce a piecewise, "stepped" surface.
+func MamdaniProductSum() Option {
+	return func(e *Engine) {
+		e.WithImplication(ProductImplication).
+			WithAggregation(SumAggregation).
+			WithDefuzzifier(defaultDefuzzifier())
+	}
+}
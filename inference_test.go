@@ -0,0 +1,193 @@
+package fuzzy
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func newInterpolationEngine(opts ...Option) *Engine {
+	engine := NewEngine(Centroid(1000)).With(opts...)
+
+	engine.Variables(
+		NewVariable(
+			"input",
+			NewTerm("low", Inverted(Linear(0, 10))),
+			NewTerm("high", Linear(0, 10)),
+		),
+		NewVariable(
+			"output",
+			NewTerm("left", Triangular(0, 25, 50)),
+			NewTerm("right", Triangular(50, 75, 100)),
+		),
+	)
+
+	engine.Rules(
+		If(Is("input", "low")).Then("output", "left"),
+		If(Is("input", "high")).Then("output", "right"),
+	)
+
+	return engine
+}
+
+// maxDeviationFromLinear returns the maximum distance between the engine output
+// and the straight line joining the output terms centroids
+func maxDeviationFromLinear(t *testing.T, engine *Engine) float64 {
+	deviation := 0.0
+
+	for x := 0.0; x <= 10; x += 0.5 {
+		results, err := engine.Infer(Values{"input": x})
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		value, err := engine.Defuzzify("output", results)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		deviation = math.Max(deviation, math.Abs(value-(25+5*x)))
+	}
+
+	return deviation
+}
+
+func TestMamdaniProductSum(t *testing.T) {
+	productSum := maxDeviationFromLinear(t, newInterpolationEngine(MamdaniProductSum()))
+	minMax := maxDeviationFromLinear(t, newInterpolationEngine())
+
+	if productSum > 0.1 {
+		t.Errorf("product-sum deviation from linear surface: got '%v', expected less than '%v'", productSum, 0.1)
+	}
+
+	if minMax <= productSum {
+		t.Errorf("min-max deviation from linear surface: got '%v', expected more than product-sum deviation '%v'", minMax, productSum)
+	}
+}
+
+func TestProductImplication(t *testing.T) {
+	m := ProductImplication(Triangular(0, 10, 20), 0.5)
+
+	if g, e := m.Value(10), 0.5; g != e {
+		t.Errorf("m.Value(10): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := m.Value(5), 0.25; g != e {
+		t.Errorf("m.Value(5): got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestSumAggregation(t *testing.T) {
+	m := SumAggregation(Triangular(0, 10, 20), Triangular(5, 15, 25))
+
+	if g, e := m.Value(10), 1.5; g != e {
+		t.Errorf("m.Value(10): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := m.Value(25), 0.0; g != e {
+		t.Errorf("m.Value(25): got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestClampedSumAggregation(t *testing.T) {
+	newEngine := func(aggregation AggregationFunc) *Engine {
+		engine := NewEngine(Centroid(100)).WithAggregation(aggregation)
+
+		engine.Variables(
+			NewVariable(
+				"temperature",
+				NewTerm("cold", Inverted(Linear(0, 10))),
+				NewTerm("cool", Triangular(0, 5, 15)),
+				NewTerm("mild", Triangular(0, 10, 20)),
+			),
+			NewVariable(
+				"heating",
+				NewTerm("high", Triangular(50, 75, 100)),
+				NewTerm("low", Triangular(0, 25, 50)),
+			),
+		)
+
+		// Three overlapping rules firing the same term
+		engine.Rules(
+			If(Is("temperature", "cold")).Then("heating", "high"),
+			If(Is("temperature", "cool")).Then("heating", "high"),
+			If(Is("temperature", "mild")).Then("heating", "high"),
+		)
+
+		return engine
+	}
+
+	aggregateAt := func(engine *Engine) Membership {
+		results, err := engine.Infer(Values{"temperature": 5})
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		variable, err := engine.variable("heating")
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		return engine.aggregate(variable, results["heating"])
+	}
+
+	clamped := aggregateAt(newEngine(ClampedSumAggregation))
+	unclamped := aggregateAt(newEngine(SumAggregation))
+
+	exceeded := false
+	for x := 0.0; x <= 100; x += 0.5 {
+		if g := clamped.Value(x); g > 1 {
+			t.Fatalf("clamped.Value(%v): got '%v', expected at most '%v'", x, g, 1.0)
+		}
+
+		if unclamped.Value(x) > 1 {
+			exceeded = true
+		}
+	}
+
+	if !exceeded {
+		t.Errorf("expected the unclamped sum to exceed 1.0 at some point")
+	}
+}
+
+func TestTermWeight(t *testing.T) {
+	newEngine := func(weight float64) *Engine {
+		return NewEngine(Centroid(1000)).
+			Variables(
+				NewVariable("pressure", NewTerm("high", Linear(0, 100))),
+				NewVariable(
+					"valve",
+					NewTerm("normal", Triangular(0, 25, 50)),
+					NewTerm("emergency", Triangular(50, 75, 100)).WithWeight(weight),
+				),
+			).
+			Rules(
+				If(Not(Is("pressure", "high"))).Then("valve", "normal"),
+				If(Is("pressure", "high")).Then("valve", "emergency"),
+			)
+	}
+
+	defuzzifyAt := func(engine *Engine) float64 {
+		results, err := engine.Infer(Values{"pressure": 50})
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		value, err := engine.Defuzzify("valve", results)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		return value
+	}
+
+	if g, e := defuzzifyAt(newEngine(1)), 50.0; math.Abs(g-e) > 0.1 {
+		t.Errorf("engine.Defuzzify(): got '%v', expected '%v'", g, e)
+	}
+
+	// The emergency term counts twice as much as the normal one: (25*1 + 75*2) / 3
+	if g, e := defuzzifyAt(newEngine(2)), 175.0/3; math.Abs(g-e) > 0.1 {
+		t.Errorf("engine.Defuzzify(): got '%v', expected '%v'", g, e)
+	}
+}
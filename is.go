@@ -7,6 +7,10 @@ import (
 type IsExpr struct {
 	variable string
 	term     string
+
+	// Crisp value, for Takagi-Sugeno conclusions (see SetValue())
+	crisp bool
+	value float64
 }
 
 func (e *IsExpr) Variable() string {
@@ -17,7 +21,17 @@ func (e *IsExpr) Term() string {
 	return e.term
 }
 
+// CrispValue returns the value set by a crisp conclusion, and false if the
+// expression refers to a term
+func (e *IsExpr) CrispValue() (float64, bool) {
+	return e.value, e.crisp
+}
+
 func (e *IsExpr) Value(ctx *Context) (float64, error) {
+	if degree, cached := ctx.cachedMembership(e.variable, e.term); cached {
+		return degree, nil
+	}
+
 	variable, err := ctx.Variable(e.variable)
 	if err != nil {
 		return 0, errors.WithStack(err)
@@ -33,11 +47,21 @@ func (e *IsExpr) Value(ctx *Context) (float64, error) {
 		return 0, errors.WithStack(err)
 	}
 
-	return term.Membership().Value(value), nil
+	degree := term.Membership().Value(value)
+	ctx.cacheMembership(e.variable, e.term, degree)
+
+	return degree, nil
 }
 
 func Is(variable string, term string) *IsExpr {
-	return &IsExpr{variable, term}
+	return &IsExpr{variable: variable, term: term}
 }
 
 var Set = Is
+
+// SetValue returns a crisp conclusion setting the variable to the value (Takagi-Sugeno rule).
+// Its term is the formatted value, e.g. "80", which is also the key of the
+// conclusion result in the inference results.
+func SetValue(variable string, value float64) *IsExpr {
+	return &IsExpr{variable: variable, term: formatValue(value), crisp: true, value: value}
+}
@@ -0,0 +1,181 @@
+package fuzzy
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type jsonVariable struct {
+	Name     string            `json:"name"`
+	Terms    []*Term           `json:"terms"`
+	Universe []float64         `json:"universe,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+type jsonRule struct {
+	ruleDefinition
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+type jsonEngine struct {
+	Variables []*Variable `json:"variables"`
+	Rules     []*Rule     `json:"rules"`
+}
+
+type jsonTerm struct {
+	Name       string               `json:"name"`
+	Domain     []float64            `json:"domain"`
+	Weight     float64              `json:"weight"`
+	Membership MembershipDefinition `json:"membership"`
+}
+
+// MarshalJSON implements json.Marshaler. Terms are emitted sorted by name.
+func (v *Variable) MarshalJSON() ([]byte, error) {
+	terms := v.Terms()
+	slices.SortFunc(terms, func(a, b *Term) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	def := jsonVariable{
+		Name:     v.name,
+		Terms:    terms,
+		Metadata: v.metadata,
+	}
+
+	if v.customUniverse {
+		def.Universe = []float64{v.universeMin, v.universeMax}
+	}
+
+	data, err := json.Marshal(def)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not marshal variable '%s'", v.name)
+	}
+
+	return data, nil
+}
+
+// MarshalJSON implements json.Marshaler. The membership is described by its kind and
+// parameters (see DescribeMembership()), an error wrapping ErrUnsupportedMembership
+// is returned for memberships which are not built-in.
+func (t *Term) MarshalJSON() ([]byte, error) {
+	membership, err := DescribeMembership(t.membership)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not describe membership of term '%s'", t.name)
+	}
+
+	min, max := t.Domain()
+
+	return json.Marshal(jsonTerm{
+		Name:       t.name,
+		Domain:     []float64{min, max},
+		Weight:     t.weight,
+		Membership: membership,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, the membership being rebuilt from
+// its "type" and "params" (see MembershipDefinition). The domain is ignored and
+// a missing weight defaults to 1.
+func (t *Term) UnmarshalJSON(data []byte) error {
+	def := jsonTerm{Weight: 1}
+	if err := json.Unmarshal(data, &def); err != nil {
+		return errors.WithStack(err)
+	}
+
+	membership, err := def.Membership.Build()
+	if err != nil {
+		return errors.Wrapf(err, "could not build membership of term '%s'", def.Name)
+	}
+
+	*t = *NewTerm(def.Name, membership).WithWeight(def.Weight)
+
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It returns an error wrapping
+// ErrTermAlreadyExists if two terms share the same name.
+func (v *Variable) UnmarshalJSON(data []byte) error {
+	var def jsonVariable
+	if err := json.Unmarshal(data, &def); err != nil {
+		return errors.WithStack(err)
+	}
+
+	variable, err := NewVariableChecked(def.Name, def.Terms...)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := applyUniverse(variable, def.Universe); err != nil {
+		return errors.WithStack(err)
+	}
+
+	variable.metadata = def.Metadata
+	*v = *variable
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The premise is described as a tree of
+// expressions, i.e. {"type": "AND", "children": [{"type": "IS", "variable": "x", "term": "a"}, ...]}.
+// Only the built-in expressions are supported, an error wrapping ErrUnsupportedExpr
+// is returned otherwise.
+func (r *Rule) MarshalJSON() ([]byte, error) {
+	def, err := describeRule(r)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not describe rule '%s'", r)
+	}
+
+	return json.Marshal(jsonRule{
+		ruleDefinition: def,
+		Metadata:       r.metadata,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A missing weight defaults to 1.
+func (r *Rule) UnmarshalJSON(data []byte) error {
+	def := jsonRule{ruleDefinition: ruleDefinition{Weight: 1}}
+	if err := json.Unmarshal(data, &def); err != nil {
+		return errors.WithStack(err)
+	}
+
+	rule, err := def.build()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	rule.metadata = def.Metadata
+	*r = *rule
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, emitting the engine variables and rules
+// in the format expected by EngineFromJSON(). The defuzzification function, the
+// implication, the aggregation and the norms are not part of the definition.
+func (e *Engine) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonEngine{
+		Variables: e.variables,
+		Rules:     e.rules,
+	})
+}
+
+// EngineFromJSON creates an engine from a JSON definition of its variables and rules,
+// i.e. the output of Engine.MarshalJSON(), using the default centroid defuzzification
+func EngineFromJSON(data []byte) (*Engine, error) {
+	var def jsonEngine
+	if err := json.Unmarshal(data, &def); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	engine := NewEngine(nil)
+	if err := engine.AddVariables(def.Variables...); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	engine.Rules(def.Rules...)
+
+	return engine, nil
+}
@@ -0,0 +1,247 @@
+package fuzzy
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestVariableMarshalJSON(t *testing.T) {
+	variable := NewVariable(
+		"temperature",
+		NewTerm("hot", Linear(20, 30)).WithWeight(2),
+		NewTerm("cold", Inverted(Linear(0, 10))),
+		NewTerm("mild", Max(Triangular(5, 15, 25), Trapezoid(10, 15, 20, 25))),
+	).WithMetadata("unit", "celsius")
+
+	data, err := json.Marshal(variable)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	var decoded struct {
+		Name  string `json:"name"`
+		Terms []struct {
+			Name       string               `json:"name"`
+			Domain     []float64            `json:"domain"`
+			Weight     float64              `json:"weight"`
+			Membership MembershipDefinition `json:"membership"`
+		} `json:"terms"`
+		Metadata map[string]string `json:"metadata"`
+	}
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := decoded.Name, "temperature"; g != e {
+		t.Errorf("decoded.Name: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := decoded.Metadata, variable.Metadata(); !reflect.DeepEqual(g, e) {
+		t.Errorf("decoded.Metadata: got '%v', expected '%v'", g, e)
+	}
+
+	// Terms are sorted by name
+	expectedNames := []string{"cold", "hot", "mild"}
+
+	if g, e := len(decoded.Terms), len(expectedNames); g != e {
+		t.Fatalf("len(decoded.Terms): got '%v', expected '%v'", g, e)
+	}
+
+	for i, term := range decoded.Terms {
+		if g, e := term.Name, expectedNames[i]; g != e {
+			t.Errorf("term #%d name: got '%v', expected '%v'", i, g, e)
+		}
+
+		original, err := variable.Term(term.Name)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if g, e := term.Weight, original.Weight(); g != e {
+			t.Errorf("term '%s' weight: got '%v', expected '%v'", term.Name, g, e)
+		}
+
+		min, max := original.Domain()
+		if g, e := term.Domain, []float64{min, max}; !reflect.DeepEqual(g, e) {
+			t.Errorf("term '%s' domain: got '%v', expected '%v'", term.Name, g, e)
+		}
+
+		membership, err := term.Membership.Build()
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		for x := 0.0; x <= 30; x += 2.5 {
+			if g, e := membership.Value(x), original.Membership().Value(x); g != e {
+				t.Errorf("term '%s' membership.Value(%v): got '%v', expected '%v'", term.Name, x, g, e)
+			}
+		}
+	}
+
+	if g, e := decoded.Terms[0].Membership.Type, KindInverted; g != e {
+		t.Errorf("cold membership type: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := decoded.Terms[1].Membership.Params, []float64{20, 30}; !reflect.DeepEqual(g, e) {
+		t.Errorf("hot membership params: got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestTermMarshalJSONUnsupported(t *testing.T) {
+	_, err := json.Marshal(NewTerm("custom", &customMembership{}))
+	if !errors.Is(err, ErrUnsupportedMembership) {
+		t.Errorf("json.Marshal(): got '%v', expected '%v'", err, ErrUnsupportedMembership)
+	}
+}
+
+func TestEngineJSONRoundTrip(t *testing.T) {
+	engine := NewEngine(nil).
+		Variables(
+			NewVariable(
+				"temperature",
+				NewTerm("cold", Inverted(Linear(0, 10))),
+				NewTerm("hot", Linear(20, 30)),
+			).WithMetadata("unit", "celsius"),
+			NewVariable(
+				"heating",
+				NewTerm("low", Triangular(0, 25, 50)),
+				NewTerm("high", Trapezoid(50, 60, 90, 100)).WithWeight(1.5),
+			),
+		).
+		Rules(
+			If(Very(Is("temperature", "cold"))).Then("heating", "high").Else("heating", "low").WithMetadata("id", "r1"),
+			If(And(Not(Is("temperature", "cold")), Or(Is("temperature", "hot"), Somewhat(Is("temperature", "hot"))))).Then("heating", "low").WithWeight(0.5),
+			If(Is("temperature", "hot")).ThenValue("fan_speed", 80),
+		)
+
+	data, err := json.Marshal(engine)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	restored, err := EngineFromJSON(data)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	for i, rule := range engine.rules {
+		if g, e := restored.rules[i].String(), rule.String(); g != e {
+			t.Errorf("rule #%d: got '%v', expected '%v'", i, g, e)
+		}
+
+		if g, e := restored.rules[i].Metadata(), rule.Metadata(); !reflect.DeepEqual(g, e) {
+			t.Errorf("rule #%d metadata: got '%v', expected '%v'", i, g, e)
+		}
+	}
+
+	if g, e := restored.variables[0].Metadata(), engine.variables[0].Metadata(); !reflect.DeepEqual(g, e) {
+		t.Errorf("variable metadata: got '%v', expected '%v'", g, e)
+	}
+
+	for _, temperature := range []float64{0, 5, 15, 25, 30} {
+		values := Values{"temperature": temperature}
+
+		expected, err := engine.Infer(values)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		results, err := restored.Infer(values)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		for _, variable := range []string{"heating", "fan_speed"} {
+			e, err := engine.Defuzzify(variable, expected)
+			if err != nil {
+				t.Fatalf("%+v", errors.WithStack(err))
+			}
+
+			g, err := restored.Defuzzify(variable, results)
+			if err != nil {
+				t.Fatalf("%+v", errors.WithStack(err))
+			}
+
+			if g != e {
+				t.Errorf("%s at temperature %v: got '%v', expected '%v'", variable, temperature, g, e)
+			}
+		}
+	}
+}
+
+func TestEngineFromJSON(t *testing.T) {
+	engine, err := EngineFromJSON([]byte(`{
+		"variables": [
+			{"name": "temperature", "terms": [{"name": "hot", "membership": {"type": "LINEAR", "params": [20, 30]}}]},
+			{"name": "ac_mode", "terms": [{"name": "cooling", "membership": {"type": "INVERTED", "children": [{"type": "LINEAR", "params": [-100, 0]}]}}]}
+		],
+		"rules": [
+			{
+				"premise": {"type": "IS", "variable": "temperature", "term": "hot"},
+				"conclusions": [{"variable": "ac_mode", "term": "cooling"}]
+			}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := engine.rules[0].Weight(), 1.0; g != e {
+		t.Errorf("rule weight: got '%v', expected '%v'", g, e)
+	}
+
+	term, err := engine.variables[0].Term("hot")
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := term.Weight(), 1.0; g != e {
+		t.Errorf("term weight: got '%v', expected '%v'", g, e)
+	}
+
+	results, err := engine.Infer(Values{"temperature": 25})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := results["ac_mode"]["cooling"].TruthDegree(), 0.5; g != e {
+		t.Errorf("ac_mode IS cooling: got '%v', expected '%v'", g, e)
+	}
+
+	testCases := []struct {
+		json     string
+		expected error
+	}{
+		{
+			json:     `{"variables": [{"name": "x", "terms": [{"name": "a", "membership": {"type": "GAUSSIAN", "params": [0, 1]}}]}]}`,
+			expected: ErrUnsupportedMembership,
+		},
+		{
+			json:     `{"variables": [{"name": "x", "terms": [{"name": "a", "membership": {"type": "LINEAR", "params": [0, 1]}}, {"name": "a", "membership": {"type": "LINEAR", "params": [1, 2]}}]}]}`,
+			expected: ErrTermAlreadyExists,
+		},
+		{
+			json:     `{"variables": [{"name": "x", "terms": []}, {"name": "x", "terms": []}]}`,
+			expected: ErrVariableAlreadyExists,
+		},
+		{
+			json:     `{"rules": [{"premise": {"type": "IMPLIES"}, "conclusions": [{"variable": "y", "term": "b"}]}]}`,
+			expected: ErrUnsupportedExpr,
+		},
+		{
+			json:     `{"rules": [{"premise": {"type": "WEIGHTED_AND", "weights": [1], "children": [{"type": "IS", "variable": "x", "term": "a"}, {"type": "IS", "variable": "x", "term": "b"}]}, "conclusions": [{"variable": "y", "term": "b"}]}]}`,
+			expected: ErrInvalidWeights,
+		},
+	}
+
+	for i, tc := range testCases {
+		_, err := EngineFromJSON([]byte(tc.json))
+		if !errors.Is(err, tc.expected) {
+			t.Errorf("test case #%d: got '%v', expected '%v'", i, err, tc.expected)
+		}
+	}
+}
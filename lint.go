@@ -0,0 +1,209 @@
+package fuzzy
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Warning is a potential issue of an engine definition reported by Lint().
+// Unlike validation errors, warnings do not prevent the inference.
+type Warning struct {
+	// Index of the rule raising the warning, -1 if the warning is not related to a rule
+	Rule     int
+	Variable string
+	Message  string
+}
+
+func (w Warning) String() string {
+	if w.Rule >= 0 {
+		return fmt.Sprintf("rule #%d: %s", w.Rule, w.Message)
+	}
+
+	return w.Message
+}
+
+// Lint analyzes the given definition and reports its potential issues:
+//
+//   - Self-referential rules, whose premise references their conclusion variable
+//     (i.e. "IF ac_mode IS off THEN ac_mode IS heating"). In a single inference pass,
+//     the premise is evaluated against the input value of the variable, not against
+//     the result of the inference.
+//   - Contradictory rules, see Contradictions().
+//   - Variables never used in a premise nor concluded by any rule. Such a variable,
+//     if intended as an output, always defuzzifies to the midpoint of its universe.
+//
+// The premises are assumed to be evaluated with the default MinMax norms, see Engine.Lint().
+func Lint(variables []*Variable, rules []*Rule) []Warning {
+	return lint(variables, rules, MinMax)
+}
+
+// lint implements Lint() for premises evaluated with the given norms
+func lint(variables []*Variable, rules []*Rule, norms Norms) []Warning {
+	warnings := make([]Warning, 0)
+
+	used := make(map[string]struct{})
+
+	for i, r := range rules {
+		for _, name := range premiseVariables(r.premise) {
+			used[name] = struct{}{}
+		}
+
+		for _, c := range slices.Concat(r.conclusions, r.alternatives) {
+			output := c.Variable()
+			used[output] = struct{}{}
+
+			if slices.Contains(premiseVariables(r.premise), output) {
+				warnings = append(warnings, Warning{
+					Rule:     i,
+					Variable: output,
+					Message:  fmt.Sprintf("premise references the conclusion variable '%s'", output),
+				})
+			}
+		}
+	}
+
+	warnings = append(warnings, contradictions(rules, norms)...)
+
+	for _, v := range variables {
+		if _, exists := used[v.Name()]; exists {
+			continue
+		}
+
+		warnings = append(warnings, Warning{
+			Rule:     -1,
+			Variable: v.Name(),
+			Message:  fmt.Sprintf("variable '%s' is neither used in a premise nor concluded by any rule", v.Name()),
+		})
+	}
+
+	return warnings
+}
+
+// Lint analyzes the engine definition and reports its potential issues, comparing the
+// premises under the engine norms. See Lint().
+func (e *Engine) Lint() []Warning {
+	return lint(e.variables, e.rules, e.norms)
+}
+
+// Contradictions reports the rules sharing an identical premise with a previous rule
+// but concluding a different term for the same output variable, i.e.
+// "IF x IS a THEN y IS b" and "IF x IS a THEN y IS c".
+//
+// Premises are compared in a canonical form where the operands of AND/OR expressions
+// are sorted, so "x IS a AND z IS d" is identical to "z IS d AND x IS a", and deduplicated
+// under the default MinMax norms, see Norms.Idempotent.
+// The operands of XOR expressions are kept duplicated, and only sorted if there are two
+// of them: as XOR is evaluated from left to right, XOR(a, b, c) may differ from XOR(b, a, c).
+// Computed expressions (see Func()) are compared by identity, as their functions cannot
+// be: only the same *FuncExpr shared by several rules makes their premises identical.
+// Premises merely subsuming each other are not reported.
+func Contradictions(rules []*Rule) []Warning {
+	return contradictions(rules, MinMax)
+}
+
+// contradictions implements Contradictions() for premises evaluated with the given norms
+func contradictions(rules []*Rule, norms Norms) []Warning {
+	warnings := make([]Warning, 0)
+
+	type conclusion struct {
+		rule int
+		term string
+	}
+
+	// Previous conclusions indexed by premise and output variable
+	seen := make(map[[2]string]conclusion)
+
+	for i, r := range rules {
+		premise := canonicalExpr(r.premise, norms.Idempotent)
+
+		for _, c := range r.conclusions {
+			key := [2]string{premise, c.Variable()}
+
+			previous, exists := seen[key]
+			if !exists {
+				seen[key] = conclusion{rule: i, term: c.Term()}
+				continue
+			}
+
+			if previous.term == c.Term() {
+				continue
+			}
+
+			warnings = append(warnings, Warning{
+				Rule:     i,
+				Variable: c.Variable(),
+				Message: fmt.Sprintf(
+					"premise is identical to rule #%d but concludes '%s IS %s' instead of '%s IS %s'",
+					previous.rule, c.Variable(), c.Term(), c.Variable(), previous.term,
+				),
+			})
+		}
+	}
+
+	return warnings
+}
+
+// canonicalExpr returns a textual form of the expression where the operands of
+// AND/OR expressions are sorted, and deduplicated if the norms are idempotent
+func canonicalExpr(e Expr, idempotent bool) string {
+	canonicalOperands := func(operator string, exprs []Expr, sorted, dedupe bool) string {
+		operands := make([]string, 0, len(exprs))
+		for _, e := range exprs {
+			operands = append(operands, canonicalExpr(e, idempotent))
+		}
+
+		if sorted {
+			slices.Sort(operands)
+		}
+
+		// Duplicated operands are only redundant for idempotent norms, i.e.
+		// a AND a is not a with the product, and XOR(a, a) is never a
+		if dedupe {
+			operands = slices.Compact(operands)
+		}
+
+		if len(operands) == 1 {
+			return operands[0]
+		}
+
+		return operator + "(" + strings.Join(operands, ", ") + ")"
+	}
+
+	switch typ := e.(type) {
+	case *AndExpr:
+		return canonicalOperands("AND", typ.exprs, true, idempotent)
+	case *OrExpr:
+		return canonicalOperands("OR", typ.exprs, true, idempotent)
+	case *XorExpr:
+		// XOR is folded from left to right, which is only commutative for two operands
+		return canonicalOperands("XOR", typ.exprs, len(typ.exprs) == 2, false)
+	case *NotExpr:
+		return "NOT(" + canonicalExpr(typ.expr, idempotent) + ")"
+	case *HedgeExpr:
+		return formatHedge(typ) + "(" + canonicalExpr(typ.expr, idempotent) + ")"
+	case *FuncExpr:
+		// Two functions on the same inputs may compute unrelated degrees
+		return fmt.Sprintf("FUNC(%p)", typ)
+	default:
+		return formatExpr(e)
+	}
+}
+
+// premiseVariables returns the names of the variables referenced in the expression
+func premiseVariables(e Expr) []string {
+	var variables []string
+
+	Walk(e, func(e Expr) {
+		switch typ := e.(type) {
+		case *IsExpr:
+			variables = append(variables, typ.variable)
+		case *CompareExpr:
+			variables = append(variables, typ.variable)
+		case *FuncExpr:
+			variables = append(variables, typ.dependencies...)
+		}
+	})
+
+	return variables
+}
@@ -0,0 +1,173 @@
+package fuzzy
+
+import "testing"
+
+func TestLintSelfReferentialRule(t *testing.T) {
+	engine := NewEngine(nil)
+
+	engine.Variables(
+		NewVariable("temperature", NewTerm("cold", Inverted(Linear(0, 10)))),
+		NewVariable(
+			"ac_mode",
+			NewTerm("off", Triangular(-50, 0, 50)),
+			NewTerm("heating", Linear(0, 100)),
+		),
+	)
+
+	engine.Rules(
+		If(Is("temperature", "cold")).Then("ac_mode", "heating"),
+		If(And(Is("temperature", "cold"), Not(Is("ac_mode", "off")))).Then("ac_mode", "heating"),
+	)
+
+	warnings := engine.Lint()
+
+	if g, e := len(warnings), 1; g != e {
+		t.Fatalf("len(warnings): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := warnings[0].Rule, 1; g != e {
+		t.Errorf("warnings[0].Rule: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := warnings[0].Variable, "ac_mode"; g != e {
+		t.Errorf("warnings[0].Variable: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := warnings[0].String(), "rule #1: premise references the conclusion variable 'ac_mode'"; g != e {
+		t.Errorf("warnings[0].String(): got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestLintUnusedVariable(t *testing.T) {
+	engine := NewEngine(nil)
+
+	engine.Variables(
+		NewVariable("temperature", NewTerm("cold", Inverted(Linear(0, 10))), NewTerm("hot", Linear(20, 30))),
+		NewVariable("heating", NewTerm("on", Linear(0, 100))),
+		NewVariable("cooling", NewTerm("on", Linear(0, 100))),
+	)
+
+	// The cooling rule is missing
+	engine.Rules(
+		If(Is("temperature", "cold")).Then("heating", "on"),
+	)
+
+	warnings := engine.Lint()
+
+	if g, e := len(warnings), 1; g != e {
+		t.Fatalf("len(warnings): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := warnings[0].Rule, -1; g != e {
+		t.Errorf("warnings[0].Rule: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := warnings[0].Variable, "cooling"; g != e {
+		t.Errorf("warnings[0].Variable: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := warnings[0].String(), "variable 'cooling' is neither used in a premise nor concluded by any rule"; g != e {
+		t.Errorf("warnings[0].String(): got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestContradictions(t *testing.T) {
+	rules := []*Rule{
+		If(And(Is("x", "a"), Is("z", "d"))).Then("y", "b"),
+		If(Is("x", "a")).Then("y", "c"),
+		If(And(Is("z", "d"), Is("x", "a"))).Then("y", "c"),
+		If(And(Is("z", "d"), Is("x", "a"))).Then("y", "b"),
+		If(And(Is("z", "d"), Is("x", "a"))).Then("w", "c"),
+	}
+
+	warnings := Contradictions(rules)
+
+	if g, e := len(warnings), 1; g != e {
+		t.Fatalf("len(warnings): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := warnings[0].Rule, 2; g != e {
+		t.Errorf("warnings[0].Rule: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := warnings[0].String(), "rule #2: premise is identical to rule #0 but concludes 'y IS c' instead of 'y IS b'"; g != e {
+		t.Errorf("warnings[0].String(): got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestContradictionsFuncExpr(t *testing.T) {
+	hot := Func(func(ctx *Context) (float64, error) { return 1, nil }, "x", "z")
+	cold := Func(func(ctx *Context) (float64, error) { return 0, nil }, "x", "z")
+
+	rules := []*Rule{
+		If(hot).Then("y", "b"),
+		If(cold).Then("y", "c"),
+		If(hot).Then("y", "c"),
+	}
+
+	warnings := Contradictions(rules)
+
+	// Only the rules sharing the same function contradict each other
+	if g, e := len(warnings), 1; g != e {
+		t.Fatalf("len(warnings): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := warnings[0].Rule, 2; g != e {
+		t.Errorf("warnings[0].Rule: got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestContradictionsXor(t *testing.T) {
+	rules := []*Rule{
+		If(Is("x", "a")).Then("y", "b"),
+		If(Xor(Is("x", "a"), Is("x", "a"))).Then("y", "c"),
+		If(Xor(Is("z", "d"), Is("x", "a"))).Then("y", "b"),
+		If(Xor(Is("x", "a"), Is("z", "d"))).Then("y", "c"),
+	}
+
+	warnings := Contradictions(rules)
+
+	// XOR(x IS a, x IS a) is not x IS a, but its operands are still unordered
+	if g, e := len(warnings), 1; g != e {
+		t.Fatalf("len(warnings): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := warnings[0].Rule, 3; g != e {
+		t.Errorf("warnings[0].Rule: got '%v', expected '%v'", g, e)
+	}
+
+	// XOR is folded from left to right, so the order of more than two operands matters
+	rules = []*Rule{
+		If(Xor(Is("x", "a"), Is("z", "d"), Is("w", "e"))).Then("y", "b"),
+		If(Xor(Is("z", "d"), Is("x", "a"), Is("w", "e"))).Then("y", "c"),
+	}
+
+	if g, e := len(Contradictions(rules)), 0; g != e {
+		t.Errorf("len(Contradictions()): got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestContradictionsNorms(t *testing.T) {
+	rules := []*Rule{
+		If(Is("x", "a")).Then("y", "b"),
+		If(And(Is("x", "a"), Is("x", "a"))).Then("y", "c"),
+	}
+
+	// x IS a AND x IS a is x IS a with the minimum
+	if g, e := len(Contradictions(rules)), 1; g != e {
+		t.Errorf("len(Contradictions()): got '%v', expected '%v'", g, e)
+	}
+
+	// but its square with the product
+	engine := NewEngine(nil).
+		WithNorms(ProductProbabilisticSum).
+		Variables(
+			NewVariable("x", NewTerm("a", Linear(0, 1))),
+			NewVariable("y", NewTerm("b", Linear(0, 1)), NewTerm("c", Linear(0, 1))),
+		).
+		Rules(rules...)
+
+	if g, e := len(engine.Lint()), 0; g != e {
+		t.Errorf("len(engine.Lint()): got '%v', expected '%v'", g, e)
+	}
+}
@@ -182,6 +182,111 @@ func Trapezoid(x1, x2, x3, x4 float64) *TrapezoidalMembership {
 	return &TrapezoidalMembership{x1, x2, x3, x4}
 }
 
+// RectangularMembership is a crisp interval, both bounds included
+type RectangularMembership struct {
+	a float64
+	b float64
+}
+
+func (m *RectangularMembership) Value(x float64) float64 {
+	if m.a <= x && x <= m.b {
+		return 1
+	}
+
+	return 0
+}
+
+func (m *RectangularMembership) Domain() (float64, float64) {
+	return m.a, m.b
+}
+
+// Rectangular returns a membership of 1 for a <= x <= b and 0 otherwise
+func Rectangular(a, b float64) *RectangularMembership {
+	return &RectangularMembership{a, b}
+}
+
+// SMembership is a smooth ascending transition from 0 at a to 1 at b,
+// made of two quadratic pieces meeting at the midpoint
+type SMembership struct {
+	a float64
+	b float64
+}
+
+func (m *SMembership) Value(x float64) float64 {
+	return sCurve(m.a, m.b, x)
+}
+
+func (m *SMembership) Domain() (float64, float64) {
+	return m.a, m.b
+}
+
+func SCurve(a, b float64) *SMembership {
+	return &SMembership{a, b}
+}
+
+// ZMembership is a smooth descending transition from 1 at a to 0 at b,
+// mirroring the SMembership
+type ZMembership struct {
+	a float64
+	b float64
+}
+
+func (m *ZMembership) Value(x float64) float64 {
+	return 1 - sCurve(m.a, m.b, x)
+}
+
+func (m *ZMembership) Domain() (float64, float64) {
+	return m.a, m.b
+}
+
+func ZCurve(a, b float64) *ZMembership {
+	return &ZMembership{a, b}
+}
+
+func sCurve(a, b, x float64) float64 {
+	switch {
+	case x <= a:
+		return 0
+	case x >= b:
+		return 1
+	case x <= (a+b)/2:
+		r := (x - a) / (b - a)
+		return 2 * r * r
+	default:
+		r := (x - b) / (b - a)
+		return 1 - 2*r*r
+	}
+}
+
+// SigmoidMembership is a saturating membership, ascending for a positive slope
+// and descending for a negative one
+type SigmoidMembership struct {
+	a float64
+	c float64
+}
+
+func (m *SigmoidMembership) Value(x float64) float64 {
+	return 1 / (1 + math.Exp(-m.a*(x-m.c)))
+}
+
+// Domain brackets the transition region of the sigmoid, i.e. c ± 6/|a|,
+// where its value goes from ~0.0025 to ~0.9975
+func (m *SigmoidMembership) Domain() (float64, float64) {
+	if m.a == 0 {
+		return m.c, m.c
+	}
+
+	width := 6 / math.Abs(m.a)
+
+	return m.c - width, m.c + width
+}
+
+// Sigmoid returns the membership 1/(1+exp(-a*(x-c))), with a the slope
+// and c the crossover point, where the membership is 0.5
+func Sigmoid(a, c float64) *SigmoidMembership {
+	return &SigmoidMembership{a, c}
+}
+
 func membershipsDomain(memberships []Membership) (float64, float64) {
 	min := math.Inf(1)
 	max := math.Inf(-1)
@@ -194,3 +299,68 @@ func membershipsDomain(memberships []Membership) (float64, float64) {
 
 	return min, max
 }
+
+type ScaledMembership struct {
+	membership Membership
+	factor     float64
+}
+
+func (m *ScaledMembership) Value(x float64) float64 {
+	return m.factor * m.membership.Value(x)
+}
+
+func (m *ScaledMembership) Domain() (float64, float64) {
+	return m.membership.Domain()
+}
+
+func Scale(m Membership, factor float64) *ScaledMembership {
+	return &ScaledMembership{m, factor}
+}
+
+type SumMembership struct {
+	memberships []Membership
+}
+
+func (m *SumMembership) Value(x float64) float64 {
+	sum := 0.0
+	for _, mm := range m.memberships {
+		sum += mm.Value(x)
+	}
+
+	return sum
+}
+
+func (m *SumMembership) Domain() (float64, float64) {
+	return membershipsDomain(m.memberships)
+}
+
+func Sum(memberships ...Membership) *SumMembership {
+	return &SumMembership{memberships}
+}
+
+type ClampedMembership struct {
+	membership Membership
+}
+
+func (m *ClampedMembership) Value(x float64) float64 {
+	return math.Max(0, math.Min(1, m.membership.Value(x)))
+}
+
+func (m *ClampedMembership) Domain() (float64, float64) {
+	return m.membership.Domain()
+}
+
+// Clamped bounds the values of the given membership to [0, 1]
+func Clamped(m Membership) *ClampedMembership {
+	return &ClampedMembership{m}
+}
+
+// Value32 evaluates the membership at x for float32 based ports of the engine.
+//
+// The membership is computed with the default float64 arithmetic, x being converted
+// exactly to float64, and the result is then rounded to the nearest float32. It is thus the
+// correctly rounded reference value, which may differ in the last bits from a computation
+// performed entirely with float32 arithmetic.
+func Value32(m Membership, x float32) float32 {
+	return float32(m.Value(float64(x)))
+}
@@ -1,6 +1,9 @@
 package fuzzy
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestTriangular(t *testing.T) {
 	triangular := Triangular(-1, 0, 1)
@@ -171,3 +174,113 @@ func TestTrapezoidAsTriangle(t *testing.T) {
 		t.Errorf("trapezoidAsTriangle(25): got '%v', expected '%v'", g, e)
 	}
 }
+
+func TestValue32(t *testing.T) {
+	triangular := Triangular(0, 10, 20)
+
+	if g, e := Value32(triangular, 5), float32(0.5); g != e {
+		t.Errorf("Value32(triangular, 5): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := Value32(triangular, 25), float32(0); g != e {
+		t.Errorf("Value32(triangular, 25): got '%v', expected '%v'", g, e)
+	}
+
+	linear := Linear(0, 3)
+
+	if g, e := Value32(linear, 1), float32(1.0/3.0); g != e {
+		t.Errorf("Value32(linear, 1): got '%v', expected '%v'", g, e)
+	}
+
+	// x is converted exactly, i.e. float32(0.1) is not 0.1
+	if g, e := Value32(linear, 0.1), float32(float64(float32(0.1))/3); g != e {
+		t.Errorf("Value32(linear, 0.1): got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestSigmoid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		sigmoid  *SigmoidMembership
+		x        float64
+		expected float64
+	}{
+		{name: "ascending at crossover", sigmoid: Sigmoid(2, 10), x: 10, expected: 0.5},
+		{name: "ascending below crossover", sigmoid: Sigmoid(2, 10), x: 7, expected: 1 / (1 + math.Exp(6))},
+		{name: "ascending above crossover", sigmoid: Sigmoid(2, 10), x: 13, expected: 1 / (1 + math.Exp(-6))},
+		{name: "descending at crossover", sigmoid: Sigmoid(-2, 10), x: 10, expected: 0.5},
+		{name: "descending below crossover", sigmoid: Sigmoid(-2, 10), x: 7, expected: 1 / (1 + math.Exp(-6))},
+		{name: "descending above crossover", sigmoid: Sigmoid(-2, 10), x: 13, expected: 1 / (1 + math.Exp(6))},
+	}
+
+	for _, tc := range testCases {
+		if g, e := tc.sigmoid.Value(tc.x), tc.expected; math.Abs(g-e) > 1e-12 {
+			t.Errorf("%s: sigmoid(%v): got '%v', expected '%v'", tc.name, tc.x, g, e)
+		}
+	}
+
+	for _, sigmoid := range []*SigmoidMembership{Sigmoid(2, 10), Sigmoid(-2, 10)} {
+		min, max := sigmoid.Domain()
+		if g, e := min, 7.0; g != e {
+			t.Errorf("sigmoid domain min: got '%v', expected '%v'", g, e)
+		}
+
+		if g, e := max, 13.0; g != e {
+			t.Errorf("sigmoid domain max: got '%v', expected '%v'", g, e)
+		}
+	}
+}
+
+func TestRectangular(t *testing.T) {
+	rectangular := Rectangular(10, 20)
+
+	testCases := []struct {
+		x        float64
+		expected float64
+	}{
+		{x: 9.999, expected: 0},
+		{x: 10, expected: 1},
+		{x: 15, expected: 1},
+		{x: 20, expected: 1},
+		{x: 20.001, expected: 0},
+	}
+
+	for _, tc := range testCases {
+		if g, e := rectangular.Value(tc.x), tc.expected; g != e {
+			t.Errorf("rectangular(%v): got '%v', expected '%v'", tc.x, g, e)
+		}
+	}
+
+	min, max := rectangular.Domain()
+	if min != 10 || max != 20 {
+		t.Errorf("rectangular domain: got '(%v, %v)', expected '(10, 20)'", min, max)
+	}
+}
+
+func TestSZCurves(t *testing.T) {
+	s := SCurve(10, 20)
+	z := ZCurve(10, 20)
+
+	testCases := []struct {
+		x float64
+		s float64
+	}{
+		{x: 5, s: 0},
+		{x: 10, s: 0},
+		{x: 12.5, s: 0.125},
+		{x: 15, s: 0.5},
+		{x: 17.5, s: 0.875},
+		{x: 20, s: 1},
+		{x: 25, s: 1},
+	}
+
+	for _, tc := range testCases {
+		if g, e := s.Value(tc.x), tc.s; g != e {
+			t.Errorf("scurve(%v): got '%v', expected '%v'", tc.x, g, e)
+		}
+
+		if g, e := z.Value(tc.x), 1-tc.s; g != e {
+			t.Errorf("zcurve(%v): got '%v', expected '%v'", tc.x, g, e)
+		}
+	}
+}
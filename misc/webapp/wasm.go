@@ -23,7 +23,7 @@ import (
 func main() {
 	document := js.Global().Get("document")
 
-	engine := fuzzy.NewEngine(fuzzy.Centroid(100))
+	engine := fuzzy.NewEngineWith(fuzzy.NamedCentroid(100))
 
 	definitionTextArea := document.Call("getElementById", "definition")
 	inputsTextArea := document.Call("getElementById", "inputs")
@@ -58,11 +58,16 @@ func main() {
 			return false
 		}
 
-		printConsole("Definition OK !")
-
 		engine.Rules(result.Rules...)
 		engine.Variables(result.Variables...)
 
+		if err := engine.Validate(); err != nil {
+			printConsole(fmt.Sprintf("Invalid definition: %v", err))
+			return false
+		}
+
+		printConsole("Definition OK !")
+
 		return true
 	}
 
@@ -0,0 +1,41 @@
+package fuzzy
+
+import "math"
+
+// TNorm combines two truth degrees as a fuzzy AND
+type TNorm func(a, b float64) float64
+
+// SNorm combines two truth degrees as a fuzzy OR
+type SNorm func(a, b float64) float64
+
+// Norms are the operators used by the AND and OR expressions
+type Norms struct {
+	TNorm TNorm
+	SNorm SNorm
+	// Idempotent is true if the norms satisfy a AND a = a and a OR a = a, as
+	// MinMax does. The linter then ignores duplicated AND/OR operands.
+	Idempotent bool
+}
+
+var (
+	// MinMax uses the minimum for AND and the maximum for OR (Zadeh operators), the default
+	MinMax = Norms{
+		TNorm:      math.Min,
+		SNorm:      math.Max,
+		Idempotent: true,
+	}
+
+	// ProductProbabilisticSum uses the product for AND and the probabilistic sum, a + b - a*b,
+	// for OR. Every operand contributes to the result, which blends them more smoothly.
+	ProductProbabilisticSum = Norms{
+		TNorm: func(a, b float64) float64 { return a * b },
+		SNorm: func(a, b float64) float64 { return a + b - a*b },
+	}
+
+	// LukasiewiczNorms uses the bounded difference, max(0, a + b - 1), for AND
+	// and the bounded sum, min(1, a + b), for OR
+	LukasiewiczNorms = Norms{
+		TNorm: func(a, b float64) float64 { return math.Max(0, a+b-1) },
+		SNorm: func(a, b float64) float64 { return math.Min(1, a+b) },
+	}
+)
@@ -0,0 +1,61 @@
+package fuzzy
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestNorms(t *testing.T) {
+	type testCase struct {
+		Name          string
+		Norms         Norms
+		ExpectedAnd   float64
+		ExpectedOr    float64
+		ExpectDefault bool
+	}
+
+	testCases := []testCase{
+		{Name: "default", ExpectedAnd: 0.4, ExpectedOr: 0.5, ExpectDefault: true},
+		{Name: "min-max", Norms: MinMax, ExpectedAnd: 0.4, ExpectedOr: 0.5},
+		{Name: "product", Norms: ProductProbabilisticSum, ExpectedAnd: 0.2, ExpectedOr: 0.7},
+		{Name: "lukasiewicz", Norms: LukasiewiczNorms, ExpectedAnd: 0, ExpectedOr: 0.9},
+		{Name: "zero", Norms: Norms{}, ExpectedAnd: 0.4, ExpectedOr: 0.5},
+		{Name: "nil-snorm", Norms: Norms{TNorm: ProductProbabilisticSum.TNorm}, ExpectedAnd: 0.2, ExpectedOr: 0.5},
+		{Name: "nil-tnorm", Norms: Norms{SNorm: ProductProbabilisticSum.SNorm}, ExpectedAnd: 0.4, ExpectedOr: 0.7},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			engine := NewEngine(nil)
+			if !tc.ExpectDefault {
+				engine.WithNorms(tc.Norms)
+			}
+
+			engine.Variables(
+				NewVariable("a", NewTerm("high", Linear(0, 10))),
+				NewVariable("b", NewTerm("high", Linear(0, 10))),
+				NewVariable("c", NewTerm("and", Linear(0, 1)), NewTerm("or", Linear(0, 1))),
+			)
+
+			engine.Rules(
+				If(And(Is("a", "high"), Is("b", "high"))).Then("c", "and"),
+				If(Or(Is("a", "high"), Is("b", "high"))).Then("c", "or"),
+			)
+
+			results, err := engine.Infer(Values{"a": 4, "b": 5})
+			if err != nil {
+				t.Fatalf("%+v", errors.WithStack(err))
+			}
+
+			if e, g := tc.ExpectedAnd, results["c"]["and"].TruthDegree(); math.Abs(e-g) > 1e-9 {
+				t.Errorf("and: got '%v', expected '%v'", g, e)
+			}
+
+			if e, g := tc.ExpectedOr, results["c"]["or"].TruthDegree(); math.Abs(e-g) > 1e-9 {
+				t.Errorf("or: got '%v', expected '%v'", g, e)
+			}
+		})
+	}
+}
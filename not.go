@@ -17,6 +17,10 @@ func (e *NotExpr) Value(ctx *Context) (float64, error) {
 	return 1 - v, nil
 }
 
+func (e *NotExpr) Expr() Expr {
+	return e.expr
+}
+
 func Not(m Expr) *NotExpr {
 	return &NotExpr{m}
 }
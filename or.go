@@ -1,8 +1,6 @@
 package fuzzy
 
 import (
-	"math"
-
 	"github.com/pkg/errors"
 )
 
@@ -10,19 +8,25 @@ type OrExpr struct {
 	exprs []Expr
 }
 
+// Value combines the operands with the s-norm of the context, the maximum by default
 func (e *OrExpr) Value(ctx *Context) (float64, error) {
-	max := math.Inf(-1) // Initialize to negative infinity
+	var result float64
 
-	for _, m := range e.exprs {
+	for i, m := range e.exprs {
 		v, err := m.Value(ctx)
 		if err != nil {
 			return 0, errors.WithStack(err)
 		}
 
-		max = math.Max(max, v)
+		if i == 0 {
+			result = v
+			continue
+		}
+
+		result = ctx.norms.SNorm(result, v)
 	}
 
-	return max, nil
+	return result, nil
 }
 
 func (e *OrExpr) Exprs() []Expr {
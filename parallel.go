@@ -0,0 +1,60 @@
+package fuzzy
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// WithParallelism evaluates the premises of the rules with n concurrent goroutines on
+// each inference, for engines with many independent rules. The premises being evaluated
+// upfront, the results are then added serially in the order of the rules, so that the
+// results and the errors are identical to the serial inference.
+//
+// Unlike the serial inference, all the premises are evaluated even when a latch (see
+// WithLatch()) stops the inference: the functions of Func() then run for the rules past
+// the latch, their errors being ignored. These functions must also be safe for concurrent
+// use. Values of n below 2 disable the parallel evaluation, which is the default.
+func (e *Engine) WithParallelism(n int) *Engine {
+	e.parallelism = n
+	return e
+}
+
+// evaluatePremises returns the truth degrees of the premises of the rules and their
+// errors, in the order of the rules, evaluated by a pool of e.parallelism goroutines.
+// The errors are reported per rule, to be returned only if the inference reaches the
+// failing rule. It returns the error of runCtx if it is done before the end.
+func (e *Engine) evaluatePremises(runCtx context.Context, ctx *Context) ([]float64, []error, error) {
+	truthDegrees := make([]float64, len(e.rules))
+	errs := make([]error, len(e.rules))
+
+	workers := min(e.parallelism, len(e.rules))
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// Each goroutine evaluates every n-th rule, so that the rules are split
+			// without synchronization
+			for i := w; i < len(e.rules); i += workers {
+				if runCtx.Err() != nil {
+					return
+				}
+
+				truthDegrees[i], errs[i] = e.rules[i].premise.Value(ctx)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if err := runCtx.Err(); err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	return truthDegrees, errs, nil
+}
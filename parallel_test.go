@@ -0,0 +1,164 @@
+package fuzzy
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// newLargeEngine returns an engine with the given number of rules, each one
+// concluding a distinct term from a combination of the inputs
+func newLargeEngine(totalRules int) *Engine {
+	temperature := NewVariable(
+		"temperature",
+		NewTerm("cold", Inverted(Linear(0, 15))),
+		NewTerm("mild", Triangular(10, 20, 30)),
+		NewTerm("hot", Linear(25, 40)),
+	)
+
+	humidity := NewVariable(
+		"humidity",
+		NewTerm("dry", Inverted(Linear(20, 50))),
+		NewTerm("humid", Linear(40, 80)),
+	)
+
+	outputTerms := make([]*Term, 0, 10)
+	for i := 0; i < 10; i++ {
+		outputTerms = append(outputTerms, NewTerm(fmt.Sprintf("level_%d", i), Triangular(float64(i*10), float64(i*10+5), float64(i*10+10))))
+	}
+
+	temperatures := []string{"cold", "mild", "hot"}
+	humidities := []string{"dry", "humid"}
+
+	rules := make([]*Rule, 0, totalRules)
+	for i := 0; i < totalRules; i++ {
+		premise := And(
+			Is("temperature", temperatures[i%len(temperatures)]),
+			Or(Is("humidity", humidities[i%len(humidities)]), Very(Is("temperature", temperatures[(i/3)%len(temperatures)]))),
+		)
+
+		rules = append(rules, If(premise).Then("comfort", outputTerms[i%len(outputTerms)].Name()).WithWeight(float64(i%7+1)/7))
+	}
+
+	return NewEngine(Centroid(100)).
+		Variables(temperature, humidity, NewVariable("comfort", outputTerms...)).
+		Rules(rules...)
+}
+
+func TestParallelism(t *testing.T) {
+	serial := newLargeEngine(2000)
+	parallel := newLargeEngine(2000).WithParallelism(8)
+
+	for _, values := range []Values{
+		{"temperature": 5, "humidity": 30},
+		{"temperature": 22, "humidity": 45},
+		{"temperature": 35, "humidity": 70},
+	} {
+		expected, err := serial.Infer(values)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		results, err := parallel.Infer(values)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		for term, result := range expected["comfort"] {
+			if g, e := results["comfort"][term].TruthDegree(), result.TruthDegree(); g != e {
+				t.Errorf("%v: comfort IS %s: got '%v', expected '%v'", values, term, g, e)
+			}
+		}
+
+		e, err := serial.Defuzzify("comfort", expected)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		g, err := parallel.Defuzzify("comfort", results)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if g != e {
+			t.Errorf("%v: comfort: got '%v', expected '%v'", values, g, e)
+		}
+	}
+
+	// Errors are reported like in the serial inference
+	if _, err := parallel.Infer(Values{"temperature": 5}); !errors.Is(err, ErrValueNotFound) {
+		t.Errorf("parallel.Infer(): got error '%v', expected '%v'", err, ErrValueNotFound)
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := parallel.InferContext(runCtx, Values{"temperature": 5, "humidity": 30}); !errors.Is(err, context.Canceled) {
+		t.Errorf("parallel.InferContext(): got error '%v', expected '%v'", err, context.Canceled)
+	}
+}
+
+func TestParallelismLatch(t *testing.T) {
+	var evaluated atomic.Int32
+
+	failing := Func(func(ctx *Context) (float64, error) {
+		evaluated.Add(1)
+		return 0, errors.New("unexpected evaluation")
+	})
+
+	engine := NewEngine(Centroid(100)).
+		Variables(
+			NewVariable("temperature", NewTerm("critical", Linear(80, 100))),
+			NewVariable("shutdown", NewTerm("true", Linear(0, 1))),
+			NewVariable("fan_speed", NewTerm("high", Linear(0, 100))),
+		).
+		Rules(
+			If(Is("temperature", "critical")).Then("shutdown", "true"),
+			If(failing).Then("fan_speed", "high"),
+		).
+		WithLatch("shutdown", LatchAbove("true", 0.9)).
+		WithParallelism(2)
+
+	// The error of the rule past the latch is ignored, as in the serial inference...
+	if _, err := engine.Infer(Values{"temperature": 100}); err != nil {
+		t.Errorf("engine.Infer(): %+v", errors.WithStack(err))
+	}
+
+	// ... but its premise is still evaluated
+	if g, e := evaluated.Load(), int32(1); g != e {
+		t.Errorf("evaluated rules: got '%v', expected '%v'", g, e)
+	}
+
+	if _, err := engine.Infer(Values{"temperature": 50}); err == nil {
+		t.Errorf("engine.Infer(): expected the error of the failing rule")
+	}
+}
+
+func BenchmarkInferSerial(b *testing.B) {
+	engine := newLargeEngine(5000)
+	values := Values{"temperature": 22, "humidity": 45}
+
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		if _, err := engine.Infer(values); err != nil {
+			b.Fatalf("%+v", errors.WithStack(err))
+		}
+	}
+}
+
+func BenchmarkInferParallel(b *testing.B) {
+	engine := newLargeEngine(5000).WithParallelism(8)
+	values := Values{"temperature": 22, "humidity": 45}
+
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		if _, err := engine.Infer(values); err != nil {
+			b.Fatalf("%+v", errors.WithStack(err))
+		}
+	}
+}
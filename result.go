@@ -1,14 +1,23 @@
 package fuzzy
 
-import "sort"
+import (
+	"math"
+	"sort"
+
+	"github.com/pkg/errors"
+)
 
 type Results map[string]map[string]Result
 
+// Best returns the result of the variable with the highest truth degree, and false
+// if the variable has no result with a truth degree above zero. Ties are broken
+// deterministically, in favor of the lowest term name in lexicographic order.
 func (r Results) Best(variable string) (*Result, bool) {
 	var best *Result
 
-	for _, res := range r[variable] {
-		if best == nil || res.TruthDegree() > best.TruthDegree() {
+	for term, res := range r[variable] {
+		if best == nil || res.TruthDegree() > best.TruthDegree() ||
+			(res.TruthDegree() == best.TruthDegree() && term < best.Term()) {
 			best = &res
 		}
 	}
@@ -20,6 +29,47 @@ func (r Results) Best(variable string) (*Result, bool) {
 	return best, true
 }
 
+// DefuzzifyWith computes the crisp output value of the variable from the results,
+// without the engine which produced them. It behaves like Engine.Defuzzify() with the
+// default max aggregation, including the midpoint fallback when the variable has no
+// results. A nil defuzzifier defaults to the centroid, as in NewEngine().
+func (r Results) DefuzzifyWith(variable *Variable, defuzzify Defuzzifier) (float64, error) {
+	if variable == nil {
+		return 0, errors.WithStack(ErrUndefinedVariable)
+	}
+
+	if fn, ok := defuzzify.(DefuzzifyFunc); defuzzify == nil || (ok && fn == nil) {
+		defuzzify = defaultDefuzzifier()
+	}
+
+	value, err := defuzzifyResults(variable, r[variable.Name()], defuzzify, MaxAggregation, false)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	return value, nil
+}
+
+// Activation returns the highest truth degree of the results of the variable,
+// 0 if the variable has no result
+func (r Results) Activation(variable string) float64 {
+	activation := 0.0
+	for _, res := range r[variable] {
+		activation = math.Max(activation, res.TruthDegree())
+	}
+	return activation
+}
+
+// TotalActivation returns the sum of the truth degrees of the results of the variable,
+// 0 if the variable has no result
+func (r Results) TotalActivation(variable string) float64 {
+	total := 0.0
+	for _, res := range r[variable] {
+		total += res.TruthDegree()
+	}
+	return total
+}
+
 func (r Results) Variables() []string {
 	variables := make([]string, 0, len(r))
 	for name := range r {
@@ -33,6 +83,10 @@ type Result struct {
 	term        string
 	truthDegree float64
 	membership  Membership
+
+	// Crisp conclusion value, for Takagi-Sugeno rules
+	crisp bool
+	value float64
 }
 
 func (r Result) Term() string {
@@ -43,10 +97,17 @@ func (r Result) TruthDegree() float64 {
 	return r.truthDegree
 }
 
+// Membership returns the implied membership of the term, nil for crisp conclusions
 func (r Result) Membership() Membership {
 	return r.membership
 }
 
+// Value returns the crisp value of the conclusion, and false if the result
+// concludes a term
+func (r Result) Value() (float64, bool) {
+	return r.value, r.crisp
+}
+
 func NewResult(term string, thruthDegree float64, membership Membership) Result {
 	return Result{
 		term:        term,
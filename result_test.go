@@ -0,0 +1,167 @@
+package fuzzy
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestResultsBest(t *testing.T) {
+	results := Results{
+		"ac_mode": {
+			"heating": Result{term: "heating", truthDegree: 0.2},
+			"stopped": Result{term: "stopped", truthDegree: 0.6},
+			"cooling": Result{term: "cooling", truthDegree: 0.6},
+			"eco":     Result{term: "eco", truthDegree: 0.6},
+		},
+		"fan": {
+			"slow": Result{term: "slow", truthDegree: 0},
+		},
+	}
+
+	// Map iteration order is randomized, the tie must be broken the same way every time
+	for i := 0; i < 100; i++ {
+		best, ok := results.Best("ac_mode")
+		if !ok {
+			t.Fatalf("results.Best(): expected a result")
+		}
+
+		if g, e := best.Term(), "cooling"; g != e {
+			t.Fatalf("results.Best(): got '%v', expected '%v'", g, e)
+		}
+	}
+
+	if _, ok := results.Best("fan"); ok {
+		t.Errorf("results.Best(): expected no result for a zero truth degree")
+	}
+
+	if _, ok := results.Best("undefined"); ok {
+		t.Errorf("results.Best(): expected no result for an undefined variable")
+	}
+}
+
+func TestResultsDefuzzifyWith(t *testing.T) {
+	temperature := NewVariable(
+		"temperature",
+		NewTerm("cold", Inverted(Linear(0, 15))),
+		NewTerm("hot", Linear(25, 40)),
+	)
+
+	heating := NewVariable(
+		"heating",
+		NewTerm("low", Triangular(0, 25, 50)),
+		NewTerm("high", Triangular(50, 75, 100)).WithWeight(2),
+	)
+
+	fan := NewVariable("fan", NewTerm("slow", Triangular(0, 20, 40)))
+
+	engine := NewEngine(MeanOfMaximum(100)).
+		Variables(temperature, heating, fan).
+		Rules(
+			If(Is("temperature", "cold")).Then("heating", "high"),
+			If(Not(Is("temperature", "cold"))).Then("heating", "low"),
+			If(Is("temperature", "hot")).ThenValue("fan", 80),
+			If(Is("temperature", "hot")).Then("fan", "slow"),
+		)
+
+	for _, value := range []float64{0, 10, 20, 30, 40} {
+		results, err := engine.Infer(Values{"temperature": value})
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		for _, variable := range []*Variable{heating, fan} {
+			expected, err := engine.Defuzzify(variable.Name(), results)
+			if err != nil {
+				t.Fatalf("%+v", errors.WithStack(err))
+			}
+
+			value, err := results.DefuzzifyWith(variable, MeanOfMaximum(100))
+			if err != nil {
+				t.Fatalf("%+v", errors.WithStack(err))
+			}
+
+			if g, e := value, expected; g != e {
+				t.Errorf("results.DefuzzifyWith(%s): got '%v', expected '%v'", variable.Name(), g, e)
+			}
+		}
+	}
+
+	results, err := engine.Infer(Values{"temperature": 20})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	// No fan rule fired: midpoint of the fan universe
+	value, err := results.DefuzzifyWith(fan, nil)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := value, 20.0; g != e {
+		t.Errorf("results.DefuzzifyWith(fan): got '%v', expected '%v'", g, e)
+	}
+
+	// No rule concludes on the pump: midpoint of the pump universe
+	pump := NewVariable("pump", NewTerm("on", Linear(0, 10)))
+
+	value, err = results.DefuzzifyWith(pump, nil)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := value, 5.0; g != e {
+		t.Errorf("results.DefuzzifyWith(pump): got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestResultsActivation(t *testing.T) {
+	engine := NewEngine(nil).
+		Variables(
+			NewVariable(
+				"temperature",
+				NewTerm("cold", Inverted(Linear(0, 20))),
+				NewTerm("hot", Linear(0, 20)),
+				NewTerm("scorching", Linear(30, 40)),
+			),
+			NewVariable(
+				"heating",
+				NewTerm("low", Triangular(0, 25, 50)),
+				NewTerm("high", Triangular(50, 75, 100)),
+			),
+			NewVariable("fan", NewTerm("fast", Linear(0, 100))),
+		).
+		Rules(
+			If(Is("temperature", "cold")).Then("heating", "high"),
+			If(Is("temperature", "hot")).Then("heating", "low"),
+			If(Very(Is("temperature", "hot"))).Then("fan", "fast"),
+			If(Is("temperature", "scorching")).Then("fan", "fast"),
+		)
+
+	// cold: 0.25, hot: 0.75, very hot: 0.5625, scorching: 0
+	results, err := engine.Infer(Values{"temperature": 15})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	testCases := []struct {
+		variable   string
+		activation float64
+		total      float64
+	}{
+		{variable: "heating", activation: 0.75, total: 1},
+		{variable: "fan", activation: 0.5625, total: 0.5625},
+		{variable: "undefined", activation: 0, total: 0},
+	}
+
+	for _, tc := range testCases {
+		if g, e := results.Activation(tc.variable), tc.activation; math.Abs(g-e) > 1e-9 {
+			t.Errorf("results.Activation(%s): got '%v', expected '%v'", tc.variable, g, e)
+		}
+
+		if g, e := results.TotalActivation(tc.variable), tc.total; math.Abs(g-e) > 1e-9 {
+			t.Errorf("results.TotalActivation(%s): got '%v', expected '%v'", tc.variable, g, e)
+		}
+	}
+}
@@ -1,30 +1,189 @@
 package fuzzy
 
+import (
+	"math"
+	"slices"
+	"strconv"
+	"time"
+)
+
 type Rule struct {
-	premise    Expr
-	conclusion *IsExpr
+	premise      Expr
+	conclusions  []*IsExpr
+	alternatives []*IsExpr
+	metadata     map[string]string
+	weight       float64
+	priority     int
+
+	timestamp time.Time
+	halfLife  time.Duration
 }
 
 func (r *Rule) Premise() Expr {
 	return r.premise
 }
 
+// Conclusion returns the first conclusion of the rule, nil if the rule has none.
+// See Conclusions().
 func (r *Rule) Conclusion() *IsExpr {
-	return r.conclusion
+	if len(r.conclusions) == 0 {
+		return nil
+	}
+
+	return r.conclusions[0]
 }
 
+// Conclusions returns the conclusions of the rule, in declaration order
+func (r *Rule) Conclusions() []*IsExpr {
+	return r.conclusions
+}
+
+// Then adds a conclusion to the rule, i.e. If(...).Then("ac_mode", "cooling").Then("fan", "high")
+// sets both outputs at once
 func (r *Rule) Then(variable string, term string) *Rule {
-	r.conclusion = Set(variable, term)
+	r.conclusions = append(r.conclusions, Set(variable, term))
+
+	return r
+}
+
+// ThenValue adds a crisp conclusion to the rule (Takagi-Sugeno rule), see SetValue()
+func (r *Rule) ThenValue(variable string, value float64) *Rule {
+	r.conclusions = append(r.conclusions, SetValue(variable, value))
+
+	return r
+}
+
+// Else adds an alternative conclusion to the rule, i.e. If(...).Then("ac_mode", "cooling").Else("ac_mode", "off").
+// The alternative conclusions fire with the complement of the premise truth degree
+// and only contribute when the premise is not fully true.
+func (r *Rule) Else(variable string, term string) *Rule {
+	r.alternatives = append(r.alternatives, Set(variable, term))
 
 	return r
 }
 
-func NewRule(premise Expr, conclusion *IsExpr) *Rule {
-	return &Rule{premise, conclusion}
+// ElseValue adds a crisp alternative conclusion to the rule, see Else() and SetValue()
+func (r *Rule) ElseValue(variable string, value float64) *Rule {
+	r.alternatives = append(r.alternatives, SetValue(variable, value))
+
+	return r
+}
+
+// Alternatives returns the ELSE conclusions of the rule, in declaration order
+func (r *Rule) Alternatives() []*IsExpr {
+	return r.alternatives
+}
+
+// concludes returns true if one of the rule conclusions, or alternative
+// conclusions, is on the variable
+func (r *Rule) concludes(variable string) bool {
+	for _, c := range slices.Concat(r.conclusions, r.alternatives) {
+		if c.Variable() == variable {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Value returns the crisp value concluded by the first conclusion of the rule,
+// and false if it concludes a term
+func (r *Rule) Value() (float64, bool) {
+	if len(r.conclusions) == 0 {
+		return 0, false
+	}
+
+	return r.conclusions[0].CrispValue()
+}
+
+// Metadata returns the arbitrary key/value pairs attached to the rule.
+// Metadata are ignored by the inference.
+func (r *Rule) Metadata() map[string]string {
+	return r.metadata
+}
+
+// WithMetadata attaches an arbitrary key/value pair to the rule
+func (r *Rule) WithMetadata(key, value string) *Rule {
+	if r.metadata == nil {
+		r.metadata = make(map[string]string)
+	}
+
+	r.metadata[key] = value
+
+	return r
+}
+
+// Weight returns the confidence in the rule, the factor applied to its
+// firing strength. Defaults to 1.
+func (r *Rule) Weight() float64 {
+	return r.weight
+}
+
+// WithWeight sets the confidence in the rule, i.e. a rule weighted 0.7 only
+// contributes 0.7 of its firing strength
+func (r *Rule) WithWeight(weight float64) *Rule {
+	r.weight = weight
+	return r
+}
+
+// Priority returns the priority of the rule, used to resolve the conflicts between
+// the rules concluding the same output variable with HighestPriorityWins. Defaults to 0.
+func (r *Rule) Priority() int {
+	return r.priority
+}
+
+// WithPriority sets the priority of the rule, see Engine.WithConflictResolution()
+func (r *Rule) WithPriority(priority int) *Rule {
+	r.priority = priority
+	return r
+}
+
+// WithDecay makes the firing strength of the rule decay over time when inferred
+// with Engine.InferAt(), the evidence behind the rule dating from timestamp and
+// losing half of its strength every halfLife
+func (r *Rule) WithDecay(timestamp time.Time, halfLife time.Duration) *Rule {
+	r.timestamp = timestamp
+	r.halfLife = halfLife
+	return r
+}
+
+// Decay returns the timestamp and the half-life of the rule,
+// a zero half-life meaning that the rule does not decay
+func (r *Rule) Decay() (time.Time, time.Duration) {
+	return r.timestamp, r.halfLife
+}
+
+// DecayFactor returns the factor applied to the firing strength of the rule at the given time,
+// i.e. 0.5^((now - timestamp) / halfLife). Rules without half-life, or whose timestamp
+// is after now, do not decay.
+func (r *Rule) DecayFactor(now time.Time) float64 {
+	if r.halfLife <= 0 {
+		return 1
+	}
+
+	age := now.Sub(r.timestamp)
+	if age <= 0 {
+		return 1
+	}
+
+	return math.Pow(0.5, float64(age)/float64(r.halfLife))
+}
+
+func NewRule(premise Expr, conclusions ...*IsExpr) *Rule {
+	return &Rule{
+		premise:     premise,
+		conclusions: conclusions,
+		weight:      1,
+	}
 }
 
 func If(expr Expr) *Rule {
 	return &Rule{
 		premise: expr,
+		weight:  1,
 	}
 }
+
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
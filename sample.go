@@ -0,0 +1,82 @@
+package fuzzy
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+const sampleOutputSteps = 1000
+
+// SampleOutput interprets the aggregated output membership of the given variable
+// as a probability density and draws a crisp value from it by inverse transform sampling.
+//
+// The aggregated membership is normalized by its area over the variable's universe
+// (numerically integrated with 1000 steps). If the variable has no results, or if the
+// aggregated membership is zero everywhere on the universe, the universe midpoint is
+// returned, as Engine.Defuzzify() does.
+//
+// If rng is nil, the default source of the math/rand package is used.
+func (e *Engine) SampleOutput(variable string, results Results, rng *rand.Rand) (float64, error) {
+	targetVariable, err := e.variable(variable)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	min, max := targetVariable.UniverseMin(), targetVariable.UniverseMax()
+	midpoint := (min + max) / 2
+
+	variableResults, ok := results[variable]
+	if !ok || len(variableResults) == 0 || min >= max {
+		return midpoint, nil
+	}
+
+	if hasCrispResults(variableResults) {
+		return 0, errors.Wrapf(ErrUnsupportedConclusion, "crisp conclusions on variable '%s'", variable)
+	}
+
+	membership := e.aggregate(targetVariable, variableResults)
+
+	step := (max - min) / sampleOutputSteps
+
+	// Cumulative area of the aggregated membership, using the trapezoidal rule
+	cumulative := make([]float64, sampleOutputSteps+1)
+	previous := membership.Value(min)
+	for i := 1; i <= sampleOutputSteps; i++ {
+		current := membership.Value(min + float64(i)*step)
+		cumulative[i] = cumulative[i-1] + (previous+current)/2*step
+		previous = current
+	}
+
+	total := cumulative[sampleOutputSteps]
+	if total <= 0 {
+		return midpoint, nil
+	}
+
+	var u float64
+	if rng != nil {
+		u = rng.Float64()
+	} else {
+		u = rand.Float64()
+	}
+
+	target := u * total
+
+	i := sort.SearchFloat64s(cumulative, target)
+	if i == 0 {
+		return min, nil
+	}
+
+	if i > sampleOutputSteps {
+		return max, nil
+	}
+
+	// Linear interpolation inside the selected step
+	x := min + float64(i-1)*step
+	if area := cumulative[i] - cumulative[i-1]; area > 0 {
+		x += (target - cumulative[i-1]) / area * step
+	}
+
+	return x, nil
+}
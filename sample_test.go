@@ -0,0 +1,82 @@
+package fuzzy
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestSampleOutput(t *testing.T) {
+	engine := NewEngine(Centroid(100))
+
+	engine.Variables(
+		NewVariable(
+			"input",
+			NewTerm("low", Inverted(Linear(0, 10))),
+			NewTerm("high", Linear(0, 10)),
+		),
+		NewVariable(
+			"output",
+			NewTerm("left", Triangular(0, 10, 20)),
+			NewTerm("right", Triangular(80, 90, 100)),
+		),
+	)
+
+	engine.Rules(
+		If(Is("input", "low")).Then("output", "left"),
+		If(Is("input", "high")).Then("output", "right"),
+	)
+
+	results, err := engine.Infer(Values{"input": 0})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	rng := rand.New(rand.NewSource(42))
+
+	var sum float64
+	const total = 2000
+	for i := 0; i < total; i++ {
+		x, err := engine.SampleOutput("output", results, rng)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if x < 0 || x > 20 {
+			t.Fatalf("engine.SampleOutput(): got '%v', expected a value in the support of 'left' [0, 20]", x)
+		}
+
+		sum += x
+	}
+
+	if g, e := sum/total, 10.0; math.Abs(g-e) > 0.5 {
+		t.Errorf("engine.SampleOutput() mean: got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestSampleOutputWithoutResults(t *testing.T) {
+	engine := NewEngine(Centroid(100))
+
+	engine.Variables(
+		NewVariable(
+			"output",
+			NewTerm("left", Triangular(0, 10, 20)),
+			NewTerm("right", Triangular(80, 90, 100)),
+		),
+	)
+
+	x, err := engine.SampleOutput("output", Results{}, nil)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := x, 50.0; g != e {
+		t.Errorf("engine.SampleOutput(): got '%v', expected '%v'", g, e)
+	}
+
+	if _, err := engine.SampleOutput("unknown", Results{}, nil); !errors.Is(err, ErrUndefinedVariable) {
+		t.Errorf("engine.SampleOutput(\"unknown\"): got error '%v', expected '%v'", err, ErrUndefinedVariable)
+	}
+}
@@ -0,0 +1,61 @@
+package fuzzy
+
+import (
+	"bytes"
+	"encoding/gob"
+
+	"github.com/pkg/errors"
+)
+
+// Snapshot serializes the engine variables and rules with encoding/gob.
+//
+// Only the built-in memberships and expressions can be captured, other
+// implementations result in an error. The defuzzification function is not
+// part of the snapshot.
+func (e *Engine) Snapshot() ([]byte, error) {
+	def, err := describeEngine(e)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var buf bytes.Buffer
+
+	if err := gob.NewEncoder(&buf).Encode(def); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Restore creates a new engine from a snapshot produced by Engine.Snapshot()
+// using the given defuzzification function.
+func Restore(snapshot []byte, defuzzify DefuzzifyFunc) (*Engine, error) {
+	var def engineDefinition
+
+	if err := gob.NewDecoder(bytes.NewReader(snapshot)).Decode(&def); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	engine, err := def.build(defuzzify)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return engine, nil
+}
+
+// Replay restores the engine captured in the snapshot and runs the inference
+// with the given inputs, reproducing the results offline.
+func Replay(snapshot []byte, inputs Values) (Results, error) {
+	engine, err := Restore(snapshot, nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	results, err := engine.Infer(inputs)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,212 @@
+package fuzzy
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestSnapshotReplay(t *testing.T) {
+	engine := NewEngine(Centroid(100))
+
+	engine.Variables(
+		NewVariable(
+			"temperature",
+			NewTerm("cold", Inverted(Linear(-10, 10))),
+			NewTerm("comfortable", Trapezoid(5, 18, 22, 25)),
+			NewTerm("hot", Linear(20, 30)),
+		),
+		NewVariable(
+			"humidity",
+			NewTerm("dry", Max(Constant(0), Inverted(Linear(20, 40)))),
+			NewTerm("wet", Min(Constant(1), Linear(60, 80))),
+		),
+		NewVariable(
+			"ac_mode",
+			NewTerm("heating", Linear(0, 100)),
+			NewTerm("off", Triangular(-50, 0, 50)),
+			NewTerm("cooling", Inverted(Linear(-100, 0))),
+		),
+	)
+
+	engine.Rules(
+		If(Is("temperature", "cold")).Then("ac_mode", "heating").ThenValue("fan_speed", 20).WithWeight(0.8),
+		If(And(Is("temperature", "comfortable"), Not(Is("humidity", "wet")))).Then("ac_mode", "off"),
+		If(Or(Is("temperature", "hot"), Is("humidity", "wet"))).Then("ac_mode", "cooling"),
+	)
+
+	snapshot, err := engine.Snapshot()
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	for _, inputs := range []Values{
+		{"temperature": -5, "humidity": 30},
+		{"temperature": 20, "humidity": 50},
+		{"temperature": 27, "humidity": 70},
+	} {
+		expected, err := engine.Infer(inputs)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		replayed, err := Replay(snapshot, inputs)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		for variable, terms := range expected {
+			for term, result := range terms {
+				if g, e := replayed[variable][term].TruthDegree(), result.TruthDegree(); g != e {
+					t.Errorf("replayed %s/%s truth degree with inputs %v: got '%v', expected '%v'", variable, term, inputs, g, e)
+				}
+			}
+		}
+
+		restored, err := Restore(snapshot, Centroid(100))
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		expectedValue, err := engine.Defuzzify("ac_mode", expected)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		replayedValue, err := restored.Defuzzify("ac_mode", replayed)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if g, e := replayedValue, expectedValue; g != e {
+			t.Errorf("replayed ac_mode value with inputs %v: got '%v', expected '%v'", inputs, g, e)
+		}
+	}
+}
+
+type customMembership struct{}
+
+func (m *customMembership) Value(x float64) float64    { return 0 }
+func (m *customMembership) Domain() (float64, float64) { return 0, 0 }
+
+func TestSnapshotUnsupportedMembership(t *testing.T) {
+	engine := NewEngine(nil)
+	engine.Variables(NewVariable("x", NewTerm("custom", &customMembership{})))
+
+	if _, err := engine.Snapshot(); !errors.Is(err, ErrUnsupportedMembership) {
+		t.Errorf("engine.Snapshot(): got error '%v', expected '%v'", err, ErrUnsupportedMembership)
+	}
+}
+
+func TestSnapshotZeroWeights(t *testing.T) {
+	engine := NewEngine(Centroid(100)).
+		Variables(
+			NewVariable("temperature", NewTerm("hot", Linear(20, 30)).WithWeight(0)),
+			NewVariable("fan", NewTerm("fast", Linear(50, 100))),
+		).
+		Rules(
+			If(Is("temperature", "hot")).Then("fan", "fast").WithWeight(0),
+		)
+
+	snapshot, err := engine.Snapshot()
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	restored, err := Restore(snapshot, nil)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	data, err := json.Marshal(engine)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	decoded, err := EngineFromJSON(data)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	for name, e := range map[string]*Engine{"restored": restored, "decoded": decoded} {
+		if g, e := e.rules[0].Weight(), 0.0; g != e {
+			t.Errorf("%s rule weight: got '%v', expected '%v'", name, g, e)
+		}
+
+		term, err := e.variables[0].Term("hot")
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if g, e := term.Weight(), 0.0; g != e {
+			t.Errorf("%s term weight: got '%v', expected '%v'", name, g, e)
+		}
+	}
+}
+
+func TestSnapshotDecay(t *testing.T) {
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	engine := NewEngine(Centroid(100)).
+		Variables(
+			NewVariable("errors", NewTerm("many", Linear(0, 10))),
+			NewVariable("alert", NewTerm("raised", Linear(0, 1))),
+		).
+		Rules(
+			If(Is("errors", "many")).Then("alert", "raised").WithDecay(timestamp, time.Hour),
+		)
+
+	snapshot, err := engine.Snapshot()
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	restored, err := Restore(snapshot, nil)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	data, err := json.Marshal(engine)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	decoded, err := EngineFromJSON(data)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	for name, e := range map[string]*Engine{"restored": restored, "decoded": decoded} {
+		ts, halfLife := e.rules[0].Decay()
+
+		if !ts.Equal(timestamp) {
+			t.Errorf("%s rule timestamp: got '%v', expected '%v'", name, ts, timestamp)
+		}
+
+		if g, e := halfLife, time.Hour; g != e {
+			t.Errorf("%s rule half-life: got '%v', expected '%v'", name, g, e)
+		}
+
+		results, err := e.InferAt(Values{"errors": 10}, timestamp.Add(time.Hour))
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if g, e := results["alert"]["raised"].TruthDegree(), 0.5; g != e {
+			t.Errorf("%s alert IS raised: got '%v', expected '%v'", name, g, e)
+		}
+	}
+
+	// Rules without decay do not carry a timestamp
+	data, err = json.Marshal(NewEngine(nil).Rules(If(Is("errors", "many")).Then("alert", "raised")))
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if strings.Contains(string(data), "timestamp") {
+		t.Errorf("json.Marshal(): got '%s', expected no timestamp", data)
+	}
+}
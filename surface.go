@@ -0,0 +1,67 @@
+package fuzzy
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ControlSurface sweeps the inA and inB input variables across their universes and returns
+// the defuzzified value of the output variable for each combination, as a (steps+1)x(steps+1)
+// matrix where surface[i][j] is the output for the i-th value of inA and the j-th value of inB.
+// Both universe bounds are included.
+//
+// Every other variable of the engine is held at the midpoint of its universe.
+func (e *Engine) ControlSurface(output, inA, inB string, steps int) ([][]float64, error) {
+	if steps < 1 {
+		return nil, errors.Errorf("invalid steps '%d', expected at least 1", steps)
+	}
+
+	if _, err := e.variable(output); err != nil {
+		return nil, errors.Wrapf(err, "output variable '%s'", output)
+	}
+
+	variableA, err := e.variable(inA)
+	if err != nil {
+		return nil, errors.Wrapf(err, "input variable '%s'", inA)
+	}
+
+	variableB, err := e.variable(inB)
+	if err != nil {
+		return nil, errors.Wrapf(err, "input variable '%s'", inB)
+	}
+
+	values := make(Values, len(e.variables))
+	for _, v := range e.variables {
+		if v.Name() == output {
+			continue
+		}
+
+		values[v.Name()] = (v.UniverseMin() + v.UniverseMax()) / 2
+	}
+
+	stepA := (variableA.UniverseMax() - variableA.UniverseMin()) / float64(steps)
+	stepB := (variableB.UniverseMax() - variableB.UniverseMin()) / float64(steps)
+
+	surface := make([][]float64, steps+1)
+	for i := 0; i <= steps; i++ {
+		surface[i] = make([]float64, steps+1)
+		values[inA] = variableA.UniverseMin() + float64(i)*stepA
+
+		for j := 0; j <= steps; j++ {
+			values[inB] = variableB.UniverseMin() + float64(j)*stepB
+
+			results, err := e.Infer(values)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+
+			value, err := e.Defuzzify(output, results)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+
+			surface[i][j] = value
+		}
+	}
+
+	return surface, nil
+}
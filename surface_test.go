@@ -0,0 +1,118 @@
+package fuzzy
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestControlSurface(t *testing.T) {
+	engine := NewEngine(Centroid(100))
+
+	engine.Variables(
+		NewVariable(
+			"temperature",
+			NewTerm("cold", Inverted(Linear(0, 40))),
+			NewTerm("hot", Linear(0, 40)),
+		),
+		NewVariable(
+			"humidity",
+			NewTerm("dry", Inverted(Linear(0, 100))),
+			NewTerm("wet", Linear(0, 100)),
+		),
+		NewVariable(
+			"fan",
+			NewTerm("slow", Triangular(0, 25, 50)),
+			NewTerm("fast", Triangular(50, 75, 100)),
+		),
+	)
+
+	engine.Rules(
+		If(And(Is("temperature", "cold"), Is("humidity", "dry"))).Then("fan", "slow"),
+		If(Or(Is("temperature", "hot"), Is("humidity", "wet"))).Then("fan", "fast"),
+	)
+
+	const steps = 4
+
+	surface, err := engine.ControlSurface("fan", "temperature", "humidity", steps)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := len(surface), steps+1; g != e {
+		t.Fatalf("len(surface): got '%v', expected '%v'", g, e)
+	}
+
+	for i, row := range surface {
+		if g, e := len(row), steps+1; g != e {
+			t.Fatalf("len(surface[%d]): got '%v', expected '%v'", i, g, e)
+		}
+	}
+
+	// Cold and dry: slow fan
+	if g := surface[0][0]; g > 50 {
+		t.Errorf("surface[0][0]: got '%v', expected a slow fan value", g)
+	}
+
+	// Hot and wet: fast fan
+	if g := surface[steps][steps]; g < 50 {
+		t.Errorf("surface[%d][%d]: got '%v', expected a fast fan value", steps, steps, g)
+	}
+
+	// The fan speed increases with the temperature
+	for i := 1; i <= steps; i++ {
+		if surface[i][0] < surface[i-1][0] {
+			t.Errorf("surface[%d][0] = '%v', expected to be greater than surface[%d][0] = '%v'", i, surface[i][0], i-1, surface[i-1][0])
+		}
+	}
+
+	if _, err := engine.ControlSurface("fan", "unknown", "humidity", steps); !errors.Is(err, ErrUndefinedVariable) {
+		t.Errorf("engine.ControlSurface(): got error '%v', expected '%v'", err, ErrUndefinedVariable)
+	}
+}
+
+func TestInferNormalized(t *testing.T) {
+	engine := NewEngine(Centroid(100))
+
+	engine.Variables(
+		NewVariable(
+			"temperature",
+			NewTerm("cold", Inverted(Linear(-10, 30))),
+			NewTerm("hot", Linear(-10, 30)),
+		),
+		NewVariable(
+			"fan",
+			NewTerm("slow", Triangular(0, 25, 50)),
+			NewTerm("fast", Triangular(50, 75, 100)),
+		),
+	)
+
+	engine.Rules(
+		If(Is("temperature", "cold")).Then("fan", "slow"),
+		If(Is("temperature", "hot")).Then("fan", "fast"),
+	)
+
+	normalized, err := engine.InferNormalized(map[string]float64{"temperature": 0.75})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	absolute, err := engine.Infer(Values{"temperature": 20})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	for _, term := range []string{"slow", "fast"} {
+		if g, e := normalized["fan"][term].TruthDegree(), absolute["fan"][term].TruthDegree(); g != e {
+			t.Errorf("normalized[\"fan\"][%q].TruthDegree(): got '%v', expected '%v'", term, g, e)
+		}
+	}
+
+	if _, err := engine.InferNormalized(map[string]float64{"temperature": 1.5}); err == nil {
+		t.Errorf("engine.InferNormalized(): expected an error for a fraction outside [0, 1]")
+	}
+
+	if _, err := engine.InferNormalized(map[string]float64{"unknown": 0.5}); !errors.Is(err, ErrUndefinedVariable) {
+		t.Errorf("engine.InferNormalized(): got error '%v', expected '%v'", err, ErrUndefinedVariable)
+	}
+}
@@ -0,0 +1,42 @@
+package fuzzy
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Tables holds the memberships of a variable's terms sampled over its universe,
+// i.e. to be interpolated by devices unable to run the engine.
+//
+// The i-th value of each term is its membership degree at x = Min + i*Step.
+type Tables struct {
+	Min   float64              `json:"min"`
+	Max   float64              `json:"max"`
+	Step  float64              `json:"step"`
+	Terms map[string][]float64 `json:"terms"`
+}
+
+// ExportTables samples the memberships of the variable's terms at steps+1
+// regularly spaced points of its universe, both bounds included
+func ExportTables(v *Variable, steps int) (*Tables, error) {
+	if steps < 1 {
+		return nil, errors.Errorf("invalid steps '%d', expected at least 1", steps)
+	}
+
+	tables := &Tables{
+		Min:   v.UniverseMin(),
+		Max:   v.UniverseMax(),
+		Step:  (v.UniverseMax() - v.UniverseMin()) / float64(steps),
+		Terms: make(map[string][]float64, len(v.terms)),
+	}
+
+	for name, t := range v.terms {
+		values := make([]float64, steps+1)
+		for i := range values {
+			values[i] = t.Membership().Value(tables.Min + float64(i)*tables.Step)
+		}
+
+		tables.Terms[name] = values
+	}
+
+	return tables, nil
+}
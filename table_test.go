@@ -0,0 +1,61 @@
+package fuzzy
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestExportTables(t *testing.T) {
+	variable := NewVariable(
+		"temperature",
+		NewTerm("cold", Inverted(Linear(0, 20))),
+		NewTerm("hot", Linear(10, 40)),
+	)
+
+	tables, err := ExportTables(variable, 4)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := tables.Min, 0.0; g != e {
+		t.Errorf("tables.Min: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := tables.Max, 40.0; g != e {
+		t.Errorf("tables.Max: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := tables.Step, 10.0; g != e {
+		t.Errorf("tables.Step: got '%v', expected '%v'", g, e)
+	}
+
+	expected := map[string][]float64{
+		"cold": {1, 0.5, 0, 0, 0},
+		"hot":  {0, 0, 1.0 / 3, 2.0 / 3, 1},
+	}
+
+	if g, e := tables.Terms, expected; !reflect.DeepEqual(g, e) {
+		t.Errorf("tables.Terms: got '%v', expected '%v'", g, e)
+	}
+
+	data, err := json.Marshal(tables)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	var decoded Tables
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := decoded, *tables; !reflect.DeepEqual(g, e) {
+		t.Errorf("decoded tables: got '%v', expected '%v'", g, e)
+	}
+
+	if _, err := ExportTables(variable, 0); err == nil {
+		t.Errorf("ExportTables(variable, 0): expected an error")
+	}
+}
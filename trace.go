@@ -0,0 +1,73 @@
+package fuzzy
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Trace records the rules evaluated by an inference, in order, see Engine.InferWithTrace()
+type Trace []RuleTrace
+
+// RuleTrace records the evaluation of a rule
+type RuleTrace struct {
+	// Index is the position of the rule in the engine
+	Index int `json:"index"`
+	// Rule is the DSL-like text of the rule
+	Rule string `json:"rule"`
+	// TruthDegree is the truth degree of the premise
+	TruthDegree float64 `json:"truthDegree"`
+	// Strength is the factor applied to the truth degree, i.e. the rule weight
+	Strength float64 `json:"strength"`
+	// Conclusions are the results contributed by the rule
+	Conclusions []ConclusionTrace `json:"conclusions"`
+}
+
+// ConclusionTrace records a result contributed by a rule
+type ConclusionTrace struct {
+	Variable string `json:"variable"`
+	// Term is the concluded term, or the crisp value of Takagi-Sugeno conclusions
+	Term        string  `json:"term"`
+	TruthDegree float64 `json:"truthDegree"`
+	// Alternative is true for the ELSE conclusions of the rule
+	Alternative bool `json:"alternative,omitempty"`
+}
+
+// Fired returns true if the rule contributed a result with a non-zero truth degree
+func (t RuleTrace) Fired() bool {
+	for _, c := range t.Conclusions {
+		if c.TruthDegree > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Fired returns the traces of the rules that contributed a result with a non-zero truth degree
+func (t Trace) Fired() Trace {
+	fired := make(Trace, 0, len(t))
+
+	for _, r := range t {
+		if r.Fired() {
+			fired = append(fired, r)
+		}
+	}
+
+	return fired
+}
+
+// InferWithTrace runs the rules of the engine on the given values, like Infer(), and
+// records the truth degree of each evaluated rule premise and the results it contributed.
+// Rules following a triggered latch are not evaluated, hence not traced.
+func (e *Engine) InferWithTrace(values Values) (Results, Trace, error) {
+	trace := make(Trace, 0, len(e.rules))
+
+	results, err := e.infer(context.Background(), values, time.Time{}, &trace)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	return results, trace, nil
+}
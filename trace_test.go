@@ -0,0 +1,109 @@
+package fuzzy
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestInferWithTrace(t *testing.T) {
+	engine := NewEngine(Centroid(100)).
+		Variables(
+			NewVariable("temperature",
+				NewTerm("cold", Inverted(Linear(0, 20))),
+				NewTerm("hot", Linear(10, 30)),
+			),
+			NewVariable("heating",
+				NewTerm("low", Inverted(Linear(0, 100))),
+				NewTerm("high", Linear(0, 100)),
+			),
+		).
+		Rules(
+			If(Is("temperature", "cold")).Then("heating", "high"),
+			If(Is("temperature", "hot")).Then("heating", "low").Else("heating", "high").WithWeight(0.5),
+			If(Very(Is("temperature", "hot"))).ThenValue("fan_speed", 80),
+		)
+
+	results, trace, err := engine.InferWithTrace(Values{"temperature": 5})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	expected, err := engine.Infer(Values{"temperature": 5})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := results["heating"]["high"].TruthDegree(), expected["heating"]["high"].TruthDegree(); g != e {
+		t.Errorf("heating IS high: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := len(trace), 3; g != e {
+		t.Fatalf("len(trace): got '%v', expected '%v'", g, e)
+	}
+
+	// cold: 0.75, hot: 0
+	expectedTrace := Trace{
+		{
+			Index: 0, Rule: "IF temperature IS cold THEN heating IS high", TruthDegree: 0.75, Strength: 1,
+			Conclusions: []ConclusionTrace{{Variable: "heating", Term: "high", TruthDegree: 0.75}},
+		},
+		{
+			Index: 1, Rule: "IF temperature IS hot THEN heating IS low ELSE heating IS high WEIGHT 0.5", TruthDegree: 0, Strength: 0.5,
+			Conclusions: []ConclusionTrace{
+				{Variable: "heating", Term: "low", TruthDegree: 0},
+				{Variable: "heating", Term: "high", TruthDegree: 0.5, Alternative: true},
+			},
+		},
+		{
+			Index: 2, Rule: "IF temperature IS VERY hot THEN fan_speed = 80", TruthDegree: 0, Strength: 1,
+			Conclusions: []ConclusionTrace{{Variable: "fan_speed", Term: "80", TruthDegree: 0}},
+		},
+	}
+
+	for i, e := range expectedTrace {
+		g := trace[i]
+
+		if g.Index != e.Index || g.Rule != e.Rule || g.Strength != e.Strength || math.Abs(g.TruthDegree-e.TruthDegree) > 1e-9 {
+			t.Errorf("trace[%d]: got '%+v', expected '%+v'", i, g, e)
+		}
+
+		if len(g.Conclusions) != len(e.Conclusions) {
+			t.Errorf("trace[%d].Conclusions: got '%+v', expected '%+v'", i, g.Conclusions, e.Conclusions)
+			continue
+		}
+
+		for j, c := range e.Conclusions {
+			gc := g.Conclusions[j]
+			if gc.Variable != c.Variable || gc.Term != c.Term || gc.Alternative != c.Alternative || math.Abs(gc.TruthDegree-c.TruthDegree) > 1e-9 {
+				t.Errorf("trace[%d].Conclusions[%d]: got '%+v', expected '%+v'", i, j, gc, c)
+			}
+		}
+	}
+
+	fired := trace.Fired()
+
+	if g, e := len(fired), 2; g != e {
+		t.Fatalf("len(trace.Fired()): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := fired[1].Index, 1; g != e {
+		t.Errorf("fired[1].Index: got '%v', expected '%v'", g, e)
+	}
+
+	data, err := json.Marshal(trace)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	var decoded Trace
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := decoded[1].Conclusions[1].Alternative, true; g != e {
+		t.Errorf("decoded alternative: got '%v', expected '%v'", g, e)
+	}
+}
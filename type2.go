@@ -0,0 +1,383 @@
+package fuzzy
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// Interval is an interval truth degree, as produced by an interval type-2 inference
+type Interval struct {
+	Lower float64
+	Upper float64
+}
+
+// Type2Term is an interval type-2 term, i.e. a term whose membership degree is
+// bounded by a lower and an upper membership function, the area between both
+// being the footprint of uncertainty of the term
+type Type2Term struct {
+	name  string
+	lower Membership
+	upper Membership
+}
+
+func (t *Type2Term) Name() string {
+	return t.name
+}
+
+func (t *Type2Term) Lower() Membership {
+	return t.lower
+}
+
+func (t *Type2Term) Upper() Membership {
+	return t.upper
+}
+
+// Value returns the interval membership degree of x
+func (t *Type2Term) Value(x float64) Interval {
+	lower, upper := t.lower.Value(x), t.upper.Value(x)
+	return Interval{Lower: math.Min(lower, upper), Upper: math.Max(lower, upper)}
+}
+
+func NewType2Term(name string, lower, upper Membership) *Type2Term {
+	return &Type2Term{
+		name:  name,
+		lower: lower,
+		upper: upper,
+	}
+}
+
+// Type2Variable is a variable made of interval type-2 terms
+type Type2Variable struct {
+	name  string
+	terms map[string]*Type2Term
+
+	universeMin float64
+	universeMax float64
+}
+
+func (v *Type2Variable) Name() string {
+	return v.name
+}
+
+func (v *Type2Variable) Term(name string) (*Type2Term, error) {
+	t, exists := v.terms[name]
+	if !exists {
+		return nil, errors.WithStack(ErrUndefinedTerm)
+	}
+
+	return t, nil
+}
+
+func (v *Type2Variable) UniverseMin() float64 {
+	return v.universeMin
+}
+
+func (v *Type2Variable) UniverseMax() float64 {
+	return v.universeMax
+}
+
+func NewType2Variable(name string, terms ...*Type2Term) *Type2Variable {
+	indexedTerms := make(map[string]*Type2Term, len(terms))
+	universeMin := math.Inf(1)
+	universeMax := math.Inf(-1)
+
+	for _, t := range terms {
+		if _, exists := indexedTerms[t.Name()]; exists {
+			panic(errors.WithStack(ErrTermAlreadyExists))
+		}
+
+		indexedTerms[t.Name()] = t
+		min, max := membershipsDomain([]Membership{t.lower, t.upper})
+		universeMin = math.Min(universeMin, min)
+		universeMax = math.Max(universeMax, max)
+	}
+
+	return &Type2Variable{
+		name:        name,
+		terms:       indexedTerms,
+		universeMin: universeMin,
+		universeMax: universeMax,
+	}
+}
+
+type Type2Results map[string]map[string]Type2Result
+
+// Type2Result is the interval type-2 conclusion of the rules on a term
+type Type2Result struct {
+	term        string
+	truthDegree Interval
+	lower       Membership
+	upper       Membership
+}
+
+func (r Type2Result) Term() string {
+	return r.term
+}
+
+func (r Type2Result) TruthDegree() Interval {
+	return r.truthDegree
+}
+
+func (r Type2Result) Lower() Membership {
+	return r.lower
+}
+
+func (r Type2Result) Upper() Membership {
+	return r.upper
+}
+
+// Type2Variables sets the interval type-2 variables used by InferType2().
+// They are independent of the variables used by Infer().
+func (e *Engine) Type2Variables(variables ...*Type2Variable) *Engine {
+	e.type2Variables = variables
+	return e
+}
+
+// InferType2 runs the rules of the engine over its interval type-2 variables.
+//
+// The premises are evaluated as intervals: AND and OR apply the engine norms to
+// each bound, NOT x returns [1-upper(x), 1-lower(x)]. Each conclusion then
+// applies the engine implication to the lower membership of its term with the
+// lower truth degree, and to the upper membership with the upper truth degree.
+func (e *Engine) InferType2(values Values) (Type2Results, error) {
+	variables := make(map[string]*Type2Variable, len(e.type2Variables))
+	for _, v := range e.type2Variables {
+		variables[v.Name()] = v
+	}
+
+	ctx, err := e.newContext(values)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	results := make(Type2Results)
+
+	for _, r := range e.rules {
+		truthDegree, err := type2Value(r.premise, variables, ctx)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		weighted := Interval{Lower: truthDegree.Lower * r.weight, Upper: truthDegree.Upper * r.weight}
+		if err := e.concludeType2(results, variables, r.conclusions, weighted); err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		// The ELSE conclusions fire with the complement of the premise, i.e. NOT premise
+		complement := Interval{Lower: (1 - truthDegree.Upper) * r.weight, Upper: (1 - truthDegree.Lower) * r.weight}
+		if complement.Upper <= 0 {
+			continue
+		}
+
+		if err := e.concludeType2(results, variables, r.alternatives, complement); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	return results, nil
+}
+
+// concludeType2 adds the interval type-2 results of the conclusions for the given truth degree
+func (e *Engine) concludeType2(results Type2Results, variables map[string]*Type2Variable, conclusions []*IsExpr, truthDegree Interval) error {
+	for _, c := range conclusions {
+		if _, crisp := c.CrispValue(); crisp {
+			return errors.Wrapf(ErrUnsupportedConclusion, "crisp conclusion '%s = %s'", c.Variable(), c.Term())
+		}
+
+		outputVariable, exists := variables[c.Variable()]
+		if !exists {
+			return errors.WithStack(ErrUndefinedVariable)
+		}
+
+		outputTerm, err := outputVariable.Term(c.Term())
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		terms, exists := results[outputVariable.Name()]
+		if !exists {
+			terms = make(map[string]Type2Result)
+			results[outputVariable.Name()] = terms
+		}
+
+		lower := e.implication(outputTerm.lower, truthDegree.Lower)
+		upper := e.implication(outputTerm.upper, truthDegree.Upper)
+
+		result, exists := terms[outputTerm.Name()]
+		if exists {
+			result.lower = e.aggregation(result.lower, lower)
+			result.upper = e.aggregation(result.upper, upper)
+			result.truthDegree.Lower = math.Max(result.truthDegree.Lower, truthDegree.Lower)
+			result.truthDegree.Upper = math.Max(result.truthDegree.Upper, truthDegree.Upper)
+		} else {
+			result = Type2Result{
+				term:        outputTerm.Name(),
+				truthDegree: truthDegree,
+				lower:       lower,
+				upper:       upper,
+			}
+		}
+
+		terms[outputTerm.Name()] = result
+	}
+
+	return nil
+}
+
+// DefuzzifyType2 type-reduces and defuzzifies the interval type-2 results of the given variable.
+//
+// The type reduction follows the Nie-Tan method: the engine defuzzification is applied
+// to the average of the aggregated lower and upper memberships. With the centroid
+// defuzzification, this is a closed-form approximation of the Karnik-Mendel centroid.
+func (e *Engine) DefuzzifyType2(variableName string, results Type2Results) (float64, error) {
+	var targetVariable *Type2Variable
+	for _, v := range e.type2Variables {
+		if v.Name() == variableName {
+			targetVariable = v
+			break
+		}
+	}
+
+	if targetVariable == nil {
+		return 0, errors.WithStack(ErrUndefinedVariable)
+	}
+
+	variableResults, ok := results[variableName]
+	if !ok || len(variableResults) == 0 {
+		return (targetVariable.UniverseMin() + targetVariable.UniverseMax()) / 2, nil
+	}
+
+	lowers := make([]Membership, 0, len(variableResults))
+	uppers := make([]Membership, 0, len(variableResults))
+	for _, res := range variableResults {
+		lowers = append(lowers, res.lower)
+		uppers = append(uppers, res.upper)
+	}
+
+	reduced := Scale(Sum(e.aggregation(lowers...), e.aggregation(uppers...)), 0.5)
+
+	return e.defuzzifier(variableName).Defuzzify(reduced, targetVariable.UniverseMin(), targetVariable.UniverseMax()), nil
+}
+
+// type2Value evaluates the given expression as an interval truth degree
+func type2Value(expr Expr, variables map[string]*Type2Variable, ctx *Context) (Interval, error) {
+	switch typ := expr.(type) {
+	case *IsExpr:
+		variable, exists := variables[typ.variable]
+		if !exists {
+			return Interval{}, errors.WithStack(ErrUndefinedVariable)
+		}
+
+		term, err := variable.Term(typ.term)
+		if err != nil {
+			return Interval{}, errors.WithStack(err)
+		}
+
+		value, err := ctx.Value(typ.variable)
+		if err != nil {
+			return Interval{}, errors.WithStack(err)
+		}
+
+		return term.Value(value), nil
+
+	case *AndExpr:
+		var result Interval
+		for i, e := range typ.exprs {
+			v, err := type2Value(e, variables, ctx)
+			if err != nil {
+				return Interval{}, errors.WithStack(err)
+			}
+
+			if i == 0 {
+				result = v
+				continue
+			}
+
+			result.Lower = ctx.norms.TNorm(result.Lower, v.Lower)
+			result.Upper = ctx.norms.TNorm(result.Upper, v.Upper)
+		}
+		return result, nil
+
+	case *OrExpr:
+		var result Interval
+		for i, e := range typ.exprs {
+			v, err := type2Value(e, variables, ctx)
+			if err != nil {
+				return Interval{}, errors.WithStack(err)
+			}
+
+			if i == 0 {
+				result = v
+				continue
+			}
+
+			result.Lower = ctx.norms.SNorm(result.Lower, v.Lower)
+			result.Upper = ctx.norms.SNorm(result.Upper, v.Upper)
+		}
+		return result, nil
+
+	case *XorExpr:
+		var result Interval
+		for i, e := range typ.exprs {
+			v, err := type2Value(e, variables, ctx)
+			if err != nil {
+				return Interval{}, errors.WithStack(err)
+			}
+
+			if i == 0 {
+				result = v
+				continue
+			}
+
+			// The norms being monotonic, the bounds combine the opposite bounds of the negated operands
+			result = Interval{
+				Lower: ctx.norms.SNorm(ctx.norms.TNorm(result.Lower, 1-v.Upper), ctx.norms.TNorm(1-result.Upper, v.Lower)),
+				Upper: ctx.norms.SNorm(ctx.norms.TNorm(result.Upper, 1-v.Lower), ctx.norms.TNorm(1-result.Lower, v.Upper)),
+			}
+		}
+		return result, nil
+
+	case *NotExpr:
+		v, err := type2Value(typ.expr, variables, ctx)
+		if err != nil {
+			return Interval{}, errors.WithStack(err)
+		}
+
+		return Interval{Lower: 1 - v.Upper, Upper: 1 - v.Lower}, nil
+
+	case *HedgeExpr:
+		v, err := type2Value(typ.expr, variables, ctx)
+		if err != nil {
+			return Interval{}, errors.WithStack(err)
+		}
+
+		return Interval{Lower: math.Pow(v.Lower, typ.exponent), Upper: math.Pow(v.Upper, typ.exponent)}, nil
+
+	case *WeightedAndExpr:
+		var result Interval
+		var totalWeight float64
+		for i, e := range typ.exprs {
+			v, err := type2Value(e, variables, ctx)
+			if err != nil {
+				return Interval{}, errors.WithStack(err)
+			}
+
+			result.Lower += typ.weights[i] * v.Lower
+			result.Upper += typ.weights[i] * v.Upper
+			totalWeight += typ.weights[i]
+		}
+		return Interval{Lower: result.Lower / totalWeight, Upper: result.Upper / totalWeight}, nil
+
+	case *FuncExpr, *CompareExpr:
+		// Crisp truth degrees
+		v, err := typ.Value(ctx)
+		if err != nil {
+			return Interval{}, errors.WithStack(err)
+		}
+
+		return Interval{Lower: v, Upper: v}, nil
+
+	default:
+		return Interval{}, errors.Wrapf(ErrUnsupportedExpr, "expression '%T'", expr)
+	}
+}
@@ -0,0 +1,110 @@
+package fuzzy
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestInferType2(t *testing.T) {
+	engine := NewEngine(Centroid(1000)).
+		Type2Variables(
+			NewType2Variable(
+				"temperature",
+				NewType2Term("cold", Inverted(Linear(5, 15)), Inverted(Linear(10, 20))),
+				NewType2Term("hot", Linear(20, 30), Linear(10, 30)),
+			),
+			NewType2Variable(
+				"ac_mode",
+				NewType2Term("heating", Linear(0, 100), Linear(0, 100)),
+				NewType2Term("cooling", Inverted(Linear(-100, 0)), Inverted(Linear(-100, 0))),
+			),
+		).
+		Rules(
+			If(Is("temperature", "cold")).Then("ac_mode", "heating"),
+			If(Is("temperature", "hot")).Then("ac_mode", "cooling"),
+			If(Not(Is("temperature", "hot"))).Then("ac_mode", "heating"),
+		)
+
+	results, err := engine.InferType2(Values{"temperature": 25})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := results["ac_mode"]["cooling"].TruthDegree(), (Interval{Lower: 0.5, Upper: 0.75}); g != e {
+		t.Errorf("ac_mode IS cooling: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := results["ac_mode"]["heating"].TruthDegree(), (Interval{Lower: 0.25, Upper: 0.5}); g != e {
+		t.Errorf("ac_mode IS heating: got '%v', expected '%v'", g, e)
+	}
+
+	value, err := engine.DefuzzifyType2("ac_mode", results)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if value >= 0 || value <= -100 {
+		t.Errorf("engine.DefuzzifyType2(): got '%v', expected a cooling value in ]-100, 0[", value)
+	}
+
+	if _, err := engine.InferType2(Values{}); !errors.Is(err, ErrValueNotFound) {
+		t.Errorf("engine.InferType2(): got error '%v', expected '%v'", err, ErrValueNotFound)
+	}
+}
+
+func TestInferType2WithoutUncertainty(t *testing.T) {
+	rules := []*Rule{
+		If(Is("temperature", "cold")).Then("ac_mode", "heating"),
+		If(Or(Is("temperature", "hot"), Not(Is("temperature", "cold")))).Then("ac_mode", "cooling"),
+	}
+
+	// A type-2 term whose lower and upper memberships are identical behaves as a type-1 term
+	engine := NewEngine(Centroid(1000)).
+		Variables(
+			NewVariable("temperature", NewTerm("cold", Inverted(Linear(5, 15))), NewTerm("hot", Linear(20, 30))),
+			NewVariable("ac_mode", NewTerm("heating", Linear(0, 100)), NewTerm("cooling", Inverted(Linear(-100, 0)))),
+		).
+		Type2Variables(
+			NewType2Variable(
+				"temperature",
+				NewType2Term("cold", Inverted(Linear(5, 15)), Inverted(Linear(5, 15))),
+				NewType2Term("hot", Linear(20, 30), Linear(20, 30)),
+			),
+			NewType2Variable(
+				"ac_mode",
+				NewType2Term("heating", Linear(0, 100), Linear(0, 100)),
+				NewType2Term("cooling", Inverted(Linear(-100, 0)), Inverted(Linear(-100, 0))),
+			),
+		).
+		Rules(rules...)
+
+	for _, temperature := range []float64{0, 10, 12, 25, 30} {
+		values := Values{"temperature": temperature}
+
+		results, err := engine.Infer(values)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		expected, err := engine.Defuzzify("ac_mode", results)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		type2Results, err := engine.InferType2(values)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		value, err := engine.DefuzzifyType2("ac_mode", type2Results)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if g, e := value, expected; math.Abs(g-e) > 1e-9 {
+			t.Errorf("engine.DefuzzifyType2() with temperature=%v: got '%v', expected '%v'", temperature, g, e)
+		}
+	}
+}
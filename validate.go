@@ -0,0 +1,103 @@
+package fuzzy
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ValidationErrors lists the problems of an engine definition found by Engine.Validate()
+type ValidationErrors []error
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the individual problems, i.e. for errors.Is(err, ErrUndefinedTerm)
+func (e ValidationErrors) Unwrap() []error {
+	return e
+}
+
+// Validate checks that the variables of the engine have distinct names and that every
+// rule premise and conclusion references a defined variable and term, which Infer()
+// would otherwise only report when reaching the faulty rule. Crisp conclusions may
+// target undefined variables. Type-2 variables are not taken into account.
+//
+// All the problems are reported at once as ValidationErrors, each one wrapping
+// ErrVariableAlreadyExists, ErrUndefinedVariable, ErrUndefinedTerm or ErrMissingArguments.
+func (e *Engine) Validate() error {
+	var errs ValidationErrors
+
+	if e.variableIndexErr != nil {
+		errs = append(errs, e.variableIndexErr)
+	}
+
+	checkTerm := func(rule int, variableName, termName string) {
+		variable, err := e.variable(variableName)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "rule #%d: variable '%s'", rule, variableName))
+			return
+		}
+
+		if _, err := variable.Term(termName); err != nil {
+			errs = append(errs, errors.Wrapf(err, "rule #%d: term '%s' of variable '%s'", rule, termName, variableName))
+		}
+	}
+
+	for i, r := range e.rules {
+		if len(r.conclusions) == 0 {
+			errs = append(errs, errors.Wrapf(ErrMissingArguments, "rule #%d: no conclusion", i))
+		}
+
+		undefined := make(map[string]struct{})
+		for _, name := range premiseVariables(r.premise) {
+			if _, reported := undefined[name]; reported {
+				continue
+			}
+
+			if _, err := e.variable(name); err != nil {
+				errs = append(errs, errors.Wrapf(err, "rule #%d: variable '%s'", i, name))
+				undefined[name] = struct{}{}
+			}
+		}
+
+		for _, is := range premiseIsExprs(r.premise) {
+			if _, reported := undefined[is.variable]; !reported {
+				checkTerm(i, is.variable, is.term)
+			}
+		}
+
+		for _, c := range slices.Concat(r.conclusions, r.alternatives) {
+			if _, crisp := c.CrispValue(); crisp {
+				continue
+			}
+
+			checkTerm(i, c.variable, c.term)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// premiseIsExprs returns the IS expressions of the premise
+func premiseIsExprs(e Expr) []*IsExpr {
+	var exprs []*IsExpr
+
+	Walk(e, func(e Expr) {
+		if is, ok := e.(*IsExpr); ok {
+			exprs = append(exprs, is)
+		}
+	})
+
+	return exprs
+}
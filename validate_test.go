@@ -0,0 +1,71 @@
+package fuzzy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestValidate(t *testing.T) {
+	variables := []*Variable{
+		NewVariable("temperature", NewTerm("cold", Inverted(Linear(0, 10))), NewTerm("hot", Linear(20, 30))),
+		NewVariable("ac_mode", NewTerm("heating", Linear(0, 100)), NewTerm("cooling", Inverted(Linear(-100, 0)))),
+	}
+
+	valid := NewEngine(nil).Variables(variables...).Rules(
+		If(Is("temperature", "cold")).Then("ac_mode", "heating"),
+		If(Very(Is("temperature", "hot"))).Then("ac_mode", "cooling").ElseValue("fan_speed", 0),
+	)
+
+	if err := valid.Validate(); err != nil {
+		t.Errorf("valid.Validate(): got '%v', expected no error", err)
+	}
+
+	invalid := NewEngine(nil).Variables(variables...).Rules(
+		If(Is("temperature", "cold")).Then("ac_mode", "heating"),
+		If(And(Is("humidity", "high"), Is("humidity", "low"))).Then("ac_mode", "cooling"),
+		If(Is("temperature", "freezing")).Then("ac_mode", "defrost"),
+		If(Is("temperature", "hot")).Then("fan", "fast"),
+	)
+
+	err := invalid.Validate()
+	if err == nil {
+		t.Fatalf("invalid.Validate(): expected an error")
+	}
+
+	var validationErrors ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		t.Fatalf("invalid.Validate(): got '%T', expected '%T'", err, validationErrors)
+	}
+
+	expected := []string{
+		"rule #1: variable 'humidity': undefined variable",
+		"rule #2: term 'freezing' of variable 'temperature': undefined term",
+		"rule #2: term 'defrost' of variable 'ac_mode': undefined term",
+		"rule #3: variable 'fan': undefined variable",
+	}
+
+	if g, e := len(validationErrors), len(expected); g != e {
+		t.Fatalf("len(validationErrors): got '%v', expected '%v' (%v)", g, e, err)
+	}
+
+	for i, e := range expected {
+		if g := validationErrors[i].Error(); g != e {
+			t.Errorf("validationErrors[%d]: got '%v', expected '%v'", i, g, e)
+		}
+	}
+
+	if !errors.Is(err, ErrUndefinedVariable) || !errors.Is(err, ErrUndefinedTerm) {
+		t.Errorf("invalid.Validate(): expected to wrap '%v' and '%v'", ErrUndefinedVariable, ErrUndefinedTerm)
+	}
+
+	duplicate := NewEngine(nil).Variables(variables[0], variables[0])
+	if err := duplicate.Validate(); !errors.Is(err, ErrVariableAlreadyExists) {
+		t.Errorf("duplicate.Validate(): got '%v', expected '%v'", err, ErrVariableAlreadyExists)
+	}
+
+	if err := duplicate.Validate(); !strings.Contains(err.Error(), "temperature") {
+		t.Errorf("duplicate.Validate(): got '%v', expected to name the variable", err)
+	}
+}
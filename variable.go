@@ -12,6 +12,11 @@ type Variable struct {
 
 	universeMin float64
 	universeMax float64
+
+	// customUniverse is true when the universe was set with WithUniverse()
+	customUniverse bool
+
+	metadata map[string]string
 }
 
 func (v *Variable) Name() string {
@@ -43,14 +48,71 @@ func (v *Variable) UniverseMax() float64 {
 	return v.universeMax
 }
 
+// WithUniverse overrides the universe of the variable, computed by default from the
+// domains of its terms, i.e. to defuzzify over the whole actuator range when the
+// terms do not span it. It panics if min is greater than max.
+func (v *Variable) WithUniverse(min, max float64) *Variable {
+	if min > max {
+		panic(errors.Errorf("invalid universe [%v, %v] for variable '%s'", min, max, v.name))
+	}
+
+	v.universeMin = min
+	v.universeMax = max
+	v.customUniverse = true
+
+	return v
+}
+
+// Metadata returns the arbitrary key/value pairs attached to the variable.
+// Metadata are ignored by the inference.
+func (v *Variable) Metadata() map[string]string {
+	return v.metadata
+}
+
+// WithMetadata attaches an arbitrary key/value pair to the variable
+func (v *Variable) WithMetadata(key, value string) *Variable {
+	if v.metadata == nil {
+		v.metadata = make(map[string]string)
+	}
+
+	v.metadata[key] = value
+
+	return v
+}
+
+// AnyActivates returns true if at least one of the variable's terms has a
+// membership degree greater than zero for x
+func (v *Variable) AnyActivates(x float64) bool {
+	for _, t := range v.terms {
+		if t.Activates(x) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewVariable creates a variable from the given terms.
+// It panics if two terms share the same name, see NewVariableChecked().
 func NewVariable(name string, terms ...*Term) *Variable {
+	v, err := NewVariableChecked(name, terms...)
+	if err != nil {
+		panic(errors.WithStack(err))
+	}
+
+	return v
+}
+
+// NewVariableChecked creates a variable from the given terms, returning an error
+// wrapping ErrTermAlreadyExists if two terms share the same name
+func NewVariableChecked(name string, terms ...*Term) (*Variable, error) {
 	indexedTerms := make(map[string]*Term, len(terms))
 	universeMin := math.Inf(1)
 	universeMax := math.Inf(-1)
 
 	for _, t := range terms {
 		if _, exists := indexedTerms[t.Name()]; exists {
-			panic(errors.WithStack(ErrTermAlreadyExists))
+			return nil, errors.Wrapf(ErrTermAlreadyExists, "term '%s' of variable '%s'", t.Name(), name)
 		}
 
 		indexedTerms[t.Name()] = t
@@ -64,12 +126,13 @@ func NewVariable(name string, terms ...*Term) *Variable {
 		terms:       indexedTerms,
 		universeMin: universeMin,
 		universeMax: universeMax,
-	}
+	}, nil
 }
 
 type Term struct {
 	name       string
 	membership Membership
+	weight     float64
 }
 
 func (t *Term) Name() string {
@@ -84,9 +147,87 @@ func (t *Term) Domain() (float64, float64) {
 	return t.membership.Domain()
 }
 
+// Weight returns the weight scaling the contribution of the term
+// to the aggregated membership used for defuzzification. Defaults to 1.
+func (t *Term) Weight() float64 {
+	return t.weight
+}
+
+// WithWeight sets the weight scaling the contribution of the term
+// to the aggregated membership used for defuzzification
+func (t *Term) WithWeight(weight float64) *Term {
+	t.weight = weight
+	return t
+}
+
+// Activates returns true if x belongs to the support of the term,
+// i.e. its membership degree is greater than zero
+func (t *Term) Activates(x float64) bool {
+	return t.membership.Value(x) > 0
+}
+
 func NewTerm(name string, membership Membership) *Term {
 	return &Term{
 		name:       name,
 		membership: membership,
+		weight:     1,
+	}
+}
+
+// UnionTerm returns a term whose membership is the maximum of the memberships
+// of the given terms, i.e. UnionTerm("extreme", cold, hot). It panics if no term
+// is given, see UnionTermChecked().
+func UnionTerm(name string, terms ...*Term) *Term {
+	term, err := UnionTermChecked(name, terms...)
+	if err != nil {
+		panic(errors.WithStack(err))
+	}
+
+	return term
+}
+
+// UnionTermChecked returns a term whose membership is the maximum of the memberships
+// of the given terms, or an error wrapping ErrMissingArguments if no term is given
+func UnionTermChecked(name string, terms ...*Term) (*Term, error) {
+	if len(terms) == 0 {
+		return nil, errors.Wrapf(ErrMissingArguments, "union term '%s'", name)
+	}
+
+	return NewTerm(name, Max(termMemberships(terms)...)), nil
+}
+
+// IntersectTerm returns a term whose membership is the minimum of the memberships
+// of the given terms, i.e. IntersectTerm("warm_and_humid", warm, humid). It panics
+// if no term is given, see IntersectTermChecked().
+func IntersectTerm(name string, terms ...*Term) *Term {
+	term, err := IntersectTermChecked(name, terms...)
+	if err != nil {
+		panic(errors.WithStack(err))
+	}
+
+	return term
+}
+
+// IntersectTermChecked returns a term whose membership is the minimum of the memberships
+// of the given terms, or an error wrapping ErrMissingArguments if no term is given
+func IntersectTermChecked(name string, terms ...*Term) (*Term, error) {
+	if len(terms) == 0 {
+		return nil, errors.Wrapf(ErrMissingArguments, "intersect term '%s'", name)
+	}
+
+	return NewTerm(name, Min(termMemberships(terms)...)), nil
+}
+
+// ComplementTerm returns a term whose membership is the complement of the membership
+// of the given term, i.e. ComplementTerm("not_cold", cold)
+func ComplementTerm(name string, term *Term) *Term {
+	return NewTerm(name, Inverted(term.Membership()))
+}
+
+func termMemberships(terms []*Term) []Membership {
+	memberships := make([]Membership, 0, len(terms))
+	for _, t := range terms {
+		memberships = append(memberships, t.Membership())
 	}
+	return memberships
 }
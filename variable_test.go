@@ -0,0 +1,240 @@
+package fuzzy
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestActivates(t *testing.T) {
+	cold := NewTerm("cold", Inverted(Linear(0, 10)))
+	hot := NewTerm("hot", Linear(20, 30))
+
+	temperature := NewVariable("temperature", cold, hot)
+
+	testCases := []struct {
+		x           float64
+		cold        bool
+		hot         bool
+		anyActivate bool
+	}{
+		{x: -5, cold: true, hot: false, anyActivate: true},
+		{x: 5, cold: true, hot: false, anyActivate: true},
+		{x: 10, cold: false, hot: false, anyActivate: false},
+		{x: 15, cold: false, hot: false, anyActivate: false},
+		{x: 20, cold: false, hot: false, anyActivate: false},
+		{x: 25, cold: false, hot: true, anyActivate: true},
+	}
+
+	for _, tc := range testCases {
+		if g, e := cold.Activates(tc.x), tc.cold; g != e {
+			t.Errorf("cold.Activates(%v): got '%v', expected '%v'", tc.x, g, e)
+		}
+
+		if g, e := hot.Activates(tc.x), tc.hot; g != e {
+			t.Errorf("hot.Activates(%v): got '%v', expected '%v'", tc.x, g, e)
+		}
+
+		if g, e := temperature.AnyActivates(tc.x), tc.anyActivate; g != e {
+			t.Errorf("temperature.AnyActivates(%v): got '%v', expected '%v'", tc.x, g, e)
+		}
+	}
+}
+
+func TestCrossovers(t *testing.T) {
+	variable := NewVariable(
+		"temperature",
+		NewTerm("cold", Inverted(Linear(0, 20))),
+		NewTerm("warm", Triangular(0, 20, 40)),
+		NewTerm("hot", Linear(30, 40)),
+	)
+
+	crossovers := variable.Crossovers()
+
+	expected := []Crossover{
+		{A: "cold", B: "warm", X: 10, Degree: 0.5},
+		{A: "hot", B: "warm", X: 100.0 / 3, Degree: 1.0 / 3},
+	}
+
+	if g, e := len(crossovers), len(expected); g != e {
+		t.Fatalf("len(crossovers): got '%v', expected '%v' (%v)", g, e, crossovers)
+	}
+
+	for i, e := range expected {
+		g := crossovers[i]
+
+		if g.A != e.A || g.B != e.B {
+			t.Errorf("crossovers[%d] terms: got '%s/%s', expected '%s/%s'", i, g.A, g.B, e.A, e.B)
+		}
+
+		if math.Abs(g.X-e.X) > 1e-6 {
+			t.Errorf("crossovers[%d].X: got '%v', expected '%v'", i, g.X, e.X)
+		}
+
+		if math.Abs(g.Degree-e.Degree) > 1e-6 {
+			t.Errorf("crossovers[%d].Degree: got '%v', expected '%v'", i, g.Degree, e.Degree)
+		}
+	}
+}
+
+func TestNewVariableChecked(t *testing.T) {
+	if _, err := NewVariableChecked("temperature", NewTerm("hot", Linear(20, 30)), NewTerm("cold", Inverted(Linear(0, 10)))); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	_, err := NewVariableChecked("temperature", NewTerm("hot", Linear(20, 30)), NewTerm("hot", Linear(25, 35)))
+	if !errors.Is(err, ErrTermAlreadyExists) {
+		t.Errorf("NewVariableChecked(): got error '%v', expected '%v'", err, ErrTermAlreadyExists)
+	}
+}
+
+func TestInferDuplicateVariables(t *testing.T) {
+	engine := NewEngine(nil).
+		Variables(
+			NewVariable("temperature", NewTerm("hot", Linear(20, 30))),
+			NewVariable("temperature", NewTerm("cold", Inverted(Linear(0, 10)))),
+		).
+		Rules(If(Is("temperature", "hot")).Then("temperature", "cold"))
+
+	if _, err := engine.Infer(Values{"temperature": 25}); !errors.Is(err, ErrVariableAlreadyExists) {
+		t.Errorf("engine.Infer(): got error '%v', expected '%v'", err, ErrVariableAlreadyExists)
+	}
+}
+
+func TestWithUniverse(t *testing.T) {
+	newEngine := func(valve *Variable) *Engine {
+		return NewEngine(Centroid(1000)).
+			Variables(
+				NewVariable("pressure", NewTerm("high", Linear(0, 10))),
+				valve,
+			).
+			Rules(
+				If(Not(Is("pressure", "high"))).Then("valve", "half"),
+				If(Is("pressure", "high")).Then("valve", "open"),
+			)
+	}
+
+	newValve := func() *Variable {
+		return NewVariable(
+			"valve",
+			NewTerm("half", Triangular(0, 25, 50)),
+			NewTerm("open", Linear(50, 100)),
+		)
+	}
+
+	defuzzifyAt := func(engine *Engine) float64 {
+		results, err := engine.Infer(Values{"pressure": 5})
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		value, err := engine.Defuzzify("valve", results)
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		return value
+	}
+
+	valve := newValve().WithUniverse(0, 150)
+
+	if g, e := valve.UniverseMax(), 150.0; g != e {
+		t.Errorf("valve.UniverseMax(): got '%v', expected '%v'", g, e)
+	}
+
+	// The saturated "open" term extends up to the end of the universe,
+	// widening it moves the centroid towards the upper bound
+	bounded := defuzzifyAt(newEngine(newValve()))
+	widened := defuzzifyAt(newEngine(valve))
+
+	if widened <= bounded+10 {
+		t.Errorf("defuzzified value with a widened universe: got '%v', expected more than '%v'", widened, bounded+10)
+	}
+
+	// The universe is preserved by snapshots and JSON definitions
+	snapshot, err := newEngine(valve).Snapshot()
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	restored, err := Restore(snapshot, Centroid(1000))
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := defuzzifyAt(restored), widened; g != e {
+		t.Errorf("restored defuzzified value: got '%v', expected '%v'", g, e)
+	}
+
+	data, err := valve.MarshalJSON()
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	var decoded Variable
+	if err := decoded.UnmarshalJSON(data); err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := decoded.UniverseMax(), 150.0; g != e {
+		t.Errorf("decoded.UniverseMax(): got '%v', expected '%v'", g, e)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("WithUniverse(): expected a panic on an inverted universe")
+		}
+	}()
+
+	newValve().WithUniverse(100, 0)
+}
+
+func TestTermCombinators(t *testing.T) {
+	cold := NewTerm("cold", Inverted(Linear(0, 10)))
+	hot := NewTerm("hot", Linear(20, 30))
+	warm := NewTerm("warm", Triangular(10, 20, 30))
+
+	extreme := UnionTerm("extreme", cold, hot)
+	warmish := IntersectTerm("warmish", warm, hot)
+	notCold := ComplementTerm("not_cold", cold)
+
+	testCases := []struct {
+		term     *Term
+		x        float64
+		expected float64
+	}{
+		{term: extreme, x: 0, expected: 1},
+		{term: extreme, x: 5, expected: 0.5},
+		{term: extreme, x: 15, expected: 0},
+		{term: extreme, x: 25, expected: 0.5},
+		{term: warmish, x: 20, expected: 0},
+		{term: warmish, x: 25, expected: 0.5},
+		{term: warmish, x: 28, expected: 0.2},
+		{term: notCold, x: 0, expected: 0},
+		{term: notCold, x: 5, expected: 0.5},
+		{term: notCold, x: 15, expected: 1},
+	}
+
+	for _, tc := range testCases {
+		if g, e := tc.term.Membership().Value(tc.x), tc.expected; math.Abs(g-e) > 1e-9 {
+			t.Errorf("%s.Value(%v): got '%v', expected '%v'", tc.term.Name(), tc.x, g, e)
+		}
+	}
+
+	if g, e := notCold.Name(), "not_cold"; g != e {
+		t.Errorf("notCold.Name(): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := extreme.Weight(), 1.0; g != e {
+		t.Errorf("extreme.Weight(): got '%v', expected '%v'", g, e)
+	}
+
+	if _, err := UnionTermChecked("none"); !errors.Is(err, ErrMissingArguments) {
+		t.Errorf("UnionTermChecked(): got error '%v', expected '%v'", err, ErrMissingArguments)
+	}
+
+	if _, err := IntersectTermChecked("none"); !errors.Is(err, ErrMissingArguments) {
+		t.Errorf("IntersectTermChecked(): got error '%v', expected '%v'", err, ErrMissingArguments)
+	}
+}
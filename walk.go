@@ -0,0 +1,33 @@
+package fuzzy
+
+// Walk visits the expression and its operands recursively, in depth-first order,
+// each expression being visited before its operands. Leaf expressions (IsExpr,
+// FuncExpr) and unknown expressions are visited without descending into them.
+func Walk(e Expr, visit func(Expr)) {
+	if e == nil {
+		return
+	}
+
+	visit(e)
+
+	switch typ := e.(type) {
+	case *AndExpr:
+		walkAll(typ.exprs, visit)
+	case *OrExpr:
+		walkAll(typ.exprs, visit)
+	case *XorExpr:
+		walkAll(typ.exprs, visit)
+	case *WeightedAndExpr:
+		walkAll(typ.exprs, visit)
+	case *NotExpr:
+		Walk(typ.expr, visit)
+	case *HedgeExpr:
+		Walk(typ.expr, visit)
+	}
+}
+
+func walkAll(exprs []Expr, visit func(Expr)) {
+	for _, e := range exprs {
+		Walk(e, visit)
+	}
+}
@@ -0,0 +1,108 @@
+package fuzzy
+
+import (
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+	expr := And(
+		Is("temperature", "hot"),
+		Or(
+			Is("humidity", "high"),
+			Not(Is("sunshine", "strong")),
+			Very(Is("wind", "strong")),
+		),
+		Xor(Is("door", "open"), Is("window", "open")),
+		WeightedAnd([]float64{1, 2}, Is("a", "b"), Func(func(ctx *Context) (float64, error) { return 1, nil }, "c")),
+	)
+
+	counts := make(map[string]int)
+	var variables []string
+
+	Walk(expr, func(e Expr) {
+		switch typ := e.(type) {
+		case *AndExpr:
+			counts["AND"]++
+		case *OrExpr:
+			counts["OR"]++
+		case *XorExpr:
+			counts["XOR"]++
+		case *WeightedAndExpr:
+			counts["WEIGHTED_AND"]++
+		case *NotExpr:
+			counts["NOT"]++
+		case *HedgeExpr:
+			counts["HEDGE"]++
+		case *FuncExpr:
+			counts["FUNC"]++
+		case *IsExpr:
+			counts["IS"]++
+			variables = append(variables, typ.Variable())
+		}
+	})
+
+	expectedCounts := map[string]int{
+		"AND":          1,
+		"OR":           1,
+		"XOR":          1,
+		"WEIGHTED_AND": 1,
+		"NOT":          1,
+		"HEDGE":        1,
+		"FUNC":         1,
+		"IS":           7,
+	}
+
+	for kind, expected := range expectedCounts {
+		if g, e := counts[kind], expected; g != e {
+			t.Errorf("visited %s expressions: got '%v', expected '%v'", kind, g, e)
+		}
+	}
+
+	// Operands are visited in depth-first order
+	expectedVariables := []string{"temperature", "humidity", "sunshine", "wind", "door", "window", "a"}
+
+	if g, e := len(variables), len(expectedVariables); g != e {
+		t.Fatalf("len(variables): got '%v', expected '%v'", g, e)
+	}
+
+	for i, e := range expectedVariables {
+		if g := variables[i]; g != e {
+			t.Errorf("variables[%d]: got '%v', expected '%v'", i, g, e)
+		}
+	}
+
+	visited := 0
+	Walk(nil, func(e Expr) { visited++ })
+
+	if g, e := visited, 0; g != e {
+		t.Errorf("visited nil expressions: got '%v', expected '%v'", g, e)
+	}
+}
+
+func TestExprAccessors(t *testing.T) {
+	hot, humid, sunny := Is("temperature", "hot"), Is("humidity", "high"), Is("sunshine", "strong")
+
+	or := Or(hot, humid, sunny)
+
+	if g, e := len(or.Exprs()), 3; g != e {
+		t.Fatalf("len(or.Exprs()): got '%v', expected '%v'", g, e)
+	}
+
+	for i, e := range []Expr{hot, humid, sunny} {
+		if g := or.Exprs()[i]; g != e {
+			t.Errorf("or.Exprs()[%d]: got '%v', expected '%v'", i, g, e)
+		}
+	}
+
+	not := Not(or)
+
+	if g, e := not.Expr(), Expr(or); g != e {
+		t.Errorf("not.Expr(): got '%v', expected '%v'", g, e)
+	}
+
+	and := And(not, hot)
+
+	if g, e := and.Exprs()[0], Expr(not); g != e {
+		t.Errorf("and.Exprs()[0]: got '%v', expected '%v'", g, e)
+	}
+}
@@ -0,0 +1,81 @@
+package fuzzy
+
+import (
+	"github.com/pkg/errors"
+)
+
+// WeightedAndExpr combines its operands with their weighted average,
+// i.e. Σ(wi * vi) / Σ(wi) where vi is the truth degree of the i-th operand
+// and wi its weight.
+//
+// Unlike And, which takes the minimum truth degree, the result lies between
+// the minimum and the maximum of the operands, and the operands with the
+// highest weights count more, as in multi-criteria decision systems.
+type WeightedAndExpr struct {
+	weights []float64
+	exprs   []Expr
+}
+
+func (e *WeightedAndExpr) Value(ctx *Context) (float64, error) {
+	var sum, totalWeight float64
+
+	for i, m := range e.exprs {
+		v, err := m.Value(ctx)
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+
+		sum += e.weights[i] * v
+		totalWeight += e.weights[i]
+	}
+
+	return sum / totalWeight, nil
+}
+
+func (e *WeightedAndExpr) Exprs() []Expr {
+	return e.exprs
+}
+
+func (e *WeightedAndExpr) Weights() []float64 {
+	return e.weights
+}
+
+// WeightedAnd returns an expression combining the given expressions with their
+// weighted average. It panics if the number of weights does not match the number of
+// expressions, if a weight is negative or if all the weights are zero, see WeightedAndChecked().
+func WeightedAnd(weights []float64, expr ...Expr) *WeightedAndExpr {
+	weighted, err := WeightedAndChecked(weights, expr...)
+	if err != nil {
+		panic(errors.WithStack(err))
+	}
+
+	return weighted
+}
+
+// WeightedAndChecked returns an expression combining the given expressions with their
+// weighted average, or an error wrapping ErrMissingArguments or ErrInvalidWeights
+// where WeightedAnd() would panic
+func WeightedAndChecked(weights []float64, expr ...Expr) (*WeightedAndExpr, error) {
+	if len(expr) == 0 {
+		return nil, errors.WithStack(ErrMissingArguments)
+	}
+
+	if len(weights) != len(expr) {
+		return nil, errors.Wrapf(ErrInvalidWeights, "got %d weights for %d expressions", len(weights), len(expr))
+	}
+
+	var totalWeight float64
+	for _, w := range weights {
+		if w < 0 {
+			return nil, errors.Wrapf(ErrInvalidWeights, "negative weight '%v'", w)
+		}
+
+		totalWeight += w
+	}
+
+	if totalWeight == 0 {
+		return nil, errors.Wrap(ErrInvalidWeights, "weights sum to zero")
+	}
+
+	return &WeightedAndExpr{weights, expr}, nil
+}
@@ -0,0 +1,109 @@
+package fuzzy
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestWeightedAnd(t *testing.T) {
+	engine := NewEngine(Centroid(100)).
+		Variables(
+			NewVariable("price", NewTerm("low", Inverted(Linear(0, 100)))),
+			NewVariable("quality", NewTerm("high", Linear(0, 10))),
+			NewVariable("score", NewTerm("good", Linear(0, 100))),
+		).
+		Rules(
+			If(WeightedAnd([]float64{1, 3}, Is("price", "low"), Is("quality", "high"))).Then("score", "good"),
+		)
+
+	results, err := engine.Infer(Values{"price": 80, "quality": 6})
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	// (1*0.2 + 3*0.6) / 4
+	if g, e := results["score"]["good"].TruthDegree(), 0.5; math.Abs(g-e) > 1e-9 {
+		t.Errorf("score IS good: got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := FormatRule(engine.rules[0]), "IF WEIGHTED_AND(1: price IS low, 3: quality IS high) THEN score IS good"; g != e {
+		t.Errorf("FormatRule(): got '%v', expected '%v'", g, e)
+	}
+
+	invalidWeights := [][]float64{
+		{1},
+		{1, -1},
+		{0, 0},
+	}
+
+	for _, weights := range invalidWeights {
+		func() {
+			defer func() {
+				err, _ := recover().(error)
+				if !errors.Is(err, ErrInvalidWeights) {
+					t.Errorf("WeightedAnd(%v): got panic '%v', expected '%v'", weights, err, ErrInvalidWeights)
+				}
+			}()
+
+			WeightedAnd(weights, Is("price", "low"), Is("quality", "high"))
+		}()
+	}
+}
+
+func TestWeightedAndRoundTrip(t *testing.T) {
+	engine := NewEngine(Centroid(100)).
+		Variables(
+			NewVariable("temperature", NewTerm("hot", Linear(20, 30))),
+			NewVariable("humidity", NewTerm("wet", Linear(40, 80))),
+			NewVariable("fan", NewTerm("fast", Triangular(50, 75, 100))),
+		).
+		Rules(
+			If(WeightedAnd([]float64{3, 1}, Is("temperature", "hot"), Is("humidity", "wet"))).Then("fan", "fast"),
+		)
+
+	data, err := json.Marshal(engine)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	restored, err := EngineFromJSON(data)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	if g, e := restored.rules[0].String(), engine.rules[0].String(); g != e {
+		t.Errorf("restored rule: got '%v', expected '%v'", g, e)
+	}
+
+	snapshot, err := engine.Snapshot()
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	values := Values{"temperature": 25, "humidity": 80}
+
+	expected, err := engine.Infer(values)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	replayed, err := Replay(snapshot, values)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	results, err := restored.Infer(values)
+	if err != nil {
+		t.Fatalf("%+v", errors.WithStack(err))
+	}
+
+	// (3 * 0.5 + 1 * 1) / 4
+	for name, r := range map[string]Results{"expected": expected, "replayed": replayed, "restored": results} {
+		if g, e := r["fan"]["fast"].TruthDegree(), 0.625; g != e {
+			t.Errorf("%s: fan IS fast: got '%v', expected '%v'", name, g, e)
+		}
+	}
+}
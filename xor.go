@@ -0,0 +1,65 @@
+package fuzzy
+
+import (
+	"github.com/pkg/errors"
+)
+
+// XorExpr is the exclusive disjunction of its operands: it is true when exactly
+// one of two operands is true.
+//
+// The operands are combined as S(T(a, 1-b), T(1-a, b)) with the norms of the context,
+// i.e. max(min(a, 1-b), min(1-a, b)) by default, rather than |a - b|, so that the
+// result is consistent with the AND, OR and NOT operators of the engine. As a
+// consequence, XOR of two half-true conditions is 0.5, not 0. Operands are combined
+// from left to right.
+type XorExpr struct {
+	exprs []Expr
+}
+
+// Value combines the operands with the exclusive disjunction, from left to right
+func (e *XorExpr) Value(ctx *Context) (float64, error) {
+	var result float64
+
+	for i, m := range e.exprs {
+		v, err := m.Value(ctx)
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+
+		if i == 0 {
+			result = v
+			continue
+		}
+
+		result = xor(ctx.norms, result, v)
+	}
+
+	return result, nil
+}
+
+func (e *XorExpr) Exprs() []Expr {
+	return e.exprs
+}
+
+func Xor(expr ...Expr) *XorExpr {
+	if len(expr) == 0 {
+		panic(errors.WithStack(ErrMissingArguments))
+	}
+
+	return &XorExpr{expr}
+}
+
+// Nand returns the negation of the conjunction of the expressions, NOT (a AND b)
+func Nand(expr ...Expr) *NotExpr {
+	return Not(And(expr...))
+}
+
+// Nor returns the negation of the disjunction of the expressions, NOT (a OR b)
+func Nor(expr ...Expr) *NotExpr {
+	return Not(Or(expr...))
+}
+
+// xor returns the exclusive disjunction of a and b with the given norms
+func xor(norms Norms, a, b float64) float64 {
+	return norms.SNorm(norms.TNorm(a, 1-b), norms.TNorm(1-a, b))
+}
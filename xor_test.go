@@ -0,0 +1,61 @@
+package fuzzy
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestXor(t *testing.T) {
+	engine := NewEngine(Centroid(100)).
+		Variables(
+			NewVariable("heater", NewTerm("on", Linear(0, 10))),
+			NewVariable("cooler", NewTerm("on", Linear(0, 10))),
+			NewVariable("comfort", NewTerm("stable", Linear(0, 100))),
+		).
+		Rules(
+			If(Xor(Is("heater", "on"), Is("cooler", "on"))).Then("comfort", "stable"),
+			If(Nand(Is("heater", "on"), Is("cooler", "on"))).ThenValue("nand", 1),
+			If(Nor(Is("heater", "on"), Is("cooler", "on"))).ThenValue("nor", 1),
+		)
+
+	testCases := []struct {
+		heater, cooler float64
+		xor, nand, nor float64
+	}{
+		{heater: 0, cooler: 0, xor: 0, nand: 1, nor: 1},
+		{heater: 10, cooler: 0, xor: 1, nand: 1, nor: 0},
+		{heater: 0, cooler: 10, xor: 1, nand: 1, nor: 0},
+		{heater: 10, cooler: 10, xor: 0, nand: 0, nor: 0},
+		{heater: 5, cooler: 5, xor: 0.5, nand: 0.5, nor: 0.5},
+		{heater: 8, cooler: 3, xor: 0.7, nand: 0.7, nor: 0.2},
+	}
+
+	for _, tc := range testCases {
+		results, err := engine.Infer(Values{"heater": tc.heater, "cooler": tc.cooler})
+		if err != nil {
+			t.Fatalf("%+v", errors.WithStack(err))
+		}
+
+		if g, e := results["comfort"]["stable"].TruthDegree(), tc.xor; math.Abs(g-e) > 1e-9 {
+			t.Errorf("XOR(%v, %v): got '%v', expected '%v'", tc.heater, tc.cooler, g, e)
+		}
+
+		if g, e := results["nand"]["1"].TruthDegree(), tc.nand; math.Abs(g-e) > 1e-9 {
+			t.Errorf("NAND(%v, %v): got '%v', expected '%v'", tc.heater, tc.cooler, g, e)
+		}
+
+		if g, e := results["nor"]["1"].TruthDegree(), tc.nor; math.Abs(g-e) > 1e-9 {
+			t.Errorf("NOR(%v, %v): got '%v', expected '%v'", tc.heater, tc.cooler, g, e)
+		}
+	}
+
+	if g, e := FormatRule(engine.rules[0]), "IF heater IS on XOR cooler IS on THEN comfort IS stable"; g != e {
+		t.Errorf("FormatRule(): got '%v', expected '%v'", g, e)
+	}
+
+	if g, e := FormatRule(engine.rules[1]), "IF NOT (heater IS on AND cooler IS on) THEN nand = 1"; g != e {
+		t.Errorf("FormatRule(): got '%v', expected '%v'", g, e)
+	}
+}